@@ -0,0 +1,204 @@
+// Command customers imports customers in bulk from CSV or NDJSON and exports
+// them with their favorite ids, without going through the API:
+//
+//	customers import partner.csv
+//	customers import -format ndjson < partner.ndjson
+//	customers export -format csv customers.csv
+//	customers export > customers.ndjson
+//
+// The format defaults to the file extension (.csv, .ndjson or .jsonl), and to
+// NDJSON when exporting to stdout. Logs go to stderr so an export can be piped.
+// An import exits with status 1 when any row failed.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/customerfile"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/tracing"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+	"github.com/juliocsrf/aiqfome-challenge/internal/wire"
+)
+
+var errUsage = errors.New("usage: customers [config flags] import|export [-format csv|ndjson] [FILE]")
+
+func main() {
+	conf, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		fatal("Error loading config", err)
+	}
+
+	appLogger, err := logger.New(os.Stderr, conf.Log.Level, conf.Log.Format)
+	if err != nil {
+		fatal("Error configuring logger", err)
+	}
+	slog.SetDefault(appLogger)
+
+	if len(args) == 0 || (args[0] != "import" && args[0] != "export") {
+		fatal("Unknown command", errUsage)
+	}
+
+	command := args[0]
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	formatName := flags.String("format", "", "csv or ndjson")
+	if err := flags.Parse(args[1:]); err != nil || flags.NArg() > 1 {
+		fatal("Invalid arguments", errUsage)
+	}
+
+	path := flags.Arg(0)
+	format, err := resolveFormat(*formatName, path, command)
+	if err != nil {
+		fatal("Invalid format", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), conf.Tracing.Exporter, conf.Tracing.ServiceName, conf.Tracing.SampleRatio)
+	if err != nil {
+		fatal("Error setting up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	dbConn, err := database.Open(context.Background(), conf.Database)
+	if err != nil {
+		fatal("Error opening database connection", err)
+	}
+	defer dbConn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if command == "import" {
+		failed, err := runImport(ctx, dbConn, conf, format, path)
+		if err != nil {
+			fatal("Customer import failed", err)
+		}
+
+		if failed {
+			dbConn.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := runExport(ctx, dbConn, conf, format, path); err != nil {
+		fatal("Customer export failed", err)
+	}
+}
+
+// resolveFormat prefers the -format flag, then the file extension. Without
+// either, an export to stdout is NDJSON and an import from stdin is an error.
+func resolveFormat(name, path, command string) (customerfile.Format, error) {
+	if name != "" {
+		return customerfile.ParseFormat(name)
+	}
+
+	if path != "" && path != "-" {
+		return customerfile.FormatFromPath(path)
+	}
+
+	if command == "export" {
+		return customerfile.FormatNDJSON, nil
+	}
+
+	return "", fmt.Errorf("-format is required when reading from stdin: %w", customerfile.ErrUnknownFormat)
+}
+
+func runImport(ctx context.Context, db *pgxpool.Pool, conf *config.Conf, format customerfile.Format, path string) (bool, error) {
+	input := io.Reader(os.Stdin)
+	if path != "" && path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return false, err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	rows, err := customerfile.ReadRows(format, input)
+	if err != nil {
+		return false, err
+	}
+
+	importUseCase, err := wire.InitializeCustomerImport(db, conf)
+	if err != nil {
+		return false, err
+	}
+
+	// An interrupted import still lists what it got through before failing.
+	report, err := importUseCase.Execute(ctx, rows)
+	if report == nil {
+		return false, err
+	}
+
+	for _, rowError := range report.Errors {
+		status := "failed"
+		if rowError.Skipped {
+			status = "skipped"
+		}
+		fmt.Fprintf(os.Stdout, "line %d %s (%s): %s\n", rowError.Line, status, rowError.Email, rowError.Error)
+	}
+
+	slog.Info("customers imported",
+		slog.Int("total", report.Total),
+		slog.Int("created", report.Created),
+		slog.Int("skipped", report.Skipped),
+		slog.Int("failed", report.Failed),
+	)
+
+	return report.Failed > 0, err
+}
+
+func runExport(ctx context.Context, db *pgxpool.Pool, conf *config.Conf, format customerfile.Format, path string) error {
+	output := io.Writer(os.Stdout)
+	if path != "" && path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+
+	exportUseCase, err := wire.InitializeCustomerExport(db, conf)
+	if err != nil {
+		return err
+	}
+
+	writer, err := customerfile.NewWriter(format, output)
+	if err != nil {
+		return err
+	}
+
+	exported := 0
+	err = exportUseCase.Execute(ctx, func(export *entity.CustomerExport) error {
+		exported++
+		return writer.Write(export)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	slog.Info("customers exported", slog.Int("customers", exported))
+	return nil
+}
+
+func fatal(message string, err error) {
+	slog.Error(message, slog.Any("error", err))
+	os.Exit(1)
+}
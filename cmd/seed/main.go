@@ -0,0 +1,68 @@
+// Command seed creates the SEED_USERS users and, when APP_ENV is development,
+// SEED_CUSTOMERS fake customers with SEED_FAVORITES_PER_CUSTOMER random
+// favorites from the product provider. It is safe to run more than once. In
+// any other environment it is how the first login is created, so it refuses
+// the default admin@admin.com:admin credentials there.
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/tracing"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+	"github.com/juliocsrf/aiqfome-challenge/internal/wire"
+)
+
+func main() {
+	conf, err := config.LoadConfig()
+	if err != nil {
+		fatal("Error loading config", err)
+	}
+
+	appLogger, err := logger.New(os.Stdout, conf.Log.Level, conf.Log.Format)
+	if err != nil {
+		fatal("Error configuring logger", err)
+	}
+	slog.SetDefault(appLogger)
+
+	if !conf.IsDevelopment() && slices.Contains(conf.Seed.Users, config.DefaultSeedUser) {
+		fatal("Refusing to seed", errors.New("SEED_USERS must not use the default credentials outside "+config.EnvDevelopment))
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), conf.Tracing.Exporter, conf.Tracing.ServiceName, conf.Tracing.SampleRatio)
+	if err != nil {
+		fatal("Error setting up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	dbConn, err := database.Open(context.Background(), conf.Database)
+	if err != nil {
+		fatal("Error opening database connection", err)
+	}
+	defer dbConn.Close()
+
+	seedUseCase, err := wire.InitializeSeed(dbConn, conf)
+	if err != nil {
+		fatal("Failed to initialize seed", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if _, err = seedUseCase.Execute(ctx); err != nil {
+		fatal("Seed failed", err)
+	}
+}
+
+func fatal(message string, err error) {
+	slog.Error(message, slog.Any("error", err))
+	os.Exit(1)
+}
@@ -21,89 +21,142 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/golang-migrate/migrate/v4"
 	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/metrics"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/tracing"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
 	"github.com/juliocsrf/aiqfome-challenge/internal/wire"
-
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/lib/pq"
 )
 
 func main() {
-	log.Println("Starting application...")
-
-	conf, err := config.LoadConfig()
+	conf, args, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Fatalf("Error loading config: %v", err)
-	}
-	log.Println("Config loaded successfully")
-
-	log.Println("Opening database connection...")
-	dbConn, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable search_path=%s",
-		conf.Database.Host,
-		conf.Database.Port,
-		conf.Database.User,
-		conf.Database.Password,
-		conf.Database.Name,
-		conf.Database.Schema,
-	))
+		fatal("Error loading config", err)
+	}
+
+	appLogger, err := logger.New(os.Stdout, conf.Log.Level, conf.Log.Format)
 	if err != nil {
-		log.Fatalf("Error opening database connection: %v", err)
+		fatal("Error configuring logger", err)
 	}
-	defer dbConn.Close()
+	slog.SetDefault(appLogger)
+	slog.Info("Config loaded successfully")
 
-	if err = dbConn.Ping(); err != nil {
-		log.Fatalf("Error pinging database: %v", err)
-	}
-	log.Println("Database connection opened successfully")
-
-	log.Println("Running database migrations...")
-	m, err := migrate.New(
-		"file://database/migrations",
-		fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-			conf.Database.User,
-			conf.Database.Password,
-			conf.Database.Host,
-			conf.Database.Port,
-			conf.Database.Name,
-		),
-	)
+	shutdownTracing, err := tracing.Setup(context.Background(), conf.Tracing.Exporter, conf.Tracing.ServiceName, conf.Tracing.SampleRatio)
 	if err != nil {
-		log.Fatalf("Error while running migrations: %v", err)
+		fatal("Error setting up tracing", err)
 	}
 
-	if err = m.Up(); err != nil {
-		if err.Error() != "no change" {
-			log.Fatalf("Error while running migrations: %v", err)
+	if len(args) > 0 {
+		if args[0] != "migrate" {
+			fatal("Unknown command", fmt.Errorf("%q, the only command is migrate", args[0]))
 		}
+
+		if err = runMigrate(context.Background(), conf.Database, args[1:]); err != nil {
+			fatal("Error while running migrations", err)
+		}
+		return
 	}
-	log.Println("Database migrations completed successfully")
 
-	routerInstance, err := wire.InitializeApp(dbConn, conf)
+	if conf.Server.SkipMigrations {
+		slog.Info("Skipping database migrations")
+	} else {
+		slog.Info("Running database migrations...")
+		if err = runMigrate(context.Background(), conf.Database, []string{"up"}); err != nil {
+			fatal("Error while running migrations", err)
+		}
+	}
+
+	slog.Info("Opening database connection...")
+	dbConn, err := database.Open(context.Background(), conf.Database)
 	if err != nil {
-		log.Fatalf("Failed to initialize app: %v", err)
+		fatal("Error opening database connection", err)
 	}
+	defer dbConn.Close()
+	slog.Info("Database connection opened successfully")
 
-	handler := routerInstance.SetupRoutes()
+	if err = metrics.RegisterDatabase(dbConn, conf.Database.Name); err != nil {
+		fatal("Error registering database metrics", err)
+	}
 
-	port := ":8080"
-	if conf.Server.Port != "" {
-		port = ":" + conf.Server.Port
+	app, err := wire.InitializeApp(dbConn, conf)
+	if err != nil {
+		fatal("Failed to initialize app", err)
 	}
 
-	log.Printf("Starting server on port %s", port)
-	log.Println("Available endpoints:")
-	for _, route := range routerInstance.GetAPIRoutes() {
-		log.Printf("  %s %s - %s", route.Method, route.Path, route.Description)
+	handler := app.Router.SetupRoutes()
+
+	port := conf.Server.Addr()
+
+	server := &http.Server{
+		Addr:              port,
+		Handler:           handler,
+		ReadTimeout:       conf.Server.ReadTimeout,
+		ReadHeaderTimeout: conf.Server.ReadHeaderTimeout,
+		WriteTimeout:      conf.Server.WriteTimeout,
+		IdleTimeout:       conf.Server.IdleTimeout,
 	}
+	server.RegisterOnShutdown(app.EventBroker.Close)
 
-	if err := http.ListenAndServe(port, handler); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	slog.Info("Starting server", slog.String("addr", port))
+	for _, route := range app.Router.GetAPIRoutes() {
+		slog.Debug("Route registered", slog.String("method", route.Method), slog.String("path", route.Path), slog.String("description", route.Description))
 	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	for _, job := range app.Schedulers {
+		slog.Info("Starting scheduled job", slog.String("job", job.Name), slog.Duration("interval", job.Interval))
+		job.Start(ctx)
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fatal("Server failed to start", err)
+		}
+	case <-ctx.Done():
+		stop()
+		slog.Info("Shutdown signal received, draining in-flight requests...")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), conf.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Error while shutting down server", slog.Any("error", err))
+	}
+
+	for _, job := range app.Schedulers {
+		job.Stop()
+	}
+
+	if err := app.WebhookDispatcher.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Error while waiting for webhook deliveries", slog.Any("error", err))
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Error while flushing traces", slog.Any("error", err))
+	}
+	slog.Info("Server stopped")
+}
+
+func fatal(message string, err error) {
+	slog.Error(message, slog.Any("error", err))
+	os.Exit(1)
 }
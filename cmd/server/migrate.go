@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up | down N | goto VERSION | version | force VERSION")
+
+// runMigrate is the migrate subcommand, which manages the schema without
+// starting the API:
+//
+//	main migrate up
+//	main migrate down 1
+//	main migrate goto 12
+//	main migrate version
+//	main migrate force 12
+func runMigrate(ctx context.Context, conf config.Database, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	command := args[0]
+	var number int
+	switch command {
+	case "up", "version":
+		if len(args) != 1 {
+			return errMigrateUsage
+		}
+	case "down", "goto", "force":
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+
+		var err error
+		if number, err = strconv.Atoi(args[1]); err != nil || number < 0 || (command == "down" && number == 0) {
+			return fmt.Errorf("invalid %s argument %q: %w", command, args[1], errMigrateUsage)
+		}
+	default:
+		return errMigrateUsage
+	}
+
+	migrator, err := database.NewMigrator(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch command {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down(number)
+	case "goto":
+		err = migrator.Goto(uint(number))
+	case "force":
+		err = migrator.Force(number)
+	}
+	if err != nil {
+		return fmt.Errorf("error while running migrate %s: %s", command, err)
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return fmt.Errorf("error while reading migration version: %s", err)
+	}
+
+	if command == "version" {
+		fmt.Printf("version=%d dirty=%t\n", version, dirty)
+		return nil
+	}
+
+	slog.Info("Database migrations completed successfully", slog.String("command", command), slog.Uint64("version", uint64(version)))
+	return nil
+}
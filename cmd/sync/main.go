@@ -0,0 +1,59 @@
+// Command sync imports the fakestoreapi catalog into the products table once
+// and exits. Run it from cron, or set CATALOG_SYNC_INTERVAL to let the API do it.
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/tracing"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+	"github.com/juliocsrf/aiqfome-challenge/internal/wire"
+)
+
+func main() {
+	conf, err := config.LoadConfig()
+	if err != nil {
+		fatal("Error loading config", err)
+	}
+
+	appLogger, err := logger.New(os.Stdout, conf.Log.Level, conf.Log.Format)
+	if err != nil {
+		fatal("Error configuring logger", err)
+	}
+	slog.SetDefault(appLogger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), conf.Tracing.Exporter, conf.Tracing.ServiceName, conf.Tracing.SampleRatio)
+	if err != nil {
+		fatal("Error setting up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	dbConn, err := database.Open(context.Background(), conf.Database)
+	if err != nil {
+		fatal("Error opening database connection", err)
+	}
+	defer dbConn.Close()
+
+	syncUseCase, err := wire.InitializeSync(dbConn, conf)
+	if err != nil {
+		fatal("Failed to initialize sync", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if _, err = syncUseCase.Execute(ctx); err != nil {
+		fatal("Catalog sync failed", err)
+	}
+}
+
+func fatal(message string, err error) {
+	slog.Error(message, slog.Any("error", err))
+	os.Exit(1)
+}
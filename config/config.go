@@ -1,69 +1,303 @@
 package config
 
 import (
-	"log/slog"
-	"os"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
 
-	"github.com/joho/godotenv"
+	// DefaultJWTSecret only exists so the API runs out of the box in
+	// development; Validate rejects it in any other environment.
+	DefaultJWTSecret = "default-secret-key-change-in-production"
 )
 
+// Conf is the whole application configuration. Every field can be set in the
+// YAML file (yaml tag), overridden by an environment variable (env tag) and
+// then by a command line flag named after the variable, e.g. DB_HOST is
+// -db-host. See Load.
 type Conf struct {
-	Database Database
-	Server   Server
-	Auth     Auth
+	Env            string         `yaml:"env" env:"APP_ENV"`
+	Database       Database       `yaml:"database"`
+	Server         Server         `yaml:"server"`
+	Auth           Auth           `yaml:"auth"`
+	CORS           CORS           `yaml:"cors"`
+	Webhook        Webhook        `yaml:"webhook"`
+	Tracing        Tracing        `yaml:"tracing"`
+	Log            Log            `yaml:"log"`
+	Fakestoreapi   Fakestoreapi   `yaml:"fakestoreapi"`
+	Catalog        Catalog        `yaml:"catalog"`
+	Pricing        Pricing        `yaml:"pricing"`
+	Recommendation Recommendation `yaml:"recommendation"`
+	Favorites      Favorites      `yaml:"favorites"`
+	CustomerImport CustomerImport `yaml:"customer_import"`
+	Seed           Seed           `yaml:"seed"`
+}
+
+// IsDevelopment reports whether the API runs in development mode, where
+// insecure defaults are allowed.
+func (c *Conf) IsDevelopment() bool {
+	return c.Env == EnvDevelopment
 }
 
 type Database struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	Schema   string
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	Schema   string `yaml:"schema" env:"DB_SCHEMA"`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE"`
+
+	// SSLRootCert is the CA bundle used by the verify-ca and verify-full
+	// modes; SSLCert and SSLKey enable client certificate authentication.
+	SSLRootCert string `yaml:"sslrootcert" env:"DB_SSLROOTCERT"`
+	SSLCert     string `yaml:"sslcert" env:"DB_SSLCERT"`
+	SSLKey      string `yaml:"sslkey" env:"DB_SSLKEY"`
+
+	// MaxConns bounds the pool; MinConns connections are kept open even
+	// when idle.
+	MaxConns        int           `yaml:"max_conns" env:"DB_MAX_CONNS"`
+	MinConns        int           `yaml:"min_conns" env:"DB_MIN_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+
+	// ConnectAttempts is how many times startup tries to reach Postgres,
+	// waiting ConnectBackoff after the first failure and doubling it after
+	// each one, so the API can start before the database under docker-compose.
+	ConnectAttempts int           `yaml:"connect_attempts" env:"DB_CONNECT_ATTEMPTS"`
+	ConnectBackoff  time.Duration `yaml:"connect_backoff" env:"DB_CONNECT_BACKOFF"`
+}
+
+// DSN is the libpq style connection string for the configured database. Values
+// are quoted, so passwords may contain spaces, quotes or backslashes.
+func (d Database) DSN() string {
+	params := [][2]string{
+		{"host", d.Host},
+		{"port", strconv.Itoa(d.Port)},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+		{"sslrootcert", d.SSLRootCert},
+		{"sslcert", d.SSLCert},
+		{"sslkey", d.SSLKey},
+		{"search_path", d.Schema},
+	}
+
+	var parts []string
+	for _, param := range params {
+		if param[1] == "" {
+			continue
+		}
+		parts = append(parts, param[0]+"="+quoteDSNValue(param[1]))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func quoteDSNValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
 }
 
 type Server struct {
-	Port string
+	Port              int           `yaml:"port" env:"SERVER_PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+
+	// SkipMigrations leaves the schema alone at startup, for deploys that run
+	// the migrate subcommand as a separate step.
+	SkipMigrations bool `yaml:"skip_migrations" env:"SERVER_SKIP_MIGRATIONS"`
+}
+
+// Addr is the address the HTTP server listens on.
+func (s Server) Addr() string {
+	return fmt.Sprintf(":%d", s.Port)
 }
 
 type Auth struct {
-	JWTSecret string
+	JWTSecret       string        `yaml:"jwt_secret" env:"JWT_SECRET"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" env:"ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env:"REFRESH_TOKEN_TTL"`
 }
 
-func LoadConfig() (*Conf, error) {
-	var err error
-	if err = godotenv.Load(); err != nil {
-		slog.Warn("No .env file found. Using environment variables")
-	}
+type CORS struct {
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+}
 
-	conf := &Conf{
-		Database: Database{},
-		Server:   Server{},
-		Auth:     Auth{},
-	}
+type Webhook struct {
+	MaxAttempts    int           `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	InitialBackoff time.Duration `yaml:"initial_backoff" env:"WEBHOOK_INITIAL_BACKOFF"`
+	Timeout        time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+	// PollInterval is how often pending deliveries are checked for retries.
+	PollInterval time.Duration `yaml:"poll_interval" env:"WEBHOOK_POLL_INTERVAL"`
+	// AllowPrivateNetworks lets webhooks target loopback, link-local and
+	// private addresses, e.g. a receiver in the same docker network.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+}
 
-	// conf.Database.ConnString = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable search_path=%s",
-	// 	os.Getenv("DB_HOST"),
-	// 	os.Getenv("DB_PORT"),
-	// 	os.Getenv("DB_USER"),
-	// 	os.Getenv("DB_PASSWORD"),
-	// 	os.Getenv("DB_NAME"),
-	// 	os.Getenv("DB_SCHEMA"),
-	// )
-
-	conf.Database.Host = os.Getenv("DB_HOST")
-	conf.Database.Port = os.Getenv("DB_PORT")
-	conf.Database.User = os.Getenv("DB_USER")
-	conf.Database.Password = os.Getenv("DB_PASSWORD")
-	conf.Database.Name = os.Getenv("DB_NAME")
-	conf.Database.Schema = os.Getenv("DB_SCHEMA")
-
-	conf.Server.Port = os.Getenv("SERVER_PORT")
-
-	conf.Auth.JWTSecret = os.Getenv("JWT_SECRET")
-	if conf.Auth.JWTSecret == "" {
-		conf.Auth.JWTSecret = "default-secret-key-change-in-production"
-	}
+type Tracing struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName string  `yaml:"service_name" env:"OTEL_SERVICE_NAME"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+type Fakestoreapi struct {
+	BaseURL          string        `yaml:"base_url" env:"FAKESTOREAPI_BASE_URL"`
+	Timeout          time.Duration `yaml:"timeout" env:"FAKESTOREAPI_TIMEOUT"`
+	MaxRetries       int           `yaml:"max_retries" env:"FAKESTOREAPI_MAX_RETRIES"`
+	RetryBackoff     time.Duration `yaml:"retry_backoff" env:"FAKESTOREAPI_RETRY_BACKOFF"`
+	BreakerThreshold int           `yaml:"breaker_threshold" env:"FAKESTOREAPI_BREAKER_THRESHOLD"`
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown" env:"FAKESTOREAPI_BREAKER_COOLDOWN"`
+}
+
+const (
+	ProductProviderFakestoreapi = "fakestoreapi"
+	ProductProviderPostgres     = "postgres"
+	ProductProviderFile         = "file"
+)
+
+type Catalog struct {
+	Provider     string        `yaml:"provider" env:"PRODUCT_PROVIDER"`
+	File         string        `yaml:"file" env:"PRODUCT_CATALOG_FILE"`
+	SyncInterval time.Duration `yaml:"sync_interval" env:"CATALOG_SYNC_INTERVAL"`
+}
+
+type Pricing struct {
+	TrackInterval time.Duration `yaml:"track_interval" env:"PRICE_TRACK_INTERVAL"`
+	Notifier      string        `yaml:"notifier" env:"PRICE_DROP_NOTIFIER"`
+}
 
-	return conf, nil
+type Recommendation struct {
+	RefreshInterval time.Duration `yaml:"refresh_interval" env:"RECOMMENDATION_REFRESH_INTERVAL"`
+}
+
+type Favorites struct {
+	// MaxPerCustomer bounds the products a customer can favorite, unless the
+	// customer has a quota of their own. Zero means no limit.
+	MaxPerCustomer int `yaml:"max_per_customer" env:"FAVORITES_MAX_PER_CUSTOMER"`
+}
+
+// CustomerImport bounds a file uploaded to POST /api/customers/import. The
+// customers command reads local files and is not limited.
+type CustomerImport struct {
+	MaxBytes int `yaml:"max_bytes" env:"CUSTOMER_IMPORT_MAX_BYTES"`
+	MaxRows  int `yaml:"max_rows" env:"CUSTOMER_IMPORT_MAX_ROWS"`
+}
+
+// Seed configures cmd/seed, which creates the Users in any environment and,
+// in development only, fake customers and favorites.
+type Seed struct {
+	// Users are "email:password" pairs, e.g. admin@admin.com:admin.
+	Users                []string `yaml:"users" env:"SEED_USERS"`
+	Customers            int      `yaml:"customers" env:"SEED_CUSTOMERS"`
+	FavoritesPerCustomer int      `yaml:"favorites_per_customer" env:"SEED_FAVORITES_PER_CUSTOMER"`
+	// RandomSeed makes the favorites picked for each customer repeatable.
+	RandomSeed int `yaml:"random_seed" env:"SEED_RANDOM_SEED"`
+}
+
+// DefaultSeedUser only exists so a development database has a login out of
+// the box; cmd/seed refuses it in any other environment.
+const DefaultSeedUser = "admin@admin.com:admin"
+
+// ParseSeedUser splits a Seed.Users entry into its email and password.
+func ParseSeedUser(entry string) (email, password string, ok bool) {
+	email, password, ok = strings.Cut(entry, ":")
+	return email, password, ok && email != "" && password != ""
+}
+
+type Log struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL"`
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+// Default is the configuration before any file, environment variable or flag
+// is applied.
+func Default() *Conf {
+	return &Conf{
+		Env: EnvProduction,
+		Database: Database{
+			Host:            "localhost",
+			Port:            5432,
+			Schema:          "public",
+			SSLMode:         "disable",
+			MaxConns:        25,
+			MinConns:        2,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectAttempts: 10,
+			ConnectBackoff:  time.Second,
+		},
+		Server: Server{
+			Port:              8080,
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      65 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Auth: Auth{
+			JWTSecret:       DefaultJWTSecret,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 7 * 24 * time.Hour,
+		},
+		CORS: CORS{
+			AllowedOrigins: []string{"*"},
+		},
+		Webhook: Webhook{
+			MaxAttempts:    5,
+			InitialBackoff: time.Second,
+			Timeout:        10 * time.Second,
+			PollInterval:   5 * time.Second,
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			ServiceName: "aiqfome-challenge",
+			SampleRatio: 1,
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "json",
+		},
+		Fakestoreapi: Fakestoreapi{
+			BaseURL:          "https://fakestoreapi.com",
+			Timeout:          5 * time.Second,
+			MaxRetries:       2,
+			RetryBackoff:     200 * time.Millisecond,
+			BreakerThreshold: 5,
+			BreakerCooldown:  30 * time.Second,
+		},
+		Catalog: Catalog{
+			Provider: ProductProviderFakestoreapi,
+			File:     "database/catalog/products.json",
+		},
+		Pricing: Pricing{
+			Notifier: "event",
+		},
+		Recommendation: Recommendation{
+			RefreshInterval: 30 * time.Minute,
+		},
+		Favorites: Favorites{
+			MaxPerCustomer: 100,
+		},
+		CustomerImport: CustomerImport{
+			MaxBytes: 10 << 20,
+			MaxRows:  10_000,
+		},
+		Seed: Seed{
+			Users:                []string{DefaultSeedUser},
+			Customers:            20,
+			FavoritesPerCustomer: 5,
+			RandomSeed:           1,
+		},
+	}
 }
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setRequiredEnv(t *testing.T) {
+	t.Setenv("APP_ENV", EnvDevelopment)
+	t.Setenv("DB_USER", "user")
+	t.Setenv("DB_NAME", "aiqfome")
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setRequiredEnv(t)
+
+	conf, args, err := Load(nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, args)
+	assert.Equal(t, 15*time.Minute, conf.Auth.AccessTokenTTL)
+	assert.Equal(t, 7*24*time.Hour, conf.Auth.RefreshTokenTTL)
+	assert.Equal(t, []string{"*"}, conf.CORS.AllowedOrigins)
+	assert.Equal(t, ":8080", conf.Server.Addr())
+	assert.Contains(t, conf.Database.DSN(), "sslmode='disable'")
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	setRequiredEnv(t)
+	path := writeConfigFile(t, `
+server:
+  port: 9000
+  read_timeout: 20s
+database:
+  host: file-host
+  max_conns: 50
+cors:
+  allowed_origins: ["https://file.example"]
+`)
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://a.example, https://b.example")
+
+	conf, args, err := Load([]string{"-config", path, "-db-host", "flag-host", "-access-token-ttl", "5m", "-server-skip-migrations", "migrate", "up"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"migrate", "up"}, args)
+	assert.Equal(t, 9000, conf.Server.Port, "file overrides defaults")
+	assert.Equal(t, 20*time.Second, conf.Server.ReadTimeout)
+	assert.Equal(t, 50, conf.Database.MaxConns)
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, conf.CORS.AllowedOrigins, "env overrides file")
+	assert.Equal(t, "flag-host", conf.Database.Host, "flags override env")
+	assert.Equal(t, 5*time.Minute, conf.Auth.AccessTokenTTL)
+	assert.True(t, conf.Server.SkipMigrations)
+}
+
+func TestLoad_ConfigFileFromEnv(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "log:\n  level: debug\n"))
+
+	conf, _, err := Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", conf.Log.Level)
+}
+
+func TestLoad_InvalidSources(t *testing.T) {
+	setRequiredEnv(t)
+
+	t.Run("unknown file key", func(t *testing.T) {
+		_, _, err := Load([]string{"-config", writeConfigFile(t, "server:\n  prot: 9000\n")})
+		assert.ErrorContains(t, err, "prot")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, _, err := Load([]string{"-config", filepath.Join(t.TempDir(), "missing.yaml")})
+		assert.ErrorContains(t, err, "reading config file")
+	})
+
+	t.Run("renamed env", func(t *testing.T) {
+		t.Setenv("DB_MAX_OPEN_CONNS", "50")
+		_, _, err := Load(nil)
+		assert.ErrorContains(t, err, "DB_MAX_OPEN_CONNS was renamed to DB_MAX_CONNS")
+	})
+
+	t.Run("invalid env value", func(t *testing.T) {
+		t.Setenv("SERVER_READ_TIMEOUT", "fifteen")
+		_, _, err := Load(nil)
+		assert.ErrorContains(t, err, `SERVER_READ_TIMEOUT: invalid duration "fifteen"`)
+	})
+
+	t.Run("invalid flag value", func(t *testing.T) {
+		_, _, err := Load([]string{"-db-port", "postgres"})
+		assert.ErrorContains(t, err, `-db-port: invalid integer "postgres"`)
+	})
+
+	t.Run("invalid boolean", func(t *testing.T) {
+		t.Setenv("SERVER_SKIP_MIGRATIONS", "maybe")
+		_, _, err := Load(nil)
+		assert.ErrorContains(t, err, `SERVER_SKIP_MIGRATIONS: invalid boolean "maybe"`)
+	})
+
+	t.Run("unknown flag", func(t *testing.T) {
+		_, _, err := Load([]string{"-nope"})
+		assert.ErrorContains(t, err, "invalid flags")
+	})
+}
+
+func TestValidate_DefaultSecretOutsideDevelopment(t *testing.T) {
+	conf := Default()
+	conf.Database.User = "user"
+	conf.Database.Name = "aiqfome"
+
+	conf.Env = EnvDevelopment
+	assert.NoError(t, conf.Validate())
+
+	conf.Env = EnvProduction
+	assert.ErrorContains(t, conf.Validate(), "JWT_SECRET must be changed from the default")
+
+	conf.Auth.JWTSecret = "a-real-secret"
+	assert.NoError(t, conf.Validate())
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	conf := Default()
+	conf.Env = "staging"
+	conf.Database.SSLMode = "sometimes"
+	conf.Auth.AccessTokenTTL = 0
+	conf.Tracing.SampleRatio = 2
+	conf.Catalog.Provider = "ftp"
+	conf.Seed.Users = []string{"admin@admin.com"}
+
+	err := conf.Validate()
+	require.Error(t, err)
+
+	for _, message := range []string{
+		`APP_ENV must be one of development or production, got "staging"`,
+		"DB_USER is required",
+		"DB_NAME is required",
+		`DB_SSLMODE must be one of`,
+		"ACCESS_TOKEN_TTL must be a positive duration",
+		"TRACING_SAMPLE_RATIO must be between 0 and 1",
+		`PRODUCT_PROVIDER must be one of fakestoreapi, postgres, file, got "ftp"`,
+		`SEED_USERS entries must be email:password, got "admin@admin.com"`,
+	} {
+		assert.ErrorContains(t, err, message)
+	}
+}
+
+func TestDatabase_EscapesCredentials(t *testing.T) {
+	database := Default().Database
+	database.User = "app"
+	database.Password = `p@ss word'\/:?`
+	database.Name = "aiqfome"
+	database.SSLMode = "verify-full"
+	database.SSLRootCert = "/etc/ssl/root ca.pem"
+
+	assert.Equal(t,
+		`host='localhost' port='5432' user='app' password='p@ss word\'\\/:?' dbname='aiqfome' sslmode='verify-full' sslrootcert='/etc/ssl/root ca.pem' search_path='public'`,
+		database.DSN(),
+	)
+}
+
+func TestValidate_DatabaseTLS(t *testing.T) {
+	conf := Default()
+	conf.Env = EnvDevelopment
+	conf.Database.User = "user"
+	conf.Database.Name = "aiqfome"
+	conf.Database.SSLMode = "verify-full"
+	conf.Database.SSLRootCert = filepath.Join(t.TempDir(), "missing.pem")
+	conf.Database.SSLCert = writeConfigFile(t, "cert")
+
+	err := conf.Validate()
+	assert.ErrorContains(t, err, "DB_SSLROOTCERT: cannot read")
+	assert.ErrorContains(t, err, "DB_SSLCERT and DB_SSLKEY must be set together")
+}
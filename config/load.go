@@ -0,0 +1,194 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// renamedEnv lists the environment variables that were renamed, with their
+// current names. Setting an old one is an error rather than being ignored, so
+// a deployment does not silently fall back to the defaults.
+var renamedEnv = [][2]string{
+	{"DB_MAX_OPEN_CONNS", "DB_MAX_CONNS"},
+	{"DB_MAX_IDLE_CONNS", "DB_MIN_CONNS"},
+}
+
+// LoadConfig loads the configuration from the command line arguments of the
+// process. See Load.
+func LoadConfig() (*Conf, error) {
+	conf, _, err := Load(os.Args[1:])
+	return conf, err
+}
+
+// Load builds the configuration from, in increasing precedence: the defaults,
+// the YAML file given by -config or CONFIG_FILE, the environment (a .env file
+// in the working directory included) and the flags in args. The result is
+// validated. The arguments left after the flags are returned, e.g. a subcommand.
+func Load(args []string) (*Conf, []string, error) {
+	conf := Default()
+
+	flags := flag.NewFlagSet("aiqfome-challenge", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	configFile := flags.String("config", "", "path to a YAML configuration file")
+
+	overrides := map[string]string{}
+	visitFields(reflect.ValueOf(conf).Elem(), func(field reflect.Value, env string) {
+		override := func(value string) error {
+			overrides[env] = value
+			return nil
+		}
+
+		// Boolean flags may be given without a value, e.g. -server-skip-migrations.
+		if field.Kind() == reflect.Bool {
+			flags.BoolFunc(flagName(env), "overrides "+env, override)
+			return
+		}
+		flags.Func(flagName(env), "overrides "+env, override)
+	})
+
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("invalid flags: %w", err)
+	}
+
+	// A missing .env file is fine: the environment variables are used as is.
+	_ = godotenv.Load()
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+
+	if path != "" {
+		if err := loadFile(conf, path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var errs []error
+	visitFields(reflect.ValueOf(conf).Elem(), func(field reflect.Value, env string) {
+		if value := os.Getenv(env); value != "" {
+			if err := setField(field, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", env, err))
+			}
+		}
+
+		if value, ok := overrides[env]; ok {
+			if err := setField(field, value); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %w", flagName(env), err))
+			}
+		}
+	})
+
+	for _, names := range renamedEnv {
+		if os.Getenv(names[0]) != "" {
+			errs = append(errs, fmt.Errorf("%s was renamed to %s", names[0], names[1]))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return conf, flags.Args(), nil
+}
+
+// loadFile decodes the YAML file over conf. Unknown keys are an error, so a
+// typo does not silently leave a setting at its default.
+func loadFile(conf *Conf, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(conf); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// visitFields calls fn for every field of v, nested structs included, that
+// has an env tag.
+func visitFields(v reflect.Value, fn func(field reflect.Value, env string)) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if env := v.Type().Field(i).Tag.Get("env"); env != "" {
+			fn(field, env)
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			visitFields(field, fn)
+		}
+	}
+}
+
+// flagName turns an environment variable name into its flag: DB_HOST is db-host.
+func flagName(env string) string {
+	return strings.ToLower(strings.ReplaceAll(env, "_", "-"))
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setField(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		field.SetInt(int64(number))
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		field.SetBool(boolean)
+	case reflect.Float64:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		field.SetFloat(number)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	sslModes         = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	tracingExporters = []string{"none", "stdout", "otlp"}
+	logFormats       = []string{"json", "text"}
+	notifiers        = []string{"event", "log"}
+	productProviders = []string{ProductProviderFakestoreapi, ProductProviderPostgres, ProductProviderFile}
+)
+
+// Validate checks the whole configuration and reports every problem found, so
+// a bad deploy fails at startup instead of on the first request.
+func (c *Conf) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{EnvDevelopment, EnvProduction}, c.Env),
+		"APP_ENV must be one of %s or %s, got %q", EnvDevelopment, EnvProduction, c.Env)
+
+	check(c.Database.Host != "", "DB_HOST is required")
+	check(validPort(c.Database.Port), "DB_PORT must be between 1 and 65535, got %d", c.Database.Port)
+	check(c.Database.User != "", "DB_USER is required")
+	check(c.Database.Name != "", "DB_NAME is required")
+	check(c.Database.Schema != "", "DB_SCHEMA is required")
+	check(slices.Contains(sslModes, c.Database.SSLMode),
+		"DB_SSLMODE must be one of %s, got %q", strings.Join(sslModes, ", "), c.Database.SSLMode)
+	check(c.Database.MaxConns > 0, "DB_MAX_CONNS must be positive, got %d", c.Database.MaxConns)
+	check(c.Database.MinConns >= 0, "DB_MIN_CONNS must not be negative")
+	check(c.Database.MinConns <= c.Database.MaxConns,
+		"DB_MIN_CONNS (%d) must not exceed DB_MAX_CONNS (%d)", c.Database.MinConns, c.Database.MaxConns)
+	check(c.Database.ConnMaxLifetime >= 0, "DB_CONN_MAX_LIFETIME must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "DB_CONN_MAX_IDLE_TIME must not be negative")
+	check(c.Database.ConnectAttempts > 0, "DB_CONNECT_ATTEMPTS must be positive, got %d", c.Database.ConnectAttempts)
+	checkPositive(check, "DB_CONNECT_BACKOFF", c.Database.ConnectBackoff)
+	checkFile(check, "DB_SSLROOTCERT", c.Database.SSLRootCert)
+	checkFile(check, "DB_SSLCERT", c.Database.SSLCert)
+	checkFile(check, "DB_SSLKEY", c.Database.SSLKey)
+	check((c.Database.SSLCert == "") == (c.Database.SSLKey == ""), "DB_SSLCERT and DB_SSLKEY must be set together")
+	check(c.Database.SSLMode != "disable" || c.Database.SSLRootCert+c.Database.SSLCert == "",
+		"DB_SSLROOTCERT and DB_SSLCERT have no effect when DB_SSLMODE is disable")
+
+	check(validPort(c.Server.Port), "SERVER_PORT must be between 1 and 65535, got %d", c.Server.Port)
+	checkPositive(check, "SERVER_READ_TIMEOUT", c.Server.ReadTimeout)
+	checkPositive(check, "SERVER_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout)
+	checkPositive(check, "SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout)
+	checkPositive(check, "SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout)
+	checkPositive(check, "SERVER_SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout)
+
+	check(c.Auth.JWTSecret != "", "JWT_SECRET is required")
+	check(c.IsDevelopment() || c.Auth.JWTSecret != DefaultJWTSecret,
+		"JWT_SECRET must be changed from the default outside %s mode", EnvDevelopment)
+	checkPositive(check, "ACCESS_TOKEN_TTL", c.Auth.AccessTokenTTL)
+	checkPositive(check, "REFRESH_TOKEN_TTL", c.Auth.RefreshTokenTTL)
+	check(c.Auth.RefreshTokenTTL >= c.Auth.AccessTokenTTL,
+		"REFRESH_TOKEN_TTL (%s) must not be shorter than ACCESS_TOKEN_TTL (%s)", c.Auth.RefreshTokenTTL, c.Auth.AccessTokenTTL)
+
+	check(len(c.CORS.AllowedOrigins) > 0, "CORS_ALLOWED_ORIGINS must list at least one origin")
+
+	check(c.Webhook.MaxAttempts > 0, "WEBHOOK_MAX_ATTEMPTS must be positive, got %d", c.Webhook.MaxAttempts)
+	checkPositive(check, "WEBHOOK_INITIAL_BACKOFF", c.Webhook.InitialBackoff)
+	checkPositive(check, "WEBHOOK_TIMEOUT", c.Webhook.Timeout)
+	checkPositive(check, "WEBHOOK_POLL_INTERVAL", c.Webhook.PollInterval)
+
+	check(slices.Contains(tracingExporters, c.Tracing.Exporter),
+		"TRACING_EXPORTER must be one of %s, got %q", strings.Join(tracingExporters, ", "), c.Tracing.Exporter)
+	check(c.Tracing.ServiceName != "", "OTEL_SERVICE_NAME is required")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1,
+		"TRACING_SAMPLE_RATIO must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "LOG_LEVEL must be debug, info, warn or error, got %q", c.Log.Level)
+	check(slices.Contains(logFormats, c.Log.Format),
+		"LOG_FORMAT must be one of %s, got %q", strings.Join(logFormats, ", "), c.Log.Format)
+
+	baseURL, err := url.Parse(c.Fakestoreapi.BaseURL)
+	check(err == nil && (baseURL.Scheme == "http" || baseURL.Scheme == "https") && baseURL.Host != "",
+		"FAKESTOREAPI_BASE_URL must be an http(s) URL, got %q", c.Fakestoreapi.BaseURL)
+	checkPositive(check, "FAKESTOREAPI_TIMEOUT", c.Fakestoreapi.Timeout)
+	check(c.Fakestoreapi.MaxRetries >= 0, "FAKESTOREAPI_MAX_RETRIES must not be negative")
+	checkPositive(check, "FAKESTOREAPI_RETRY_BACKOFF", c.Fakestoreapi.RetryBackoff)
+	check(c.Fakestoreapi.BreakerThreshold > 0, "FAKESTOREAPI_BREAKER_THRESHOLD must be positive, got %d", c.Fakestoreapi.BreakerThreshold)
+	checkPositive(check, "FAKESTOREAPI_BREAKER_COOLDOWN", c.Fakestoreapi.BreakerCooldown)
+
+	check(slices.Contains(productProviders, c.Catalog.Provider),
+		"PRODUCT_PROVIDER must be one of %s, got %q", strings.Join(productProviders, ", "), c.Catalog.Provider)
+	check(c.Catalog.Provider != ProductProviderFile || c.Catalog.File != "",
+		"PRODUCT_CATALOG_FILE is required when PRODUCT_PROVIDER is %s", ProductProviderFile)
+	check(c.Catalog.SyncInterval >= 0, "CATALOG_SYNC_INTERVAL must not be negative")
+
+	check(c.Pricing.TrackInterval >= 0, "PRICE_TRACK_INTERVAL must not be negative")
+	check(slices.Contains(notifiers, c.Pricing.Notifier),
+		"PRICE_DROP_NOTIFIER must be one of %s, got %q", strings.Join(notifiers, ", "), c.Pricing.Notifier)
+
+	check(c.Recommendation.RefreshInterval >= 0, "RECOMMENDATION_REFRESH_INTERVAL must not be negative")
+
+	check(c.Favorites.MaxPerCustomer >= 0, "FAVORITES_MAX_PER_CUSTOMER must not be negative")
+
+	check(c.CustomerImport.MaxBytes > 0, "CUSTOMER_IMPORT_MAX_BYTES must be positive, got %d", c.CustomerImport.MaxBytes)
+	check(c.CustomerImport.MaxRows > 0, "CUSTOMER_IMPORT_MAX_ROWS must be positive, got %d", c.CustomerImport.MaxRows)
+
+	for _, entry := range c.Seed.Users {
+		_, _, ok := ParseSeedUser(entry)
+		check(ok, "SEED_USERS entries must be email:password, got %q", entry)
+	}
+	check(c.Seed.Customers >= 0, "SEED_CUSTOMERS must not be negative")
+	check(c.Seed.FavoritesPerCustomer >= 0, "SEED_FAVORITES_PER_CUSTOMER must not be negative")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// checkFile only runs when the setting is given, as the certificates are optional.
+func checkFile(check func(bool, string, ...any), name, path string) {
+	if path == "" {
+		return
+	}
+
+	_, err := os.Stat(path)
+	check(err == nil, "%s: cannot read %q: %v", name, path, err)
+}
+
+func checkPositive(check func(bool, string, ...any), name string, value time.Duration) {
+	check(value > 0, "%s must be a positive duration, got %s", name, value)
+}
@@ -0,0 +1,10 @@
+CREATE TABLE products (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    image VARCHAR(2048) NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rate_count BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
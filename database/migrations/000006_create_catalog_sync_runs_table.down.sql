@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS catalog_sync_runs;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS discontinued_at,
+    DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,16 @@
+ALTER TABLE products
+    ADD COLUMN content_hash VARCHAR(64),
+    ADD COLUMN discontinued_at TIMESTAMP;
+
+CREATE TABLE catalog_sync_runs (
+    id BIGSERIAL PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    fetched INT NOT NULL DEFAULT 0,
+    inserted INT NOT NULL DEFAULT 0,
+    updated INT NOT NULL DEFAULT 0,
+    unchanged INT NOT NULL DEFAULT 0,
+    discontinued INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP
+);
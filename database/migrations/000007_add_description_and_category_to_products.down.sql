@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_category;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS description;
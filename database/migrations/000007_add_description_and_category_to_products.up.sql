@@ -0,0 +1,5 @@
+ALTER TABLE products
+    ADD COLUMN description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN category VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_products_category ON products(category);
@@ -0,0 +1,4 @@
+ALTER TABLE favorites
+    DROP COLUMN IF EXISTS price,
+    DROP COLUMN IF EXISTS image,
+    DROP COLUMN IF EXISTS title;
@@ -0,0 +1,4 @@
+ALTER TABLE favorites
+    ADD COLUMN title VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN image VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN price DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_product_prices_product_id_recorded_at;
+DROP TABLE IF EXISTS product_prices;
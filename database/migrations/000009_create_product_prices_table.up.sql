@@ -0,0 +1,8 @@
+CREATE TABLE product_prices (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_prices_product_id_recorded_at ON product_prices(product_id, recorded_at);
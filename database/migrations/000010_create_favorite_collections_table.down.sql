@@ -0,0 +1,15 @@
+-- Only the default collection maps back to the flat favorites list.
+DELETE FROM favorites f
+USING favorite_collections c
+WHERE c.id = f.collection_id AND NOT c.is_default;
+
+DROP INDEX IF EXISTS idx_favorites_customer_id;
+
+ALTER TABLE favorites
+    DROP CONSTRAINT IF EXISTS fk_favorites_collection,
+    DROP CONSTRAINT favorites_pkey,
+    ADD PRIMARY KEY (customer_id, product_id),
+    DROP COLUMN collection_id;
+
+DROP INDEX IF EXISTS idx_favorite_collections_default;
+DROP TABLE IF EXISTS favorite_collections;
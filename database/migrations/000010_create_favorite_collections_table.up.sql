@@ -0,0 +1,40 @@
+CREATE TABLE favorite_collections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    customer_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_favorite_collections_customer_name UNIQUE (customer_id, name),
+
+    CONSTRAINT fk_favorite_collections_customer
+        FOREIGN KEY (customer_id)
+        REFERENCES customers(id)
+        ON DELETE CASCADE
+);
+
+-- A customer has at most one default collection, which backs the
+-- /customers/{customer_id}/favorites/{product_id} routes.
+CREATE UNIQUE INDEX idx_favorite_collections_default ON favorite_collections(customer_id) WHERE is_default;
+
+INSERT INTO favorite_collections (customer_id, name, is_default)
+SELECT DISTINCT customer_id, 'Favoritos', TRUE FROM favorites;
+
+ALTER TABLE favorites ADD COLUMN collection_id UUID;
+
+UPDATE favorites f
+SET collection_id = c.id
+FROM favorite_collections c
+WHERE c.customer_id = f.customer_id AND c.is_default;
+
+ALTER TABLE favorites
+    ALTER COLUMN collection_id SET NOT NULL,
+    DROP CONSTRAINT favorites_pkey,
+    ADD PRIMARY KEY (collection_id, product_id),
+    ADD CONSTRAINT fk_favorites_collection
+        FOREIGN KEY (collection_id)
+        REFERENCES favorite_collections(id)
+        ON DELETE CASCADE;
+
+CREATE INDEX idx_favorites_customer_id ON favorites(customer_id);
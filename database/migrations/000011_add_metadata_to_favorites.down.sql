@@ -0,0 +1,4 @@
+ALTER TABLE favorites
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS quantity,
+    DROP COLUMN IF EXISTS note;
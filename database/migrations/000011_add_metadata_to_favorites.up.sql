@@ -0,0 +1,5 @@
+-- priority: 0 = low, 1 = normal, 2 = high
+ALTER TABLE favorites
+    ADD COLUMN note TEXT NOT NULL DEFAULT '',
+    ADD COLUMN quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    ADD COLUMN priority SMALLINT NOT NULL DEFAULT 1 CHECK (priority BETWEEN 0 AND 2);
@@ -0,0 +1,2 @@
+-- Backs the most favorited leaderboard, which counts favorites created in a time window.
+CREATE INDEX idx_favorites_created_at ON favorites(created_at, product_id);
@@ -0,0 +1,9 @@
+-- Precomputed "customers who favorited product_id also favorited related_product_id",
+-- rebuilt by the recommendations job.
+CREATE TABLE product_co_favorites (
+    product_id BIGINT NOT NULL,
+    related_product_id BIGINT NOT NULL,
+    customers INTEGER NOT NULL,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, related_product_id)
+);
@@ -0,0 +1,2 @@
+-- Overrides FAVORITES_MAX_PER_CUSTOMER for a customer, e.g. premium accounts. NULL uses the default.
+ALTER TABLE customers ADD COLUMN favorites_quota INTEGER CHECK (favorites_quota > 0);
@@ -0,0 +1,5 @@
+-- The admin/admin user used to be created by 000001. It is now created by
+-- cmd/seed in development only, so drop it unless its password was changed.
+DELETE FROM users
+WHERE id = '01986709-c873-7525-bd98-20457930777c'
+  AND password = '$2a$10$fLtpywS.uDkctCvp2oRk7.bpbh.obycMk3EWJU6toqx4A64j1nj6q';
@@ -0,0 +1,2 @@
+-- The backfilled snapshots are kept: they cannot be told apart from the ones
+-- taken when favoriting.
@@ -0,0 +1,7 @@
+-- Favorites created before 000008 were left with the column defaults. Copy the
+-- snapshot from the catalog table; the ones it does not know are filled from
+-- the live product when they are read.
+UPDATE favorites f
+SET title = p.title, image = p.image, price = p.price
+FROM products p
+WHERE p.id = f.product_id AND f.title = '';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_next_attempt_at;
+
+ALTER TABLE webhook_deliveries
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS attempts_left;
@@ -0,0 +1,16 @@
+-- Pending deliveries are the retry queue: the dispatcher claims the ones whose
+-- next_attempt_at is due, so retries survive restarts.
+--
+-- This migration briefly shipped as version 16, so every statement tolerates
+-- databases that already applied it under that version.
+ALTER TABLE webhook_deliveries
+    ADD COLUMN IF NOT EXISTS attempts_left INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ;
+
+-- Retries of deliveries left pending by older versions lived in memory and
+-- were lost; give them what remains of the default WEBHOOK_MAX_ATTEMPTS.
+UPDATE webhook_deliveries
+SET attempts_left = GREATEST(5 - attempts, 1), next_attempt_at = NOW()
+WHERE status = 'pending' AND next_attempt_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,4 @@
+-- Password: admin
+INSERT INTO users (id, name, email, password)
+VALUES ('01986709-c873-7525-bd98-20457930777c', 'Admin', 'admin@admin.com', '$2a$10$fLtpywS.uDkctCvp2oRk7.bpbh.obycMk3EWJU6toqx4A64j1nj6q')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- 000001 still inserts the admin/admin user. Databases that recorded version
+-- 16 before it was restored never ran 000016, so drop the user here as well,
+-- unless its password was changed.
+DELETE FROM users
+WHERE id = '01986709-c873-7525-bd98-20457930777c'
+  AND password = '$2a$10$fLtpywS.uDkctCvp2oRk7.bpbh.obycMk3EWJU6toqx4A64j1nj6q';
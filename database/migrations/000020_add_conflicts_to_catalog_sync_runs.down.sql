@@ -0,0 +1 @@
+ALTER TABLE catalog_sync_runs DROP COLUMN IF EXISTS conflicts;
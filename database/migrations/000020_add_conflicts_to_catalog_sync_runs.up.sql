@@ -0,0 +1,3 @@
+-- Upstream products whose id is already taken by a product created through the
+-- API are skipped by the sync and counted here.
+ALTER TABLE catalog_sync_runs ADD COLUMN conflicts INT NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations, so the binaries apply them
+// without depending on the directory they run from.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
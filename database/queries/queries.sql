@@ -4,26 +4,318 @@ SELECT * FROM customers;
 -- name: FindCustomerById :one
 SELECT * FROM customers WHERE id = $1;
 
+-- name: FindCustomerByEmail :one
+SELECT * FROM customers WHERE email = $1;
+
 -- name: InsertCustomer :exec
 INSERT INTO customers (id, name, email) values ($1, $2, $3);
 
+-- name: InsertCustomers :many
+INSERT INTO customers (id, name, email)
+SELECT unnest(@ids::uuid[]), unnest(@names::text[]), unnest(@emails::text[])
+ON CONFLICT (email) DO NOTHING
+RETURNING email;
+
+-- name: FindCustomersWithFavoriteIds :many
+SELECT c.id, c.name, c.email,
+    COALESCE(array_agg(DISTINCT f.product_id ORDER BY f.product_id) FILTER (WHERE f.product_id IS NOT NULL), '{}')::bigint[] AS favorite_ids
+FROM (
+    SELECT id, name, email FROM customers
+    WHERE id > @after_id::uuid
+    ORDER BY id
+    LIMIT @page_size
+) c
+LEFT JOIN favorites f ON f.customer_id = c.id
+GROUP BY c.id, c.name, c.email
+ORDER BY c.id;
+
 -- name: UpdateCustomer :exec
 UPDATE customers SET name = $1, email = $2, updated_at = NOW() WHERE id = $3;
 
 -- name: DeleteCustomer :exec
 DELETE FROM customers WHERE id = $1;
 
+-- name: UpdateCustomerFavoritesQuota :exec
+UPDATE customers SET favorites_quota = $1, updated_at = NOW() WHERE id = $2;
+
+-- name: LockCustomerFavorites :one
+SELECT id FROM customers WHERE id = $1 FOR UPDATE;
+
+-- name: CountFavoriteProductsByCustomer :one
+SELECT COUNT(DISTINCT product_id) FROM favorites WHERE customer_id = $1;
+
+-- name: IsProductFavoritedByCustomer :one
+SELECT EXISTS (SELECT 1 FROM favorites WHERE customer_id = $1 AND product_id = $2);
+
 -- name: FindAllFavoriteProdutsFromCustomer :many
-SELECT * FROM favorites WHERE customer_id = $1;
+SELECT f.* FROM favorites f
+JOIN favorite_collections c ON c.id = f.collection_id
+WHERE f.customer_id = $1 AND c.is_default
+ORDER BY f.created_at, f.product_id;
 
 -- name: InsertFavoriteCustomerProduct :exec
-INSERT INTO favorites (customer_id, product_id) VALUES ($1, $2);
+INSERT INTO favorites (collection_id, customer_id, product_id, title, image, price, note, quantity, priority)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: UpdateFavoriteCustomerProduct :execrows
+UPDATE favorites f
+SET note = $3, quantity = $4, priority = $5
+FROM favorite_collections c
+WHERE c.id = f.collection_id AND c.is_default AND f.customer_id = $1 AND f.product_id = $2;
+
+-- name: DeleteFavoriteCustomerProduct :execrows
+DELETE FROM favorites f
+USING favorite_collections c
+WHERE c.id = f.collection_id AND c.is_default AND f.customer_id = $1 AND f.product_id = $2;
+
+-- name: CountFavoritesByProduct :many
+SELECT product_id, COUNT(DISTINCT customer_id) AS favorites FROM favorites GROUP BY product_id ORDER BY product_id;
+
+-- name: CountFavoritesByProductIds :many
+SELECT product_id, COUNT(DISTINCT customer_id) AS favorites
+FROM favorites
+WHERE product_id = ANY(@product_ids::bigint[])
+GROUP BY product_id;
+
+-- name: CountFavoritesByProductSince :many
+SELECT product_id, COUNT(DISTINCT customer_id) AS favorites
+FROM favorites
+WHERE created_at >= @since::timestamptz
+GROUP BY product_id
+ORDER BY favorites DESC, product_id
+LIMIT @page_size OFFSET @page_offset;
+
+-- name: DeleteFavoritesByProducts :execrows
+DELETE FROM favorites WHERE product_id = ANY(@product_ids::bigint[]);
+
+-- name: EnsureDefaultFavoriteCollection :one
+INSERT INTO favorite_collections (id, customer_id, name, is_default) VALUES ($1, $2, $3, TRUE)
+ON CONFLICT (customer_id) WHERE is_default DO UPDATE SET is_default = TRUE
+RETURNING *;
+
+-- name: FindFavoriteCollectionsByCustomer :many
+SELECT c.*, COUNT(f.product_id) AS favorites
+FROM favorite_collections c
+LEFT JOIN favorites f ON f.collection_id = c.id
+WHERE c.customer_id = $1
+GROUP BY c.id
+ORDER BY c.is_default DESC, c.created_at, c.name;
+
+-- name: FindFavoriteCollectionById :one
+SELECT * FROM favorite_collections WHERE id = $1 AND customer_id = $2;
 
--- name: DeleteFavoriteCustomerProduct :exec
-DELETE FROM favorites WHERE customer_id = $1 AND product_id = $2;
+-- name: InsertFavoriteCollection :exec
+INSERT INTO favorite_collections (id, customer_id, name) VALUES ($1, $2, $3);
+
+-- name: UpdateFavoriteCollection :exec
+UPDATE favorite_collections SET name = $1, updated_at = NOW() WHERE id = $2;
+
+-- name: DeleteFavoriteCollection :exec
+DELETE FROM favorite_collections WHERE id = $1;
+
+-- name: FindFavoritesByCollection :many
+SELECT * FROM favorites WHERE collection_id = $1 ORDER BY created_at, product_id;
+
+-- name: DeleteFavoriteFromCollection :execrows
+DELETE FROM favorites WHERE collection_id = $1 AND product_id = $2;
+
+-- name: MoveFavoriteToCollection :execrows
+UPDATE favorites SET collection_id = @target_id::uuid WHERE collection_id = @source_id::uuid AND product_id = @product_id::bigint;
+
+-- name: CopyFavoriteToCollection :execrows
+INSERT INTO favorites (collection_id, customer_id, product_id, title, image, price, note, quantity, priority)
+SELECT @target_id::uuid, customer_id, product_id, title, image, price, note, quantity, priority
+FROM favorites
+WHERE collection_id = @source_id::uuid AND product_id = @product_id::bigint;
 
 -- name: FindUserByEmail :one
 SELECT * FROM users WHERE email = $1;
 
 -- name: FindUserById :one
-SELECT * FROM users WHERE id = $1;
\ No newline at end of file
+SELECT * FROM users WHERE id = $1;
+
+-- name: InsertUser :exec
+INSERT INTO users (id, name, email, password) VALUES ($1, $2, $3, $4);
+
+-- name: FindAllWebhooks :many
+SELECT * FROM webhooks ORDER BY created_at;
+
+-- name: FindWebhookById :one
+SELECT * FROM webhooks WHERE id = $1;
+
+-- name: FindActiveWebhooksByEvent :many
+SELECT * FROM webhooks WHERE active = TRUE AND sqlc.arg(event_type)::text = ANY(events);
+
+-- name: InsertWebhook :exec
+INSERT INTO webhooks (id, url, events, secret, active) VALUES ($1, $2, $3, $4, $5);
+
+-- name: UpdateWebhook :exec
+UPDATE webhooks SET url = $1, events = $2, secret = $3, active = $4, updated_at = NOW() WHERE id = $5;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks WHERE id = $1;
+
+-- name: FindWebhookDeliveryById :one
+SELECT * FROM webhook_deliveries WHERE id = $1;
+
+-- name: FindAllWebhookDeliveriesFromWebhook :many
+SELECT * FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY created_at DESC;
+
+-- name: InsertWebhookDelivery :exec
+INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, attempts, attempts_left, response_status, last_error, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = $1, attempts = $2, attempts_left = $3, response_status = $4, last_error = $5, delivered_at = $6, next_attempt_at = $7
+WHERE id = $8;
+
+-- Pushes the claimed deliveries to lease_until, so no other poller takes them
+-- while they are sent; a delivery whose sender died is due again afterwards.
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries SET next_attempt_at = @lease_until::timestamptz
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= @now::timestamptz
+    ORDER BY next_attempt_at
+    LIMIT @max_deliveries
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: RequeueWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET status = 'pending', attempts_left = @attempts_left, delivered_at = NULL, next_attempt_at = @next_attempt_at::timestamptz
+WHERE id = @id AND status <> 'pending';
+
+-- name: FindAllProducts :many
+SELECT * FROM products WHERE discontinued_at IS NULL ORDER BY id;
+
+-- name: FindProductsByCategory :many
+SELECT * FROM products WHERE category = $1 AND discontinued_at IS NULL ORDER BY id;
+
+-- name: SearchProducts :many
+SELECT * FROM products
+WHERE discontinued_at IS NULL
+    AND (sqlc.narg(category)::text IS NULL OR category = sqlc.narg(category))
+    AND (sqlc.narg(search)::text IS NULL OR strpos(lower(title), lower(sqlc.narg(search))) > 0)
+    AND (sqlc.narg(min_price)::float8 IS NULL OR price >= sqlc.narg(min_price))
+    AND (sqlc.narg(max_price)::float8 IS NULL OR price <= sqlc.narg(max_price))
+    AND (sqlc.narg(min_rating)::float8 IS NULL OR rate >= sqlc.narg(min_rating))
+    AND (sqlc.narg(max_rating)::float8 IS NULL OR rate <= sqlc.narg(max_rating))
+ORDER BY
+    CASE WHEN @sort_field::text = 'price' AND NOT @sort_desc::boolean THEN price END,
+    CASE WHEN @sort_field::text = 'price' AND @sort_desc::boolean THEN price END DESC,
+    CASE WHEN @sort_field::text = 'rating' AND NOT @sort_desc::boolean THEN rate END,
+    CASE WHEN @sort_field::text = 'rating' AND @sort_desc::boolean THEN rate END DESC,
+    CASE WHEN @sort_field::text = 'rate_count' AND NOT @sort_desc::boolean THEN rate_count END,
+    CASE WHEN @sort_field::text = 'rate_count' AND @sort_desc::boolean THEN rate_count END DESC,
+    id
+LIMIT @page_size OFFSET @page_offset;
+
+-- name: CountSearchedProducts :one
+SELECT COUNT(*) FROM products
+WHERE discontinued_at IS NULL
+    AND (sqlc.narg(category)::text IS NULL OR category = sqlc.narg(category))
+    AND (sqlc.narg(search)::text IS NULL OR strpos(lower(title), lower(sqlc.narg(search))) > 0)
+    AND (sqlc.narg(min_price)::float8 IS NULL OR price >= sqlc.narg(min_price))
+    AND (sqlc.narg(max_price)::float8 IS NULL OR price <= sqlc.narg(max_price))
+    AND (sqlc.narg(min_rating)::float8 IS NULL OR rate >= sqlc.narg(min_rating))
+    AND (sqlc.narg(max_rating)::float8 IS NULL OR rate <= sqlc.narg(max_rating));
+
+-- name: FindProductCategories :many
+SELECT DISTINCT category FROM products WHERE category <> '' AND discontinued_at IS NULL ORDER BY category;
+
+-- name: FindProductsByIds :many
+SELECT * FROM products WHERE id = ANY(@ids::bigint[]) AND discontinued_at IS NULL ORDER BY id;
+
+-- name: FindProductById :one
+SELECT * FROM products WHERE id = $1 AND discontinued_at IS NULL;
+
+-- name: InsertProduct :one
+INSERT INTO products (title, description, category, image, price, rate, rate_count) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;
+
+-- name: UpdateProduct :execrows
+UPDATE products SET title = $1, description = $2, category = $3, image = $4, price = $5, rate = $6, rate_count = $7, updated_at = NOW() WHERE id = $8;
+
+-- name: DeleteProduct :execrows
+DELETE FROM products WHERE id = $1;
+
+-- name: LockCatalogSync :one
+SELECT pg_try_advisory_xact_lock(@lock_key::bigint);
+
+-- name: FindLocalProductIds :many
+SELECT id FROM products WHERE content_hash IS NULL AND id = ANY(@ids::bigint[]) ORDER BY id;
+
+-- name: UpsertSyncedProduct :one
+INSERT INTO products (id, title, description, category, image, price, rate, rate_count, content_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (id) DO UPDATE SET
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    category = EXCLUDED.category,
+    image = EXCLUDED.image,
+    price = EXCLUDED.price,
+    rate = EXCLUDED.rate,
+    rate_count = EXCLUDED.rate_count,
+    content_hash = EXCLUDED.content_hash,
+    discontinued_at = NULL,
+    updated_at = NOW()
+WHERE products.content_hash IS NOT NULL
+    AND (products.content_hash IS DISTINCT FROM EXCLUDED.content_hash OR products.discontinued_at IS NOT NULL)
+RETURNING (xmax = 0)::boolean AS inserted;
+
+-- name: DiscontinueMissingProducts :execrows
+UPDATE products SET discontinued_at = NOW(), updated_at = NOW()
+WHERE content_hash IS NOT NULL AND discontinued_at IS NULL AND NOT (id = ANY(@synced_ids::bigint[]));
+
+-- name: ResetProductIdSequence :exec
+SELECT setval(pg_get_serial_sequence('products', 'id'), GREATEST((SELECT MAX(id) FROM products), 1));
+
+-- name: InsertCatalogSyncRun :one
+INSERT INTO catalog_sync_runs (status, started_at) VALUES ($1, $2) RETURNING id;
+
+-- name: FinishCatalogSyncRun :exec
+UPDATE catalog_sync_runs
+SET status = $1, fetched = $2, inserted = $3, updated = $4, unchanged = $5, discontinued = $6, conflicts = $7, error = $8, finished_at = $9
+WHERE id = $10;
+
+-- name: FindCustomerIdsByFavoriteProduct :many
+SELECT DISTINCT customer_id FROM favorites WHERE product_id = $1 ORDER BY customer_id;
+
+-- name: FindLatestProductPrices :many
+SELECT DISTINCT ON (product_id) product_id, price FROM product_prices ORDER BY product_id, recorded_at DESC, id DESC;
+
+-- name: LockPriceTracking :one
+SELECT pg_try_advisory_xact_lock(@lock_key::bigint);
+
+-- name: InsertProductPrices :exec
+INSERT INTO product_prices (product_id, price, recorded_at)
+SELECT unnest(@product_ids::bigint[]), unnest(@prices::float8[]), unnest(@recorded_ats::timestamptz[]);
+
+-- name: FindProductPriceHistory :many
+SELECT * FROM product_prices WHERE product_id = $1 ORDER BY recorded_at, id;
+
+-- name: DeleteProductCoFavorites :exec
+DELETE FROM product_co_favorites;
+
+-- name: InsertProductCoFavorites :execrows
+INSERT INTO product_co_favorites (product_id, related_product_id, customers)
+SELECT a.product_id, b.product_id, COUNT(DISTINCT a.customer_id)
+FROM favorites a
+JOIN favorites b ON b.customer_id = a.customer_id AND b.product_id <> a.product_id
+GROUP BY a.product_id, b.product_id;
+
+-- name: FindCoFavoritedProductsForCustomer :many
+SELECT c.related_product_id, SUM(c.customers)::bigint AS customers
+FROM product_co_favorites c
+WHERE c.product_id IN (SELECT f.product_id FROM favorites f WHERE f.customer_id = $1)
+  AND c.related_product_id NOT IN (SELECT f.product_id FROM favorites f WHERE f.customer_id = $1)
+GROUP BY c.related_product_id
+ORDER BY customers DESC, c.related_product_id;
+
+-- name: LockProductCoFavoritesRefresh :one
+SELECT pg_try_advisory_xact_lock(@lock_key::bigint);
+
+-- name: FindFavoriteProductIdsByCustomer :many
+SELECT DISTINCT product_id FROM favorites WHERE customer_id = $1 ORDER BY product_id;
@@ -0,0 +1,116 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker opens after Threshold consecutive failures and rejects calls until
+// Cooldown has passed. It then lets a single probe through: a success closes
+// the circuit again and a failure keeps it open for another Cooldown.
+type Breaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+func New(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call may proceed, returning ErrOpen otherwise.
+// Every allowed call must be followed by Success, Failure or Cancel.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case Open:
+		return ErrOpen
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.state = HalfOpen
+		b.probing = true
+	}
+
+	return nil
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = Closed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.Threshold {
+		b.state = Open
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
+
+// Cancel releases an allowed call that ended without telling anything about
+// the upstream health, e.g. because the caller gave up.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+func (b *Breaker) currentState() State {
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.Cooldown {
+		return HalfOpen
+	}
+
+	return b.state
+}
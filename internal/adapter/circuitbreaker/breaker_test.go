@@ -0,0 +1,73 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*Breaker, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := New(threshold, cooldown)
+	breaker.now = func() time.Time { return now }
+
+	return breaker, &now
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	breaker, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, breaker.Allow())
+		breaker.Failure()
+	}
+	assert.Equal(t, Closed, breaker.State())
+
+	assert.NoError(t, breaker.Allow())
+	breaker.Failure()
+
+	assert.Equal(t, Open, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrOpen)
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(2, time.Minute)
+
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+
+	assert.Equal(t, Closed, breaker.State())
+}
+
+func TestBreaker_HalfOpenAllowsSingleProbe(t *testing.T) {
+	breaker, now := newTestBreaker(1, time.Minute)
+
+	breaker.Failure()
+	*now = now.Add(time.Minute)
+	assert.Equal(t, HalfOpen, breaker.State())
+
+	assert.NoError(t, breaker.Allow())
+	assert.ErrorIs(t, breaker.Allow(), ErrOpen)
+
+	breaker.Success()
+	assert.Equal(t, Closed, breaker.State())
+	assert.NoError(t, breaker.Allow())
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	breaker, now := newTestBreaker(5, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		breaker.Failure()
+	}
+	*now = now.Add(time.Minute)
+
+	assert.NoError(t, breaker.Allow())
+	breaker.Failure()
+
+	assert.Equal(t, Open, breaker.State())
+	*now = now.Add(30 * time.Second)
+	assert.ErrorIs(t, breaker.Allow(), ErrOpen)
+}
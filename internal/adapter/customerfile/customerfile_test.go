@@ -0,0 +1,102 @@
+package customerfile
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/customer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, format Format, content string) []customer.ImportRow {
+	rows, err := ReadRows(format, strings.NewReader(content))
+	require.NoError(t, err)
+	return slices.Collect(rows)
+}
+
+func TestReadRows_CSV(t *testing.T) {
+	rows := readAll(t, FormatCSV, "\ufeffEmail, Name ,plan\n"+
+		"ana@example.com, Ana Silva,gold\n"+
+		"\"bruno@example.com\",\"Bruno \"\"B\"\" Santos\"\n"+
+		"carla@example.com,\"Carla\n")
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, customer.ImportRow{Line: 2, Name: "Ana Silva", Email: "ana@example.com"}, rows[0])
+	assert.Equal(t, customer.ImportRow{Line: 3, Name: `Bruno "B" Santos`, Email: "bruno@example.com"}, rows[1])
+	assert.Equal(t, 4, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+}
+
+func TestReadRows_CSVHeaderWithoutEmail(t *testing.T) {
+	_, err := ReadRows(FormatCSV, strings.NewReader("name,phone\nAna,123\n"))
+	assert.ErrorContains(t, err, "name and email columns")
+}
+
+func TestReadRows_NDJSON(t *testing.T) {
+	rows := readAll(t, FormatNDJSON, `{"name":"Ana","email":"ana@example.com"}
+
+{"name": "Bruno"
+{"email":" carla@example.com ","name":"Carla","favorite_ids":[1]}
+`)
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, customer.ImportRow{Line: 1, Name: "Ana", Email: "ana@example.com"}, rows[0])
+	assert.Equal(t, 3, rows[1].Line)
+	assert.ErrorContains(t, rows[1].Err, "invalid JSON")
+	assert.Equal(t, customer.ImportRow{Line: 4, Name: "Carla", Email: "carla@example.com"}, rows[2])
+}
+
+func TestWriter_ExportCanBeImportedBack(t *testing.T) {
+	exports := []*entity.CustomerExport{
+		{Customer: &entity.Customer{Id: "id-1", Name: "Ana, Silva", Email: "ana@example.com"}, FavoriteIds: []int64{1, 7}},
+		{Customer: &entity.Customer{Id: "id-2", Name: "Bruno", Email: "bruno@example.com"}},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatNDJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buffer bytes.Buffer
+			writer, err := NewWriter(format, &buffer)
+			require.NoError(t, err)
+			for _, export := range exports {
+				require.NoError(t, writer.Write(export))
+			}
+			require.NoError(t, writer.Flush())
+
+			rows := readAll(t, format, buffer.String())
+			require.Len(t, rows, 2)
+			assert.Equal(t, "Ana, Silva", rows[0].Name)
+			assert.Equal(t, "bruno@example.com", rows[1].Email)
+		})
+	}
+}
+
+func TestWriter_Formats(t *testing.T) {
+	export := &entity.CustomerExport{Customer: &entity.Customer{Id: "id-1", Name: "Ana", Email: "ana@example.com"}}
+
+	var csvOutput, ndjsonOutput bytes.Buffer
+	csvWriter, _ := NewWriter(FormatCSV, &csvOutput)
+	require.NoError(t, csvWriter.Write(&entity.CustomerExport{Customer: export.Customer, FavoriteIds: []int64{1, 7}}))
+	require.NoError(t, csvWriter.Flush())
+	ndjsonWriter, _ := NewWriter(FormatNDJSON, &ndjsonOutput)
+	require.NoError(t, ndjsonWriter.Write(export))
+
+	assert.Equal(t, "id,name,email,favorite_ids\nid-1,Ana,ana@example.com,1;7\n", csvOutput.String())
+	assert.JSONEq(t, `{"id":"id-1","name":"Ana","email":"ana@example.com","favorite_ids":[]}`, ndjsonOutput.String())
+}
+
+func TestFormatDetection(t *testing.T) {
+	format, err := FormatFromContentType("text/csv; charset=utf-8")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = FormatFromPath("partner/customers.JSONL")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatNDJSON, format)
+
+	_, err = ParseFormat("xlsx")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,63 @@
+// Package customerfile reads and writes customers in bulk, as CSV or NDJSON
+// (one JSON object per line).
+package customerfile
+
+import (
+	"errors"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+var ErrUnknownFormat = errors.New("format must be csv or ndjson")
+
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatNDJSON:
+		return FormatNDJSON, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// FormatFromContentType accepts text/csv, application/x-ndjson and application/ndjson.
+func FormatFromContentType(contentType string) (Format, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV, nil
+	case "application/x-ndjson", "application/ndjson":
+		return FormatNDJSON, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// FormatFromPath picks the format from the file extension: .csv, .ndjson or .jsonl.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+
+	return "application/x-ndjson"
+}
@@ -0,0 +1,127 @@
+package customerfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/customer"
+)
+
+// maxLineSize bounds an NDJSON line.
+const maxLineSize = 1 << 20
+
+// ReadRows decodes the customers in r. A CSV file must start with a header
+// with name and email columns, in any order; other columns are ignored, so an
+// export can be imported back. Rows that cannot be decoded are yielded with
+// Err set, so one bad line does not stop the import.
+func ReadRows(format Format, r io.Reader) (iter.Seq[customer.ImportRow], error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatNDJSON:
+		return readNDJSON(r), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func readCSV(r io.Reader) (iter.Seq[customer.ImportRow], error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error while reading CSV header: %w", err)
+	}
+
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+	// Spreadsheets often save CSV files with a byte order mark.
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	nameColumn, emailColumn := slices.Index(header, "name"), slices.Index(header, "email")
+	if nameColumn < 0 || emailColumn < 0 {
+		return nil, errors.New("CSV header must have name and email columns")
+	}
+
+	return func(yield func(customer.ImportRow) bool) {
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				if !yield(customer.ImportRow{Line: parseErr.StartLine, Err: parseErr.Err}) {
+					return
+				}
+				continue
+			}
+
+			if err != nil {
+				yield(customer.ImportRow{Err: fmt.Errorf("error while reading CSV: %w", err)})
+				return
+			}
+
+			line, _ := reader.FieldPos(0)
+			row := customer.ImportRow{Line: line}
+			if nameColumn < len(record) {
+				row.Name = strings.TrimSpace(record[nameColumn])
+			}
+			if emailColumn < len(record) {
+				row.Email = strings.TrimSpace(record[emailColumn])
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+	}, nil
+}
+
+func readNDJSON(r io.Reader) iter.Seq[customer.ImportRow] {
+	return func(yield func(customer.ImportRow) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+		line := 0
+		for scanner.Scan() {
+			line++
+			content := strings.TrimSpace(scanner.Text())
+			if content == "" {
+				continue
+			}
+
+			var record struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+			}
+
+			row := customer.ImportRow{Line: line}
+			if err := json.Unmarshal([]byte(content), &record); err != nil {
+				row.Err = fmt.Errorf("invalid JSON: %w", err)
+			} else {
+				row.Name = strings.TrimSpace(record.Name)
+				row.Email = strings.TrimSpace(record.Email)
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(customer.ImportRow{Line: line + 1, Err: fmt.Errorf("error while reading NDJSON: %w", err)})
+		}
+	}
+}
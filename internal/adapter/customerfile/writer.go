@@ -0,0 +1,86 @@
+package customerfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+// Writer encodes exported customers. Output may be buffered until Flush.
+type Writer interface {
+	Write(*entity.CustomerExport) error
+	Flush() error
+}
+
+// NewWriter returns a Writer for format. The CSV columns are id, name, email
+// and favorite_ids, the ids separated by semicolons.
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type csvWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvWriter) Write(export *entity.CustomerExport) error {
+	if !c.wroteHeader {
+		if err := c.writer.Write([]string{"id", "name", "email", "favorite_ids"}); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+
+	ids := make([]string, len(export.FavoriteIds))
+	for i, id := range export.FavoriteIds {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+
+	return c.writer.Write([]string{export.Customer.Id, export.Customer.Name, export.Customer.Email, strings.Join(ids, ";")})
+}
+
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+type ndjsonCustomer struct {
+	Id          string  `json:"id"`
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
+	FavoriteIds []int64 `json:"favorite_ids"`
+}
+
+func (n *ndjsonWriter) Write(export *entity.CustomerExport) error {
+	favoriteIds := export.FavoriteIds
+	if favoriteIds == nil {
+		favoriteIds = []int64{}
+	}
+
+	return n.encoder.Encode(ndjsonCustomer{
+		Id:          export.Customer.Id,
+		Name:        export.Customer.Name,
+		Email:       export.Customer.Email,
+		FavoriteIds: favoriteIds,
+	})
+}
+
+// Flush is a no-op: the encoder writes every customer right away.
+func (n *ndjsonWriter) Flush() error {
+	return nil
+}
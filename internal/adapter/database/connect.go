@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+// maxConnectBackoff caps the wait between connection attempts.
+const maxConnectBackoff = 30 * time.Second
+
+// Open creates the connection pool with the configured limits and waits until
+// Postgres accepts connections, retrying with exponential backoff up to
+// conf.ConnectAttempts times.
+func Open(ctx context.Context, conf config.Database) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(conf.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing database config: %s", err)
+	}
+
+	poolConfig.MaxConns = int32(conf.MaxConns)
+	poolConfig.MinConns = int32(conf.MinConns)
+	poolConfig.MaxConnLifetime = conf.ConnMaxLifetime
+	poolConfig.MaxConnIdleTime = conf.ConnMaxIdleTime
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening database: %s", err)
+	}
+
+	if err := waitForDatabase(ctx, pool, conf.ConnectAttempts, conf.ConnectBackoff); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func waitForDatabase(ctx context.Context, pool *pgxpool.Pool, attempts int, backoff time.Duration) error {
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			logger.FromContext(ctx).Warn("database: not ready, retrying",
+				slog.Int("attempt", attempt),
+				slog.Duration("backoff", backoff),
+				slog.Any("error", lastErr),
+			)
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff = min(backoff*2, maxConnectBackoff)
+		}
+
+		if lastErr = pool.Ping(ctx); lastErr == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("error while connecting to database after %d attempts: %s", attempts, lastErr)
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unreachableDatabase() config.Database {
+	conf := config.Default().Database
+	conf.Host = "127.0.0.1"
+	conf.Port = 1
+	conf.User = "user"
+	conf.Name = "aiqfome"
+	conf.ConnectAttempts = 3
+	conf.ConnectBackoff = time.Millisecond
+	return conf
+}
+
+func TestOpen_GivesUpAfterConfiguredAttempts(t *testing.T) {
+	db, err := Open(context.Background(), unreachableDatabase())
+
+	assert.Nil(t, db)
+	assert.ErrorContains(t, err, "after 3 attempts")
+}
+
+func TestOpen_StopsRetryingWhenContextIsDone(t *testing.T) {
+	conf := unreachableDatabase()
+	conf.ConnectAttempts = 100
+	conf.ConnectBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := Open(ctx, conf)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDSN_IsParsedByPgx(t *testing.T) {
+	conf := unreachableDatabase()
+	conf.Password = `p@ss word'\`
+	conf.Schema = "app"
+
+	poolConfig, err := pgxpool.ParseConfig(conf.DSN())
+	require.NoError(t, err)
+
+	assert.Equal(t, "127.0.0.1", poolConfig.ConnConfig.Host)
+	assert.Equal(t, uint16(1), poolConfig.ConnConfig.Port)
+	assert.Equal(t, `p@ss word'\`, poolConfig.ConnConfig.Password)
+	assert.Equal(t, "app", poolConfig.ConnConfig.RuntimeParams["search_path"])
+}
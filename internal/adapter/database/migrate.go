@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	pgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/juliocsrf/aiqfome-challenge/config"
+	"github.com/juliocsrf/aiqfome-challenge/database/migrations"
+)
+
+// migrationsLockKey is the advisory lock held while migrations run, so
+// replicas starting together apply them one at a time.
+const migrationsLockKey = 4_210_037
+
+// Migrator applies the embedded migrations while holding migrationsLockKey.
+type Migrator struct {
+	pool    *pgxpool.Pool
+	conn    *pgxpool.Conn
+	migrate *migrate.Migrate
+}
+
+// NewMigrator waits for the migrations lock and prepares golang-migrate. It
+// opens a pool of its own, as golang-migrate closes the database it is given.
+// Close releases the lock.
+func NewMigrator(ctx context.Context, conf config.Database) (*Migrator, error) {
+	// One connection holds the lock, the other one is golang-migrate's.
+	conf.MaxConns = 2
+	conf.MinConns = 0
+
+	pool, err := Open(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("error while acquiring migrations connection: %s", err)
+	}
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationsLockKey); err != nil {
+		conn.Release()
+		pool.Close()
+		return nil, fmt.Errorf("error while locking migrations: %s", err)
+	}
+
+	migrator := &Migrator{pool: pool, conn: conn}
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		migrator.Close()
+		return nil, fmt.Errorf("error while reading migrations: %s", err)
+	}
+
+	driver, err := pgx.WithInstance(stdlib.OpenDBFromPool(pool), &pgx.Config{})
+	if err != nil {
+		migrator.Close()
+		return nil, fmt.Errorf("error while preparing migrations: %s", err)
+	}
+
+	migrator.migrate, err = migrate.NewWithInstance("iofs", source, "pgx5", driver)
+	if err != nil {
+		migrator.Close()
+		return nil, fmt.Errorf("error while preparing migrations: %s", err)
+	}
+
+	return migrator, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.migrate.Up())
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(steps int) error {
+	return ignoreNoChange(m.migrate.Steps(-steps))
+}
+
+// Goto migrates up or down to version.
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.migrate.Migrate(version))
+}
+
+// Force sets the version without running any migration, to recover from a
+// migration that failed halfway and left the database dirty.
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+// Version is the current version, zero when no migration was applied yet, and
+// whether the last migration failed halfway.
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
+
+// Close releases the migrations lock and the connections.
+func (m *Migrator) Close() error {
+	_, unlockErr := m.conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationsLockKey)
+	m.conn.Release()
+
+	var closeErr error
+	if m.migrate != nil {
+		_, closeErr = m.migrate.Close()
+	}
+	m.pool.Close()
+
+	return errors.Join(unlockErr, closeErr)
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return err
+}
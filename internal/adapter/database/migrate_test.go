@@ -0,0 +1,40 @@
+package database
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/juliocsrf/aiqfome-challenge/database/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations_AreReversible(t *testing.T) {
+	source, err := iofs.New(migrations.FS, ".")
+	require.NoError(t, err)
+	defer source.Close()
+
+	version, err := source.First()
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), version)
+
+	for {
+		up, _, err := source.ReadUp(version)
+		require.NoError(t, err, "version %d has no up migration", version)
+		up.Close()
+
+		down, _, err := source.ReadDown(version)
+		require.NoError(t, err, "version %d has no down migration", version)
+		down.Close()
+
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, version+1, next, "migration versions must be sequential")
+		version = next
+	}
+}
@@ -5,23 +5,83 @@
 package database
 
 import (
-	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type CatalogSyncRun struct {
+	ID           int64
+	Status       string
+	Fetched      int32
+	Inserted     int32
+	Updated      int32
+	Unchanged    int32
+	Discontinued int32
+	Error        string
+	StartedAt    time.Time
+	FinishedAt   *time.Time
+	Conflicts    int32
+}
+
 type Customer struct {
-	ID        uuid.UUID
-	Name      string
-	Email     string
-	CreatedAt sql.NullTime
-	UpdatedAt sql.NullTime
+	ID             uuid.UUID
+	Name           string
+	Email          string
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+	FavoritesQuota *int32
 }
 
 type Favorite struct {
+	CustomerID   uuid.UUID
+	ProductID    int64
+	CreatedAt    *time.Time
+	Title        string
+	Image        string
+	Price        float64
+	CollectionID uuid.UUID
+	Note         string
+	Quantity     int32
+	Priority     int16
+}
+
+type FavoriteCollection struct {
+	ID         uuid.UUID
 	CustomerID uuid.UUID
+	Name       string
+	IsDefault  bool
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+}
+
+type Product struct {
+	ID             int64
+	Title          string
+	Image          string
+	Price          float64
+	Rate           float64
+	RateCount      int64
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+	ContentHash    *string
+	DiscontinuedAt *time.Time
+	Description    string
+	Category       string
+}
+
+type ProductCoFavorite struct {
+	ProductID        int64
+	RelatedProductID int64
+	Customers        int32
+	ComputedAt       time.Time
+}
+
+type ProductPrice struct {
+	ID         int64
 	ProductID  int64
-	CreatedAt  sql.NullTime
+	Price      float64
+	RecordedAt time.Time
 }
 
 type User struct {
@@ -29,6 +89,32 @@ type User struct {
 	Name      string
 	Email     string
 	Password  string
-	CreatedAt sql.NullTime
-	UpdatedAt sql.NullTime
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+type Webhook struct {
+	ID        uuid.UUID
+	Url       string
+	Events    []string
+	Secret    string
+	Active    bool
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	ResponseStatus int32
+	LastError      string
+	CreatedAt      *time.Time
+	DeliveredAt    *time.Time
+	AttemptsLeft   int32
+	NextAttemptAt  *time.Time
 }
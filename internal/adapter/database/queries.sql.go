@@ -7,21 +7,254 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries SET next_attempt_at = $1::timestamptz
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= $2::timestamptz
+    ORDER BY next_attempt_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at, attempts_left, next_attempt_at
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	LeaseUntil    time.Time
+	Now           time.Time
+	MaxDeliveries int32
+}
+
+// Pushes the claimed deliveries to lease_until, so no other poller takes them
+// while they are sent; a delivery whose sender died is due again afterwards.
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, claimDueWebhookDeliveries, arg.LeaseUntil, arg.Now, arg.MaxDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+			&i.AttemptsLeft,
+			&i.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const copyFavoriteToCollection = `-- name: CopyFavoriteToCollection :execrows
+INSERT INTO favorites (collection_id, customer_id, product_id, title, image, price, note, quantity, priority)
+SELECT $1::uuid, customer_id, product_id, title, image, price, note, quantity, priority
+FROM favorites
+WHERE collection_id = $2::uuid AND product_id = $3::bigint
+`
+
+type CopyFavoriteToCollectionParams struct {
+	TargetID  uuid.UUID
+	SourceID  uuid.UUID
+	ProductID int64
+}
+
+func (q *Queries) CopyFavoriteToCollection(ctx context.Context, arg CopyFavoriteToCollectionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, copyFavoriteToCollection, arg.TargetID, arg.SourceID, arg.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countFavoriteProductsByCustomer = `-- name: CountFavoriteProductsByCustomer :one
+SELECT COUNT(DISTINCT product_id) FROM favorites WHERE customer_id = $1
+`
+
+func (q *Queries) CountFavoriteProductsByCustomer(ctx context.Context, customerID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countFavoriteProductsByCustomer, customerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFavoritesByProduct = `-- name: CountFavoritesByProduct :many
+SELECT product_id, COUNT(DISTINCT customer_id) AS favorites FROM favorites GROUP BY product_id ORDER BY product_id
+`
+
+type CountFavoritesByProductRow struct {
+	ProductID int64
+	Favorites int64
+}
+
+func (q *Queries) CountFavoritesByProduct(ctx context.Context) ([]CountFavoritesByProductRow, error) {
+	rows, err := q.db.Query(ctx, countFavoritesByProduct)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountFavoritesByProductRow
+	for rows.Next() {
+		var i CountFavoritesByProductRow
+		if err := rows.Scan(&i.ProductID, &i.Favorites); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countFavoritesByProductIds = `-- name: CountFavoritesByProductIds :many
+SELECT product_id, COUNT(DISTINCT customer_id) AS favorites
+FROM favorites
+WHERE product_id = ANY($1::bigint[])
+GROUP BY product_id
+`
+
+type CountFavoritesByProductIdsRow struct {
+	ProductID int64
+	Favorites int64
+}
+
+func (q *Queries) CountFavoritesByProductIds(ctx context.Context, productIds []int64) ([]CountFavoritesByProductIdsRow, error) {
+	rows, err := q.db.Query(ctx, countFavoritesByProductIds, productIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountFavoritesByProductIdsRow
+	for rows.Next() {
+		var i CountFavoritesByProductIdsRow
+		if err := rows.Scan(&i.ProductID, &i.Favorites); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countFavoritesByProductSince = `-- name: CountFavoritesByProductSince :many
+SELECT product_id, COUNT(DISTINCT customer_id) AS favorites
+FROM favorites
+WHERE created_at >= $1::timestamptz
+GROUP BY product_id
+ORDER BY favorites DESC, product_id
+LIMIT $3 OFFSET $2
+`
+
+type CountFavoritesByProductSinceParams struct {
+	Since      time.Time
+	PageOffset int32
+	PageSize   int32
+}
+
+type CountFavoritesByProductSinceRow struct {
+	ProductID int64
+	Favorites int64
+}
+
+func (q *Queries) CountFavoritesByProductSince(ctx context.Context, arg CountFavoritesByProductSinceParams) ([]CountFavoritesByProductSinceRow, error) {
+	rows, err := q.db.Query(ctx, countFavoritesByProductSince, arg.Since, arg.PageOffset, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountFavoritesByProductSinceRow
+	for rows.Next() {
+		var i CountFavoritesByProductSinceRow
+		if err := rows.Scan(&i.ProductID, &i.Favorites); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countSearchedProducts = `-- name: CountSearchedProducts :one
+SELECT COUNT(*) FROM products
+WHERE discontinued_at IS NULL
+    AND ($1::text IS NULL OR category = $1)
+    AND ($2::text IS NULL OR strpos(lower(title), lower($2)) > 0)
+    AND ($3::float8 IS NULL OR price >= $3)
+    AND ($4::float8 IS NULL OR price <= $4)
+    AND ($5::float8 IS NULL OR rate >= $5)
+    AND ($6::float8 IS NULL OR rate <= $6)
+`
+
+type CountSearchedProductsParams struct {
+	Category  *string
+	Search    *string
+	MinPrice  *float64
+	MaxPrice  *float64
+	MinRating *float64
+	MaxRating *float64
+}
+
+func (q *Queries) CountSearchedProducts(ctx context.Context, arg CountSearchedProductsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSearchedProducts,
+		arg.Category,
+		arg.Search,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.MinRating,
+		arg.MaxRating,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteCustomer = `-- name: DeleteCustomer :exec
 DELETE FROM customers WHERE id = $1
 `
 
 func (q *Queries) DeleteCustomer(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteCustomer, id)
+	_, err := q.db.Exec(ctx, deleteCustomer, id)
+	return err
+}
+
+const deleteFavoriteCollection = `-- name: DeleteFavoriteCollection :exec
+DELETE FROM favorite_collections WHERE id = $1
+`
+
+func (q *Queries) DeleteFavoriteCollection(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteFavoriteCollection, id)
 	return err
 }
 
-const deleteFavoriteCustomerProduct = `-- name: DeleteFavoriteCustomerProduct :exec
-DELETE FROM favorites WHERE customer_id = $1 AND product_id = $2
+const deleteFavoriteCustomerProduct = `-- name: DeleteFavoriteCustomerProduct :execrows
+DELETE FROM favorites f
+USING favorite_collections c
+WHERE c.id = f.collection_id AND c.is_default AND f.customer_id = $1 AND f.product_id = $2
 `
 
 type DeleteFavoriteCustomerProductParams struct {
@@ -29,17 +262,150 @@ type DeleteFavoriteCustomerProductParams struct {
 	ProductID  int64
 }
 
-func (q *Queries) DeleteFavoriteCustomerProduct(ctx context.Context, arg DeleteFavoriteCustomerProductParams) error {
-	_, err := q.db.ExecContext(ctx, deleteFavoriteCustomerProduct, arg.CustomerID, arg.ProductID)
+func (q *Queries) DeleteFavoriteCustomerProduct(ctx context.Context, arg DeleteFavoriteCustomerProductParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFavoriteCustomerProduct, arg.CustomerID, arg.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFavoriteFromCollection = `-- name: DeleteFavoriteFromCollection :execrows
+DELETE FROM favorites WHERE collection_id = $1 AND product_id = $2
+`
+
+type DeleteFavoriteFromCollectionParams struct {
+	CollectionID uuid.UUID
+	ProductID    int64
+}
+
+func (q *Queries) DeleteFavoriteFromCollection(ctx context.Context, arg DeleteFavoriteFromCollectionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFavoriteFromCollection, arg.CollectionID, arg.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFavoritesByProducts = `-- name: DeleteFavoritesByProducts :execrows
+DELETE FROM favorites WHERE product_id = ANY($1::bigint[])
+`
+
+func (q *Queries) DeleteFavoritesByProducts(ctx context.Context, productIds []int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFavoritesByProducts, productIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProduct = `-- name: DeleteProduct :execrows
+DELETE FROM products WHERE id = $1
+`
+
+func (q *Queries) DeleteProduct(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProduct, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProductCoFavorites = `-- name: DeleteProductCoFavorites :exec
+DELETE FROM product_co_favorites
+`
+
+func (q *Queries) DeleteProductCoFavorites(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteProductCoFavorites)
+	return err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :exec
+DELETE FROM webhooks WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteWebhook, id)
 	return err
 }
 
+const discontinueMissingProducts = `-- name: DiscontinueMissingProducts :execrows
+UPDATE products SET discontinued_at = NOW(), updated_at = NOW()
+WHERE content_hash IS NOT NULL AND discontinued_at IS NULL AND NOT (id = ANY($1::bigint[]))
+`
+
+func (q *Queries) DiscontinueMissingProducts(ctx context.Context, syncedIds []int64) (int64, error) {
+	result, err := q.db.Exec(ctx, discontinueMissingProducts, syncedIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const ensureDefaultFavoriteCollection = `-- name: EnsureDefaultFavoriteCollection :one
+INSERT INTO favorite_collections (id, customer_id, name, is_default) VALUES ($1, $2, $3, TRUE)
+ON CONFLICT (customer_id) WHERE is_default DO UPDATE SET is_default = TRUE
+RETURNING id, customer_id, name, is_default, created_at, updated_at
+`
+
+type EnsureDefaultFavoriteCollectionParams struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Name       string
+}
+
+func (q *Queries) EnsureDefaultFavoriteCollection(ctx context.Context, arg EnsureDefaultFavoriteCollectionParams) (FavoriteCollection, error) {
+	row := q.db.QueryRow(ctx, ensureDefaultFavoriteCollection, arg.ID, arg.CustomerID, arg.Name)
+	var i FavoriteCollection
+	err := row.Scan(
+		&i.ID,
+		&i.CustomerID,
+		&i.Name,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findActiveWebhooksByEvent = `-- name: FindActiveWebhooksByEvent :many
+SELECT id, url, events, secret, active, created_at, updated_at FROM webhooks WHERE active = TRUE AND $1::text = ANY(events)
+`
+
+func (q *Queries) FindActiveWebhooksByEvent(ctx context.Context, eventType string) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, findActiveWebhooksByEvent, eventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Events,
+			&i.Secret,
+			&i.Active,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findAllCustomers = `-- name: FindAllCustomers :many
-SELECT id, name, email, created_at, updated_at FROM customers
+SELECT id, name, email, created_at, updated_at, favorites_quota FROM customers
 `
 
 func (q *Queries) FindAllCustomers(ctx context.Context) ([]Customer, error) {
-	rows, err := q.db.QueryContext(ctx, findAllCustomers)
+	rows, err := q.db.Query(ctx, findAllCustomers)
 	if err != nil {
 		return nil, err
 	}
@@ -53,70 +419,665 @@ func (q *Queries) FindAllCustomers(ctx context.Context) ([]Customer, error) {
 			&i.Email,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FavoritesQuota,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllFavoriteProdutsFromCustomer = `-- name: FindAllFavoriteProdutsFromCustomer :many
+SELECT f.customer_id, f.product_id, f.created_at, f.title, f.image, f.price, f.collection_id, f.note, f.quantity, f.priority FROM favorites f
+JOIN favorite_collections c ON c.id = f.collection_id
+WHERE f.customer_id = $1 AND c.is_default
+ORDER BY f.created_at, f.product_id
+`
+
+func (q *Queries) FindAllFavoriteProdutsFromCustomer(ctx context.Context, customerID uuid.UUID) ([]Favorite, error) {
+	rows, err := q.db.Query(ctx, findAllFavoriteProdutsFromCustomer, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Favorite
+	for rows.Next() {
+		var i Favorite
+		if err := rows.Scan(
+			&i.CustomerID,
+			&i.ProductID,
+			&i.CreatedAt,
+			&i.Title,
+			&i.Image,
+			&i.Price,
+			&i.CollectionID,
+			&i.Note,
+			&i.Quantity,
+			&i.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllProducts = `-- name: FindAllProducts :many
+SELECT id, title, image, price, rate, rate_count, created_at, updated_at, content_hash, discontinued_at, description, category FROM products WHERE discontinued_at IS NULL ORDER BY id
+`
+
+func (q *Queries) FindAllProducts(ctx context.Context) ([]Product, error) {
+	rows, err := q.db.Query(ctx, findAllProducts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Image,
+			&i.Price,
+			&i.Rate,
+			&i.RateCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.DiscontinuedAt,
+			&i.Description,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllWebhookDeliveriesFromWebhook = `-- name: FindAllWebhookDeliveriesFromWebhook :many
+SELECT id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at, attempts_left, next_attempt_at FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY created_at DESC
+`
+
+func (q *Queries) FindAllWebhookDeliveriesFromWebhook(ctx context.Context, webhookID uuid.UUID) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, findAllWebhookDeliveriesFromWebhook, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+			&i.AttemptsLeft,
+			&i.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllWebhooks = `-- name: FindAllWebhooks :many
+SELECT id, url, events, secret, active, created_at, updated_at FROM webhooks ORDER BY created_at
+`
+
+func (q *Queries) FindAllWebhooks(ctx context.Context) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, findAllWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Events,
+			&i.Secret,
+			&i.Active,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findCoFavoritedProductsForCustomer = `-- name: FindCoFavoritedProductsForCustomer :many
+SELECT c.related_product_id, SUM(c.customers)::bigint AS customers
+FROM product_co_favorites c
+WHERE c.product_id IN (SELECT f.product_id FROM favorites f WHERE f.customer_id = $1)
+  AND c.related_product_id NOT IN (SELECT f.product_id FROM favorites f WHERE f.customer_id = $1)
+GROUP BY c.related_product_id
+ORDER BY customers DESC, c.related_product_id
+`
+
+type FindCoFavoritedProductsForCustomerRow struct {
+	RelatedProductID int64
+	Customers        int64
+}
+
+func (q *Queries) FindCoFavoritedProductsForCustomer(ctx context.Context, customerID uuid.UUID) ([]FindCoFavoritedProductsForCustomerRow, error) {
+	rows, err := q.db.Query(ctx, findCoFavoritedProductsForCustomer, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindCoFavoritedProductsForCustomerRow
+	for rows.Next() {
+		var i FindCoFavoritedProductsForCustomerRow
+		if err := rows.Scan(&i.RelatedProductID, &i.Customers); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findCustomerByEmail = `-- name: FindCustomerByEmail :one
+SELECT id, name, email, created_at, updated_at, favorites_quota FROM customers WHERE email = $1
+`
+
+func (q *Queries) FindCustomerByEmail(ctx context.Context, email string) (Customer, error) {
+	row := q.db.QueryRow(ctx, findCustomerByEmail, email)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FavoritesQuota,
+	)
+	return i, err
+}
+
+const findCustomerById = `-- name: FindCustomerById :one
+SELECT id, name, email, created_at, updated_at, favorites_quota FROM customers WHERE id = $1
+`
+
+func (q *Queries) FindCustomerById(ctx context.Context, id uuid.UUID) (Customer, error) {
+	row := q.db.QueryRow(ctx, findCustomerById, id)
+	var i Customer
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FavoritesQuota,
+	)
+	return i, err
+}
+
+const findCustomerIdsByFavoriteProduct = `-- name: FindCustomerIdsByFavoriteProduct :many
+SELECT DISTINCT customer_id FROM favorites WHERE product_id = $1 ORDER BY customer_id
+`
+
+func (q *Queries) FindCustomerIdsByFavoriteProduct(ctx context.Context, productID int64) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, findCustomerIdsByFavoriteProduct, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var customer_id uuid.UUID
+		if err := rows.Scan(&customer_id); err != nil {
+			return nil, err
+		}
+		items = append(items, customer_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findCustomersWithFavoriteIds = `-- name: FindCustomersWithFavoriteIds :many
+SELECT c.id, c.name, c.email,
+    COALESCE(array_agg(DISTINCT f.product_id ORDER BY f.product_id) FILTER (WHERE f.product_id IS NOT NULL), '{}')::bigint[] AS favorite_ids
+FROM (
+    SELECT id, name, email FROM customers
+    WHERE id > $1::uuid
+    ORDER BY id
+    LIMIT $2
+) c
+LEFT JOIN favorites f ON f.customer_id = c.id
+GROUP BY c.id, c.name, c.email
+ORDER BY c.id
+`
+
+type FindCustomersWithFavoriteIdsParams struct {
+	AfterID  uuid.UUID
+	PageSize int32
+}
+
+type FindCustomersWithFavoriteIdsRow struct {
+	ID          uuid.UUID
+	Name        string
+	Email       string
+	FavoriteIds []int64
+}
+
+func (q *Queries) FindCustomersWithFavoriteIds(ctx context.Context, arg FindCustomersWithFavoriteIdsParams) ([]FindCustomersWithFavoriteIdsRow, error) {
+	rows, err := q.db.Query(ctx, findCustomersWithFavoriteIds, arg.AfterID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindCustomersWithFavoriteIdsRow
+	for rows.Next() {
+		var i FindCustomersWithFavoriteIdsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.FavoriteIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFavoriteCollectionById = `-- name: FindFavoriteCollectionById :one
+SELECT id, customer_id, name, is_default, created_at, updated_at FROM favorite_collections WHERE id = $1 AND customer_id = $2
+`
+
+type FindFavoriteCollectionByIdParams struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+}
+
+func (q *Queries) FindFavoriteCollectionById(ctx context.Context, arg FindFavoriteCollectionByIdParams) (FavoriteCollection, error) {
+	row := q.db.QueryRow(ctx, findFavoriteCollectionById, arg.ID, arg.CustomerID)
+	var i FavoriteCollection
+	err := row.Scan(
+		&i.ID,
+		&i.CustomerID,
+		&i.Name,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findFavoriteCollectionsByCustomer = `-- name: FindFavoriteCollectionsByCustomer :many
+SELECT c.id, c.customer_id, c.name, c.is_default, c.created_at, c.updated_at, COUNT(f.product_id) AS favorites
+FROM favorite_collections c
+LEFT JOIN favorites f ON f.collection_id = c.id
+WHERE c.customer_id = $1
+GROUP BY c.id
+ORDER BY c.is_default DESC, c.created_at, c.name
+`
+
+type FindFavoriteCollectionsByCustomerRow struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Name       string
+	IsDefault  bool
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+	Favorites  int64
+}
+
+func (q *Queries) FindFavoriteCollectionsByCustomer(ctx context.Context, customerID uuid.UUID) ([]FindFavoriteCollectionsByCustomerRow, error) {
+	rows, err := q.db.Query(ctx, findFavoriteCollectionsByCustomer, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindFavoriteCollectionsByCustomerRow
+	for rows.Next() {
+		var i FindFavoriteCollectionsByCustomerRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CustomerID,
+			&i.Name,
+			&i.IsDefault,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Favorites,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFavoriteProductIdsByCustomer = `-- name: FindFavoriteProductIdsByCustomer :many
+SELECT DISTINCT product_id FROM favorites WHERE customer_id = $1 ORDER BY product_id
+`
+
+func (q *Queries) FindFavoriteProductIdsByCustomer(ctx context.Context, customerID uuid.UUID) ([]int64, error) {
+	rows, err := q.db.Query(ctx, findFavoriteProductIdsByCustomer, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var product_id int64
+		if err := rows.Scan(&product_id); err != nil {
+			return nil, err
+		}
+		items = append(items, product_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFavoritesByCollection = `-- name: FindFavoritesByCollection :many
+SELECT customer_id, product_id, created_at, title, image, price, collection_id, note, quantity, priority FROM favorites WHERE collection_id = $1 ORDER BY created_at, product_id
+`
+
+func (q *Queries) FindFavoritesByCollection(ctx context.Context, collectionID uuid.UUID) ([]Favorite, error) {
+	rows, err := q.db.Query(ctx, findFavoritesByCollection, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Favorite
+	for rows.Next() {
+		var i Favorite
+		if err := rows.Scan(
+			&i.CustomerID,
+			&i.ProductID,
+			&i.CreatedAt,
+			&i.Title,
+			&i.Image,
+			&i.Price,
+			&i.CollectionID,
+			&i.Note,
+			&i.Quantity,
+			&i.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findLatestProductPrices = `-- name: FindLatestProductPrices :many
+SELECT DISTINCT ON (product_id) product_id, price FROM product_prices ORDER BY product_id, recorded_at DESC, id DESC
+`
+
+type FindLatestProductPricesRow struct {
+	ProductID int64
+	Price     float64
+}
+
+func (q *Queries) FindLatestProductPrices(ctx context.Context) ([]FindLatestProductPricesRow, error) {
+	rows, err := q.db.Query(ctx, findLatestProductPrices)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindLatestProductPricesRow
+	for rows.Next() {
+		var i FindLatestProductPricesRow
+		if err := rows.Scan(&i.ProductID, &i.Price); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findLocalProductIds = `-- name: FindLocalProductIds :many
+SELECT id FROM products WHERE content_hash IS NULL AND id = ANY($1::bigint[]) ORDER BY id
+`
+
+func (q *Queries) FindLocalProductIds(ctx context.Context, ids []int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, findLocalProductIds, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findProductById = `-- name: FindProductById :one
+SELECT id, title, image, price, rate, rate_count, created_at, updated_at, content_hash, discontinued_at, description, category FROM products WHERE id = $1 AND discontinued_at IS NULL
+`
+
+func (q *Queries) FindProductById(ctx context.Context, id int64) (Product, error) {
+	row := q.db.QueryRow(ctx, findProductById, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Image,
+		&i.Price,
+		&i.Rate,
+		&i.RateCount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ContentHash,
+		&i.DiscontinuedAt,
+		&i.Description,
+		&i.Category,
+	)
+	return i, err
+}
+
+const findProductCategories = `-- name: FindProductCategories :many
+SELECT DISTINCT category FROM products WHERE category <> '' AND discontinued_at IS NULL ORDER BY category
+`
+
+func (q *Queries) FindProductCategories(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, findProductCategories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var category string
+		if err := rows.Scan(&category); err != nil {
+			return nil, err
+		}
+		items = append(items, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findProductPriceHistory = `-- name: FindProductPriceHistory :many
+SELECT id, product_id, price, recorded_at FROM product_prices WHERE product_id = $1 ORDER BY recorded_at, id
+`
+
+func (q *Queries) FindProductPriceHistory(ctx context.Context, productID int64) ([]ProductPrice, error) {
+	rows, err := q.db.Query(ctx, findProductPriceHistory, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductPrice
+	for rows.Next() {
+		var i ProductPrice
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Price,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findProductsByCategory = `-- name: FindProductsByCategory :many
+SELECT id, title, image, price, rate, rate_count, created_at, updated_at, content_hash, discontinued_at, description, category FROM products WHERE category = $1 AND discontinued_at IS NULL ORDER BY id
+`
+
+func (q *Queries) FindProductsByCategory(ctx context.Context, category string) ([]Product, error) {
+	rows, err := q.db.Query(ctx, findProductsByCategory, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Image,
+			&i.Price,
+			&i.Rate,
+			&i.RateCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.DiscontinuedAt,
+			&i.Description,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
 	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
-const findAllFavoriteProdutsFromCustomer = `-- name: FindAllFavoriteProdutsFromCustomer :many
-SELECT customer_id, product_id, created_at FROM favorites WHERE customer_id = $1
+const findProductsByIds = `-- name: FindProductsByIds :many
+SELECT id, title, image, price, rate, rate_count, created_at, updated_at, content_hash, discontinued_at, description, category FROM products WHERE id = ANY($1::bigint[]) AND discontinued_at IS NULL ORDER BY id
 `
 
-func (q *Queries) FindAllFavoriteProdutsFromCustomer(ctx context.Context, customerID uuid.UUID) ([]Favorite, error) {
-	rows, err := q.db.QueryContext(ctx, findAllFavoriteProdutsFromCustomer, customerID)
+func (q *Queries) FindProductsByIds(ctx context.Context, ids []int64) ([]Product, error) {
+	rows, err := q.db.Query(ctx, findProductsByIds, ids)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Favorite
+	var items []Product
 	for rows.Next() {
-		var i Favorite
-		if err := rows.Scan(&i.CustomerID, &i.ProductID, &i.CreatedAt); err != nil {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Image,
+			&i.Price,
+			&i.Rate,
+			&i.RateCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.DiscontinuedAt,
+			&i.Description,
+			&i.Category,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
 	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return items, nil
 }
 
-const findCustomerById = `-- name: FindCustomerById :one
-SELECT id, name, email, created_at, updated_at FROM customers WHERE id = $1
+const findUserByEmail = `-- name: FindUserByEmail :one
+SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1
 `
 
-func (q *Queries) FindCustomerById(ctx context.Context, id uuid.UUID) (Customer, error) {
-	row := q.db.QueryRowContext(ctx, findCustomerById, id)
-	var i Customer
+func (q *Queries) FindUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, findUserByEmail, email)
+	var i User
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Email,
+		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const findUserByEmail = `-- name: FindUserByEmail :one
-SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1
+const findUserById = `-- name: FindUserById :one
+SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1
 `
 
-func (q *Queries) FindUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, findUserByEmail, email)
+func (q *Queries) FindUserById(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, findUserById, id)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -129,24 +1090,101 @@ func (q *Queries) FindUserByEmail(ctx context.Context, email string) (User, erro
 	return i, err
 }
 
-const findUserById = `-- name: FindUserById :one
-SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1
+const findWebhookById = `-- name: FindWebhookById :one
+SELECT id, url, events, secret, active, created_at, updated_at FROM webhooks WHERE id = $1
 `
 
-func (q *Queries) FindUserById(ctx context.Context, id uuid.UUID) (User, error) {
-	row := q.db.QueryRowContext(ctx, findUserById, id)
-	var i User
+func (q *Queries) FindWebhookById(ctx context.Context, id uuid.UUID) (Webhook, error) {
+	row := q.db.QueryRow(ctx, findWebhookById, id)
+	var i Webhook
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Email,
-		&i.Password,
+		&i.Url,
+		&i.Events,
+		&i.Secret,
+		&i.Active,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const findWebhookDeliveryById = `-- name: FindWebhookDeliveryById :one
+SELECT id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error, created_at, delivered_at, attempts_left, next_attempt_at FROM webhook_deliveries WHERE id = $1
+`
+
+func (q *Queries) FindWebhookDeliveryById(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, findWebhookDeliveryById, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WebhookID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+		&i.AttemptsLeft,
+		&i.NextAttemptAt,
+	)
+	return i, err
+}
+
+const finishCatalogSyncRun = `-- name: FinishCatalogSyncRun :exec
+UPDATE catalog_sync_runs
+SET status = $1, fetched = $2, inserted = $3, updated = $4, unchanged = $5, discontinued = $6, conflicts = $7, error = $8, finished_at = $9
+WHERE id = $10
+`
+
+type FinishCatalogSyncRunParams struct {
+	Status       string
+	Fetched      int32
+	Inserted     int32
+	Updated      int32
+	Unchanged    int32
+	Discontinued int32
+	Conflicts    int32
+	Error        string
+	FinishedAt   *time.Time
+	ID           int64
+}
+
+func (q *Queries) FinishCatalogSyncRun(ctx context.Context, arg FinishCatalogSyncRunParams) error {
+	_, err := q.db.Exec(ctx, finishCatalogSyncRun,
+		arg.Status,
+		arg.Fetched,
+		arg.Inserted,
+		arg.Updated,
+		arg.Unchanged,
+		arg.Discontinued,
+		arg.Conflicts,
+		arg.Error,
+		arg.FinishedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const insertCatalogSyncRun = `-- name: InsertCatalogSyncRun :one
+INSERT INTO catalog_sync_runs (status, started_at) VALUES ($1, $2) RETURNING id
+`
+
+type InsertCatalogSyncRunParams struct {
+	Status    string
+	StartedAt time.Time
+}
+
+func (q *Queries) InsertCatalogSyncRun(ctx context.Context, arg InsertCatalogSyncRunParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertCatalogSyncRun, arg.Status, arg.StartedAt)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertCustomer = `-- name: InsertCustomer :exec
 INSERT INTO customers (id, name, email) values ($1, $2, $3)
 `
@@ -158,24 +1196,414 @@ type InsertCustomerParams struct {
 }
 
 func (q *Queries) InsertCustomer(ctx context.Context, arg InsertCustomerParams) error {
-	_, err := q.db.ExecContext(ctx, insertCustomer, arg.ID, arg.Name, arg.Email)
+	_, err := q.db.Exec(ctx, insertCustomer, arg.ID, arg.Name, arg.Email)
+	return err
+}
+
+const insertCustomers = `-- name: InsertCustomers :many
+INSERT INTO customers (id, name, email)
+SELECT unnest($1::uuid[]), unnest($2::text[]), unnest($3::text[])
+ON CONFLICT (email) DO NOTHING
+RETURNING email
+`
+
+type InsertCustomersParams struct {
+	Ids    []uuid.UUID
+	Names  []string
+	Emails []string
+}
+
+func (q *Queries) InsertCustomers(ctx context.Context, arg InsertCustomersParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, insertCustomers, arg.Ids, arg.Names, arg.Emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertFavoriteCollection = `-- name: InsertFavoriteCollection :exec
+INSERT INTO favorite_collections (id, customer_id, name) VALUES ($1, $2, $3)
+`
+
+type InsertFavoriteCollectionParams struct {
+	ID         uuid.UUID
+	CustomerID uuid.UUID
+	Name       string
+}
+
+func (q *Queries) InsertFavoriteCollection(ctx context.Context, arg InsertFavoriteCollectionParams) error {
+	_, err := q.db.Exec(ctx, insertFavoriteCollection, arg.ID, arg.CustomerID, arg.Name)
 	return err
 }
 
 const insertFavoriteCustomerProduct = `-- name: InsertFavoriteCustomerProduct :exec
-INSERT INTO favorites (customer_id, product_id) VALUES ($1, $2)
+INSERT INTO favorites (collection_id, customer_id, product_id, title, image, price, note, quantity, priority)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 `
 
 type InsertFavoriteCustomerProductParams struct {
+	CollectionID uuid.UUID
+	CustomerID   uuid.UUID
+	ProductID    int64
+	Title        string
+	Image        string
+	Price        float64
+	Note         string
+	Quantity     int32
+	Priority     int16
+}
+
+func (q *Queries) InsertFavoriteCustomerProduct(ctx context.Context, arg InsertFavoriteCustomerProductParams) error {
+	_, err := q.db.Exec(ctx, insertFavoriteCustomerProduct,
+		arg.CollectionID,
+		arg.CustomerID,
+		arg.ProductID,
+		arg.Title,
+		arg.Image,
+		arg.Price,
+		arg.Note,
+		arg.Quantity,
+		arg.Priority,
+	)
+	return err
+}
+
+const insertProduct = `-- name: InsertProduct :one
+INSERT INTO products (title, description, category, image, price, rate, rate_count) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+`
+
+type InsertProductParams struct {
+	Title       string
+	Description string
+	Category    string
+	Image       string
+	Price       float64
+	Rate        float64
+	RateCount   int64
+}
+
+func (q *Queries) InsertProduct(ctx context.Context, arg InsertProductParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertProduct,
+		arg.Title,
+		arg.Description,
+		arg.Category,
+		arg.Image,
+		arg.Price,
+		arg.Rate,
+		arg.RateCount,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertProductCoFavorites = `-- name: InsertProductCoFavorites :execrows
+INSERT INTO product_co_favorites (product_id, related_product_id, customers)
+SELECT a.product_id, b.product_id, COUNT(DISTINCT a.customer_id)
+FROM favorites a
+JOIN favorites b ON b.customer_id = a.customer_id AND b.product_id <> a.product_id
+GROUP BY a.product_id, b.product_id
+`
+
+func (q *Queries) InsertProductCoFavorites(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, insertProductCoFavorites)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertProductPrices = `-- name: InsertProductPrices :exec
+INSERT INTO product_prices (product_id, price, recorded_at)
+SELECT unnest($1::bigint[]), unnest($2::float8[]), unnest($3::timestamptz[])
+`
+
+type InsertProductPricesParams struct {
+	ProductIds  []int64
+	Prices      []float64
+	RecordedAts []time.Time
+}
+
+func (q *Queries) InsertProductPrices(ctx context.Context, arg InsertProductPricesParams) error {
+	_, err := q.db.Exec(ctx, insertProductPrices, arg.ProductIds, arg.Prices, arg.RecordedAts)
+	return err
+}
+
+const insertUser = `-- name: InsertUser :exec
+INSERT INTO users (id, name, email, password) VALUES ($1, $2, $3, $4)
+`
+
+type InsertUserParams struct {
+	ID       uuid.UUID
+	Name     string
+	Email    string
+	Password string
+}
+
+func (q *Queries) InsertUser(ctx context.Context, arg InsertUserParams) error {
+	_, err := q.db.Exec(ctx, insertUser,
+		arg.ID,
+		arg.Name,
+		arg.Email,
+		arg.Password,
+	)
+	return err
+}
+
+const insertWebhook = `-- name: InsertWebhook :exec
+INSERT INTO webhooks (id, url, events, secret, active) VALUES ($1, $2, $3, $4, $5)
+`
+
+type InsertWebhookParams struct {
+	ID     uuid.UUID
+	Url    string
+	Events []string
+	Secret string
+	Active bool
+}
+
+func (q *Queries) InsertWebhook(ctx context.Context, arg InsertWebhookParams) error {
+	_, err := q.db.Exec(ctx, insertWebhook,
+		arg.ID,
+		arg.Url,
+		arg.Events,
+		arg.Secret,
+		arg.Active,
+	)
+	return err
+}
+
+const insertWebhookDelivery = `-- name: InsertWebhookDelivery :exec
+INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, status, attempts, attempts_left, response_status, last_error, next_attempt_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+
+type InsertWebhookDeliveryParams struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	AttemptsLeft   int32
+	ResponseStatus int32
+	LastError      string
+	NextAttemptAt  *time.Time
+}
+
+func (q *Queries) InsertWebhookDelivery(ctx context.Context, arg InsertWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, insertWebhookDelivery,
+		arg.ID,
+		arg.WebhookID,
+		arg.EventID,
+		arg.EventType,
+		arg.Payload,
+		arg.Status,
+		arg.Attempts,
+		arg.AttemptsLeft,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.NextAttemptAt,
+	)
+	return err
+}
+
+const isProductFavoritedByCustomer = `-- name: IsProductFavoritedByCustomer :one
+SELECT EXISTS (SELECT 1 FROM favorites WHERE customer_id = $1 AND product_id = $2)
+`
+
+type IsProductFavoritedByCustomerParams struct {
 	CustomerID uuid.UUID
 	ProductID  int64
 }
 
-func (q *Queries) InsertFavoriteCustomerProduct(ctx context.Context, arg InsertFavoriteCustomerProductParams) error {
-	_, err := q.db.ExecContext(ctx, insertFavoriteCustomerProduct, arg.CustomerID, arg.ProductID)
+func (q *Queries) IsProductFavoritedByCustomer(ctx context.Context, arg IsProductFavoritedByCustomerParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isProductFavoritedByCustomer, arg.CustomerID, arg.ProductID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const lockCatalogSync = `-- name: LockCatalogSync :one
+SELECT pg_try_advisory_xact_lock($1::bigint)
+`
+
+func (q *Queries) LockCatalogSync(ctx context.Context, lockKey int64) (bool, error) {
+	row := q.db.QueryRow(ctx, lockCatalogSync, lockKey)
+	var pg_try_advisory_xact_lock bool
+	err := row.Scan(&pg_try_advisory_xact_lock)
+	return pg_try_advisory_xact_lock, err
+}
+
+const lockCustomerFavorites = `-- name: LockCustomerFavorites :one
+SELECT id FROM customers WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) LockCustomerFavorites(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockCustomerFavorites, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const lockPriceTracking = `-- name: LockPriceTracking :one
+SELECT pg_try_advisory_xact_lock($1::bigint)
+`
+
+func (q *Queries) LockPriceTracking(ctx context.Context, lockKey int64) (bool, error) {
+	row := q.db.QueryRow(ctx, lockPriceTracking, lockKey)
+	var pg_try_advisory_xact_lock bool
+	err := row.Scan(&pg_try_advisory_xact_lock)
+	return pg_try_advisory_xact_lock, err
+}
+
+const lockProductCoFavoritesRefresh = `-- name: LockProductCoFavoritesRefresh :one
+SELECT pg_try_advisory_xact_lock($1::bigint)
+`
+
+func (q *Queries) LockProductCoFavoritesRefresh(ctx context.Context, lockKey int64) (bool, error) {
+	row := q.db.QueryRow(ctx, lockProductCoFavoritesRefresh, lockKey)
+	var pg_try_advisory_xact_lock bool
+	err := row.Scan(&pg_try_advisory_xact_lock)
+	return pg_try_advisory_xact_lock, err
+}
+
+const moveFavoriteToCollection = `-- name: MoveFavoriteToCollection :execrows
+UPDATE favorites SET collection_id = $1::uuid WHERE collection_id = $2::uuid AND product_id = $3::bigint
+`
+
+type MoveFavoriteToCollectionParams struct {
+	TargetID  uuid.UUID
+	SourceID  uuid.UUID
+	ProductID int64
+}
+
+func (q *Queries) MoveFavoriteToCollection(ctx context.Context, arg MoveFavoriteToCollectionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveFavoriteToCollection, arg.TargetID, arg.SourceID, arg.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const requeueWebhookDelivery = `-- name: RequeueWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET status = 'pending', attempts_left = $1, delivered_at = NULL, next_attempt_at = $2::timestamptz
+WHERE id = $3 AND status <> 'pending'
+`
+
+type RequeueWebhookDeliveryParams struct {
+	AttemptsLeft  int32
+	NextAttemptAt time.Time
+	ID            uuid.UUID
+}
+
+func (q *Queries) RequeueWebhookDelivery(ctx context.Context, arg RequeueWebhookDeliveryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueWebhookDelivery, arg.AttemptsLeft, arg.NextAttemptAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetProductIdSequence = `-- name: ResetProductIdSequence :exec
+SELECT setval(pg_get_serial_sequence('products', 'id'), GREATEST((SELECT MAX(id) FROM products), 1))
+`
+
+func (q *Queries) ResetProductIdSequence(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, resetProductIdSequence)
 	return err
 }
 
+const searchProducts = `-- name: SearchProducts :many
+SELECT id, title, image, price, rate, rate_count, created_at, updated_at, content_hash, discontinued_at, description, category FROM products
+WHERE discontinued_at IS NULL
+    AND ($1::text IS NULL OR category = $1)
+    AND ($2::text IS NULL OR strpos(lower(title), lower($2)) > 0)
+    AND ($3::float8 IS NULL OR price >= $3)
+    AND ($4::float8 IS NULL OR price <= $4)
+    AND ($5::float8 IS NULL OR rate >= $5)
+    AND ($6::float8 IS NULL OR rate <= $6)
+ORDER BY
+    CASE WHEN $7::text = 'price' AND NOT $8::boolean THEN price END,
+    CASE WHEN $7::text = 'price' AND $8::boolean THEN price END DESC,
+    CASE WHEN $7::text = 'rating' AND NOT $8::boolean THEN rate END,
+    CASE WHEN $7::text = 'rating' AND $8::boolean THEN rate END DESC,
+    CASE WHEN $7::text = 'rate_count' AND NOT $8::boolean THEN rate_count END,
+    CASE WHEN $7::text = 'rate_count' AND $8::boolean THEN rate_count END DESC,
+    id
+LIMIT $10 OFFSET $9
+`
+
+type SearchProductsParams struct {
+	Category   *string
+	Search     *string
+	MinPrice   *float64
+	MaxPrice   *float64
+	MinRating  *float64
+	MaxRating  *float64
+	SortField  string
+	SortDesc   bool
+	PageOffset int32
+	PageSize   int32
+}
+
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]Product, error) {
+	rows, err := q.db.Query(ctx, searchProducts,
+		arg.Category,
+		arg.Search,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.MinRating,
+		arg.MaxRating,
+		arg.SortField,
+		arg.SortDesc,
+		arg.PageOffset,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Image,
+			&i.Price,
+			&i.Rate,
+			&i.RateCount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentHash,
+			&i.DiscontinuedAt,
+			&i.Description,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateCustomer = `-- name: UpdateCustomer :exec
 UPDATE customers SET name = $1, email = $2, updated_at = NOW() WHERE id = $3
 `
@@ -187,6 +1615,197 @@ type UpdateCustomerParams struct {
 }
 
 func (q *Queries) UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) error {
-	_, err := q.db.ExecContext(ctx, updateCustomer, arg.Name, arg.Email, arg.ID)
+	_, err := q.db.Exec(ctx, updateCustomer, arg.Name, arg.Email, arg.ID)
+	return err
+}
+
+const updateCustomerFavoritesQuota = `-- name: UpdateCustomerFavoritesQuota :exec
+UPDATE customers SET favorites_quota = $1, updated_at = NOW() WHERE id = $2
+`
+
+type UpdateCustomerFavoritesQuotaParams struct {
+	FavoritesQuota *int32
+	ID             uuid.UUID
+}
+
+func (q *Queries) UpdateCustomerFavoritesQuota(ctx context.Context, arg UpdateCustomerFavoritesQuotaParams) error {
+	_, err := q.db.Exec(ctx, updateCustomerFavoritesQuota, arg.FavoritesQuota, arg.ID)
+	return err
+}
+
+const updateFavoriteCollection = `-- name: UpdateFavoriteCollection :exec
+UPDATE favorite_collections SET name = $1, updated_at = NOW() WHERE id = $2
+`
+
+type UpdateFavoriteCollectionParams struct {
+	Name string
+	ID   uuid.UUID
+}
+
+func (q *Queries) UpdateFavoriteCollection(ctx context.Context, arg UpdateFavoriteCollectionParams) error {
+	_, err := q.db.Exec(ctx, updateFavoriteCollection, arg.Name, arg.ID)
+	return err
+}
+
+const updateFavoriteCustomerProduct = `-- name: UpdateFavoriteCustomerProduct :execrows
+UPDATE favorites f
+SET note = $3, quantity = $4, priority = $5
+FROM favorite_collections c
+WHERE c.id = f.collection_id AND c.is_default AND f.customer_id = $1 AND f.product_id = $2
+`
+
+type UpdateFavoriteCustomerProductParams struct {
+	CustomerID uuid.UUID
+	ProductID  int64
+	Note       string
+	Quantity   int32
+	Priority   int16
+}
+
+func (q *Queries) UpdateFavoriteCustomerProduct(ctx context.Context, arg UpdateFavoriteCustomerProductParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateFavoriteCustomerProduct,
+		arg.CustomerID,
+		arg.ProductID,
+		arg.Note,
+		arg.Quantity,
+		arg.Priority,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateProduct = `-- name: UpdateProduct :execrows
+UPDATE products SET title = $1, description = $2, category = $3, image = $4, price = $5, rate = $6, rate_count = $7, updated_at = NOW() WHERE id = $8
+`
+
+type UpdateProductParams struct {
+	Title       string
+	Description string
+	Category    string
+	Image       string
+	Price       float64
+	Rate        float64
+	RateCount   int64
+	ID          int64
+}
+
+func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateProduct,
+		arg.Title,
+		arg.Description,
+		arg.Category,
+		arg.Image,
+		arg.Price,
+		arg.Rate,
+		arg.RateCount,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateWebhook = `-- name: UpdateWebhook :exec
+UPDATE webhooks SET url = $1, events = $2, secret = $3, active = $4, updated_at = NOW() WHERE id = $5
+`
+
+type UpdateWebhookParams struct {
+	Url    string
+	Events []string
+	Secret string
+	Active bool
+	ID     uuid.UUID
+}
+
+func (q *Queries) UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) error {
+	_, err := q.db.Exec(ctx, updateWebhook,
+		arg.Url,
+		arg.Events,
+		arg.Secret,
+		arg.Active,
+		arg.ID,
+	)
+	return err
+}
+
+const updateWebhookDelivery = `-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = $1, attempts = $2, attempts_left = $3, response_status = $4, last_error = $5, delivered_at = $6, next_attempt_at = $7
+WHERE id = $8
+`
+
+type UpdateWebhookDeliveryParams struct {
+	Status         string
+	Attempts       int32
+	AttemptsLeft   int32
+	ResponseStatus int32
+	LastError      string
+	DeliveredAt    *time.Time
+	NextAttemptAt  *time.Time
+	ID             uuid.UUID
+}
+
+func (q *Queries) UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, updateWebhookDelivery,
+		arg.Status,
+		arg.Attempts,
+		arg.AttemptsLeft,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.DeliveredAt,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
 	return err
 }
+
+const upsertSyncedProduct = `-- name: UpsertSyncedProduct :one
+INSERT INTO products (id, title, description, category, image, price, rate, rate_count, content_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (id) DO UPDATE SET
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    category = EXCLUDED.category,
+    image = EXCLUDED.image,
+    price = EXCLUDED.price,
+    rate = EXCLUDED.rate,
+    rate_count = EXCLUDED.rate_count,
+    content_hash = EXCLUDED.content_hash,
+    discontinued_at = NULL,
+    updated_at = NOW()
+WHERE products.content_hash IS NOT NULL
+    AND (products.content_hash IS DISTINCT FROM EXCLUDED.content_hash OR products.discontinued_at IS NOT NULL)
+RETURNING (xmax = 0)::boolean AS inserted
+`
+
+type UpsertSyncedProductParams struct {
+	ID          int64
+	Title       string
+	Description string
+	Category    string
+	Image       string
+	Price       float64
+	Rate        float64
+	RateCount   int64
+	ContentHash *string
+}
+
+func (q *Queries) UpsertSyncedProduct(ctx context.Context, arg UpsertSyncedProductParams) (bool, error) {
+	row := q.db.QueryRow(ctx, upsertSyncedProduct,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.Category,
+		arg.Image,
+		arg.Price,
+		arg.Rate,
+		arg.RateCount,
+		arg.ContentHash,
+	)
+	var inserted bool
+	err := row.Scan(&inserted)
+	return inserted, err
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DatabaseChecker struct {
+	DB *pgxpool.Pool
+}
+
+func NewDatabaseChecker(db *pgxpool.Pool) *DatabaseChecker {
+	return &DatabaseChecker{
+		DB: db,
+	}
+}
+
+func (d *DatabaseChecker) Name() string {
+	return "database"
+}
+
+func (d *DatabaseChecker) Check(ctx context.Context) error {
+	return d.DB.Ping(ctx)
+}
+
+// HTTPChecker considers an upstream reachable when it answers with anything
+// other than a server error.
+type HTTPChecker struct {
+	CheckName string
+	URL       string
+	Client    *http.Client
+}
+
+func NewHTTPChecker(name, url string) *HTTPChecker {
+	return &HTTPChecker{
+		CheckName: name,
+		URL:       url,
+		Client:    http.DefaultClient,
+	}
+}
+
+func (h *HTTPChecker) Name() string {
+	return h.CheckName
+}
+
+func (h *HTTPChecker) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
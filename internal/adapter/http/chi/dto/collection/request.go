@@ -0,0 +1,9 @@
+package collection
+
+type CollectionRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type TransferFavoriteRequest struct {
+	TargetCollectionID string `json:"target_collection_id" validate:"required,uuid"`
+}
@@ -0,0 +1,71 @@
+package collection
+
+import (
+	customerDto "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/dto/customer"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type CollectionResponse struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	IsDefault      bool   `json:"is_default"`
+	FavoritesCount int64  `json:"favorites_count"`
+}
+
+type CollectionListResponse struct {
+	Collections []CollectionResponse `json:"collections"`
+	Total       int                  `json:"total"`
+}
+
+// CollectionDetailResponse lists the favorites in the same shape as the customer's favorites.
+type CollectionDetailResponse struct {
+	CollectionResponse
+	Favorites []customerDto.Favorite `json:"favorites"`
+}
+
+// TransferFavoriteResponse representa a resposta após mover ou copiar um favorito
+type TransferFavoriteResponse struct {
+	ProductID          int64  `json:"product_id"`
+	SourceCollectionID string `json:"source_collection_id"`
+	TargetCollectionID string `json:"target_collection_id"`
+	Message            string `json:"message"`
+}
+
+func FromEntity(collection *entity.Collection) *CollectionResponse {
+	return &CollectionResponse{
+		ID:             collection.Id,
+		Name:           collection.Name,
+		IsDefault:      collection.IsDefault,
+		FavoritesCount: collection.FavoritesCount,
+	}
+}
+
+func FromEntityWithFavorites(collection *entity.Collection) *CollectionDetailResponse {
+	return &CollectionDetailResponse{
+		CollectionResponse: *FromEntity(collection),
+		Favorites:          customerDto.FromFavorites(collection.Favorites),
+	}
+}
+
+func FromEntities(collections []*entity.Collection) *CollectionListResponse {
+	collectionResponses := make([]CollectionResponse, len(collections))
+	for i, collection := range collections {
+		collectionResponses[i] = *FromEntity(collection)
+	}
+
+	return &CollectionListResponse{
+		Collections: collectionResponses,
+		Total:       len(collectionResponses),
+	}
+}
+
+// ErrorResponse representa uma resposta de erro
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+// SuccessResponse representa uma resposta de sucesso genérica
+type SuccessResponse struct {
+	Message string `json:"message"`
+}
@@ -1,37 +1,144 @@
 package customer
 
-import "github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/customer"
+)
 
 type CustomerResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Favorites []Product `json:"favorites"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Favorites []Favorite `json:"favorites"`
 }
 
-type Product struct {
-	ID    int64   `json:"id"`
-	Title string  `json:"title"`
-	Image string  `json:"image"`
-	Price float64 `json:"price"`
+// Favorite shows the current catalog data when it could be loaded and the
+// snapshot taken when the product was favorited otherwise.
+type Favorite struct {
+	ID             int64      `json:"id"`
+	Title          string     `json:"title"`
+	Image          string     `json:"image"`
+	Price          float64    `json:"price"`
+	Available      bool       `json:"available"`
+	FavoritedPrice float64    `json:"favorited_price"`
+	FavoritedAt    *time.Time `json:"favorited_at,omitempty"`
+	Note           string     `json:"note"`
+	Quantity       int        `json:"quantity"`
+	Priority       string     `json:"priority"`
 }
 
 func FromEntity(customer *entity.Customer) *CustomerResponse {
-	favorites := make([]Product, len(customer.Favorites))
-	for i, product := range customer.Favorites {
-		favorites[i] = Product{
-			ID:    product.Id,
-			Title: product.Title,
-			Image: product.Image,
-			Price: product.Price,
-		}
-	}
-
 	return &CustomerResponse{
 		ID:        customer.Id,
 		Name:      customer.Name,
 		Email:     customer.Email,
-		Favorites: favorites,
+		Favorites: FromFavorites(customer.Favorites),
+	}
+}
+
+func FromFavorites(favorites []*entity.Favorite) []Favorite {
+	responses := make([]Favorite, len(favorites))
+	for i, favorite := range favorites {
+		responses[i] = Favorite{
+			ID:             favorite.ProductId,
+			Title:          favorite.Title,
+			Image:          favorite.Image,
+			Price:          favorite.Price,
+			Available:      favorite.Available,
+			FavoritedPrice: favorite.Price,
+			Note:           favorite.Note,
+			Quantity:       favorite.Quantity,
+			Priority:       favorite.Priority.String(),
+		}
+
+		if favorite.Product != nil {
+			responses[i].Title = favorite.Product.Title
+			responses[i].Image = favorite.Product.Image
+			responses[i].Price = favorite.Product.Price
+		}
+
+		if !favorite.CreatedAt.IsZero() {
+			responses[i].FavoritedAt = &favorite.CreatedAt
+		}
+	}
+
+	return responses
+}
+
+type Recommendation struct {
+	ID               int64   `json:"id"`
+	Title            string  `json:"title"`
+	Category         string  `json:"category"`
+	Image            string  `json:"image"`
+	Price            float64 `json:"price"`
+	Rate             float64 `json:"rate"`
+	CoFavorites      int64   `json:"co_favorites"`
+	CategoryAffinity int     `json:"category_affinity"`
+}
+
+type RecommendationListResponse struct {
+	CustomerID      string           `json:"customer_id"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+func FromRecommendations(customerId string, recommendations []*entity.Recommendation) *RecommendationListResponse {
+	responses := make([]Recommendation, len(recommendations))
+	for i, recommendation := range recommendations {
+		responses[i] = Recommendation{
+			ID:               recommendation.Product.Id,
+			Title:            recommendation.Product.Title,
+			Category:         recommendation.Product.Category,
+			Image:            recommendation.Product.Image,
+			Price:            recommendation.Product.Price,
+			Rate:             recommendation.Product.Rate,
+			CoFavorites:      recommendation.CoFavorites,
+			CategoryAffinity: recommendation.CategoryAffinity,
+		}
+	}
+
+	return &RecommendationListResponse{
+		CustomerID:      customerId,
+		Recommendations: responses,
+	}
+}
+
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Email   string `json:"email,omitempty"`
+	Error   string `json:"error"`
+	Skipped bool   `json:"skipped"`
+}
+
+// ImportReportResponse is the outcome of an import. Error is only set when the
+// import stopped early, in which case the report covers the rows read so far.
+type ImportReportResponse struct {
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Skipped int              `json:"skipped"`
+	Failed  int              `json:"failed"`
+	Errors  []ImportRowError `json:"errors"`
+	Error   string           `json:"error,omitempty"`
+}
+
+func FromImportReport(report *customer.ImportReport) *ImportReportResponse {
+	rowErrors := make([]ImportRowError, len(report.Errors))
+	for i, rowError := range report.Errors {
+		rowErrors[i] = ImportRowError{
+			Line:    rowError.Line,
+			Email:   rowError.Email,
+			Error:   rowError.Error,
+			Skipped: rowError.Skipped,
+		}
+	}
+
+	return &ImportReportResponse{
+		Total:   report.Total,
+		Created: report.Created,
+		Skipped: report.Skipped,
+		Failed:  report.Failed,
+		Errors:  rowErrors,
 	}
 }
 
@@ -9,3 +9,16 @@ type DeleteFavoriteRequest struct {
 	CustomerID string `json:"customer_id" validate:"required,uuid"`
 	ProductID  int64  `json:"product_id" validate:"required"`
 }
+
+// UpdateFavoriteRequest only changes the fields that are present.
+type UpdateFavoriteRequest struct {
+	Note     *string `json:"note" validate:"omitempty,max=500"`
+	Quantity *int    `json:"quantity" validate:"omitempty,min=1,max=999"`
+	Priority *string `json:"priority" validate:"omitempty,oneof=low normal high"`
+}
+
+// SetQuotaRequest overrides the customer's favorites limit; a null quota
+// restores the default.
+type SetQuotaRequest struct {
+	Quota *int `json:"quota" validate:"omitempty,min=1,max=2147483647"`
+}
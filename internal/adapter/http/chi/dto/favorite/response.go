@@ -1,5 +1,10 @@
 package favorite
 
+import (
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	favoriteUseCase "github.com/juliocsrf/aiqfome-challenge/internal/usecase/favorite"
+)
+
 // FavoriteResponse representa a resposta após operação com favoritos
 type FavoriteResponse struct {
 	CustomerID string `json:"customer_id"`
@@ -7,6 +12,77 @@ type FavoriteResponse struct {
 	Message    string `json:"message"`
 }
 
+// UpdateFavoriteResponse representa o favorito após a edição de nota, quantidade e prioridade
+type UpdateFavoriteResponse struct {
+	CustomerID string `json:"customer_id"`
+	ProductID  int64  `json:"product_id"`
+	Note       string `json:"note"`
+	Quantity   int    `json:"quantity"`
+	Priority   string `json:"priority"`
+	Message    string `json:"message"`
+}
+
+func FromUpdatedFavorite(customerId string, favorite *entity.Favorite) *UpdateFavoriteResponse {
+	return &UpdateFavoriteResponse{
+		CustomerID: customerId,
+		ProductID:  favorite.ProductId,
+		Note:       favorite.Note,
+		Quantity:   favorite.Quantity,
+		Priority:   favorite.Priority.String(),
+		Message:    "favorite updated successfully",
+	}
+}
+
+// OrphanFavorite é um produto favoritado que não existe mais no catálogo
+type OrphanFavorite struct {
+	ProductID int64 `json:"product_id"`
+	Favorites int64 `json:"favorites"`
+}
+
+// OrphanFavoritesResponse lista os produtos órfãos encontrados
+type OrphanFavoritesResponse struct {
+	Products []OrphanFavorite `json:"products"`
+	Total    int              `json:"total"`
+}
+
+// PruneOrphanFavoritesResponse informa os produtos órfãos e quantos favoritos foram removidos
+type PruneOrphanFavoritesResponse struct {
+	Products []OrphanFavorite `json:"products"`
+	Removed  int64            `json:"removed"`
+}
+
+func FromOrphans(orphans []*entity.FavoritedProduct) []OrphanFavorite {
+	products := make([]OrphanFavorite, len(orphans))
+	for i, orphan := range orphans {
+		products[i] = OrphanFavorite{
+			ProductID: orphan.ProductId,
+			Favorites: orphan.Favorites,
+		}
+	}
+
+	return products
+}
+
+// QuotaResponse representa o limite de favoritos de um cliente e quanto dele já foi usado
+type QuotaResponse struct {
+	CustomerID string `json:"customer_id"`
+	Limit      int    `json:"limit"`
+	Used       int64  `json:"used"`
+	Remaining  int64  `json:"remaining"`
+	// Quota is the customer's own limit, null when the default applies.
+	Quota *int `json:"quota"`
+}
+
+func FromQuota(quota *favoriteUseCase.Quota) *QuotaResponse {
+	return &QuotaResponse{
+		CustomerID: quota.CustomerId,
+		Limit:      quota.Limit,
+		Used:       quota.Used,
+		Remaining:  max(int64(quota.Limit)-quota.Used, 0),
+		Quota:      quota.Override,
+	}
+}
+
 // ErrorResponse representa uma resposta de erro
 type ErrorResponse struct {
 	Error   string `json:"error"`
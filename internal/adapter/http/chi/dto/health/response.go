@@ -0,0 +1,44 @@
+package health
+
+import "github.com/juliocsrf/aiqfome-challenge/internal/usecase/health"
+
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status string          `json:"status"`
+	Checks []CheckResponse `json:"checks"`
+}
+
+type CheckResponse struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+func FromResults(results []health.CheckResult, ready bool) *ReadinessResponse {
+	checks := make([]CheckResponse, len(results))
+	for i, result := range results {
+		checks[i] = CheckResponse{
+			Name:       result.Name,
+			Status:     statusOf(result.Healthy),
+			Error:      result.Error,
+			DurationMs: result.Duration.Milliseconds(),
+		}
+	}
+
+	return &ReadinessResponse{
+		Status: statusOf(ready),
+		Checks: checks,
+	}
+}
+
+func statusOf(healthy bool) string {
+	if healthy {
+		return "ok"
+	}
+
+	return "unavailable"
+}
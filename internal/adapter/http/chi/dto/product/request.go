@@ -0,0 +1,52 @@
+package product
+
+import (
+	"strings"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type CreateProductRequest struct {
+	Title       string  `json:"title" validate:"required"`
+	Description string  `json:"description"`
+	Category    string  `json:"category" validate:"max=255"`
+	Image       string  `json:"image" validate:"required,url"`
+	Price       float64 `json:"price" validate:"required,gt=0"`
+	Rate        float64 `json:"rate" validate:"gte=0,lte=5"`
+	RateCount   int64   `json:"rate_count" validate:"gte=0"`
+}
+
+type UpdateProductRequest struct {
+	Title       string  `json:"title" validate:"required"`
+	Description string  `json:"description"`
+	Category    string  `json:"category" validate:"max=255"`
+	Image       string  `json:"image" validate:"required,url"`
+	Price       float64 `json:"price" validate:"required,gt=0"`
+	Rate        float64 `json:"rate" validate:"gte=0,lte=5"`
+	RateCount   int64   `json:"rate_count" validate:"gte=0"`
+}
+
+func (r *CreateProductRequest) ToEntity() (*entity.Product, error) {
+	return entity.NewProductWithoutId(
+		strings.TrimSpace(r.Title),
+		strings.TrimSpace(r.Description),
+		strings.TrimSpace(r.Category),
+		strings.TrimSpace(r.Image),
+		r.Price,
+		r.Rate,
+		r.RateCount,
+	)
+}
+
+func (r *UpdateProductRequest) ToEntityWithId(id int64) (*entity.Product, error) {
+	return entity.NewProduct(
+		id,
+		strings.TrimSpace(r.Title),
+		strings.TrimSpace(r.Description),
+		strings.TrimSpace(r.Category),
+		strings.TrimSpace(r.Image),
+		r.Price,
+		r.Rate,
+		r.RateCount,
+	)
+}
@@ -1,29 +1,43 @@
 package product
 
-import "github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/product"
+)
 
 type ProductResponse struct {
-	ID        int64   `json:"id"`
-	Title     string  `json:"title"`
-	Image     string  `json:"image"`
-	Price     float64 `json:"price"`
-	Rate      float64 `json:"rate"`
-	RateCount int64   `json:"rate_count"`
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Category    string  `json:"category"`
+	Image       string  `json:"image"`
+	Price       float64 `json:"price"`
+	Rate        float64 `json:"rate"`
+	RateCount   int64   `json:"rate_count"`
+	// FavoriteCount is only present when requested with include=favorite_count.
+	FavoriteCount *int64 `json:"favorite_count,omitempty"`
 }
 
 type ProductListResponse struct {
 	Products []ProductResponse `json:"products"`
-	Total    int               `json:"total"`
+	// Total is the number of products matching the filters, across all pages.
+	Total  int `json:"total"`
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset"`
 }
 
 func FromEntity(product *entity.Product) *ProductResponse {
 	return &ProductResponse{
-		ID:        product.Id,
-		Title:     product.Title,
-		Image:     product.Image,
-		Price:     product.Price,
-		Rate:      product.Rate,
-		RateCount: product.RateCount,
+		ID:          product.Id,
+		Title:       product.Title,
+		Description: product.Description,
+		Category:    product.Category,
+		Image:       product.Image,
+		Price:       product.Price,
+		Rate:        product.Rate,
+		RateCount:   product.RateCount,
 	}
 }
 
@@ -39,6 +53,71 @@ func FromEntities(products []*entity.Product) *ProductListResponse {
 	}
 }
 
+// WithFavoriteCounts embeds the favorite count of every product in the list.
+// Products missing from counts were favorited by nobody.
+func (r *ProductListResponse) WithFavoriteCounts(counts map[int64]int64) {
+	for i := range r.Products {
+		count := counts[r.Products[i].ID]
+		r.Products[i].FavoriteCount = &count
+	}
+}
+
+type FavoriteCountResponse struct {
+	ProductID     int64 `json:"product_id"`
+	FavoriteCount int64 `json:"favorite_count"`
+}
+
+type MostFavoritedResponse struct {
+	Since    time.Time         `json:"since"`
+	Products []ProductResponse `json:"products"`
+}
+
+func FromMostFavorited(ranking *product.MostFavorited) *MostFavoritedResponse {
+	products := make([]ProductResponse, len(ranking.Products))
+	for i, ranked := range ranking.Products {
+		products[i] = *FromEntity(ranked.Product)
+		products[i].FavoriteCount = &ranked.FavoriteCount
+	}
+
+	return &MostFavoritedResponse{
+		Since:    ranking.Since,
+		Products: products,
+	}
+}
+
+type PricePointResponse struct {
+	Price      float64   `json:"price"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type PriceHistoryResponse struct {
+	ProductID int64                `json:"product_id"`
+	Prices    []PricePointResponse `json:"prices"`
+}
+
+func FromPricePoints(productId int64, points []*entity.PricePoint) *PriceHistoryResponse {
+	prices := make([]PricePointResponse, len(points))
+	for i, point := range points {
+		prices[i] = PricePointResponse{
+			Price:      point.Price,
+			RecordedAt: point.RecordedAt,
+		}
+	}
+
+	return &PriceHistoryResponse{
+		ProductID: productId,
+		Prices:    prices,
+	}
+}
+
+type CategoryListResponse struct {
+	Categories []string `json:"categories"`
+}
+
+type SuccessResponse struct {
+	Message string `json:"message"`
+}
+
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message,omitempty"`
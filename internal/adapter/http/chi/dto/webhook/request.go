@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"strings"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type CreateWebhookRequest struct {
+	Url    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Secret string   `json:"secret,omitempty"`
+}
+
+type UpdateWebhookRequest struct {
+	Url    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Active *bool    `json:"active" validate:"required"`
+}
+
+func (r *CreateWebhookRequest) ToEntity() (*entity.Webhook, error) {
+	return entity.NewWebhook(
+		strings.TrimSpace(r.Url),
+		r.Events,
+		r.Secret,
+	)
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type WebhookResponse struct {
+	ID     string   `json:"id"`
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	Secret string   `json:"secret,omitempty"`
+}
+
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+	Total    int               `json:"total"`
+}
+
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+}
+
+type DeliveryListResponse struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+	Total      int                `json:"total"`
+}
+
+// FromEntity omits the secret, which is only exposed once on creation.
+func FromEntity(webhook *entity.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:     webhook.Id,
+		Url:    webhook.Url,
+		Events: webhook.Events,
+		Active: webhook.Active,
+	}
+}
+
+func FromEntityWithSecret(webhook *entity.Webhook) *WebhookResponse {
+	response := FromEntity(webhook)
+	response.Secret = webhook.Secret
+	return response
+}
+
+func FromEntities(webhooks []*entity.Webhook) *WebhookListResponse {
+	webhookResponses := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		webhookResponses[i] = *FromEntity(webhook)
+	}
+
+	return &WebhookListResponse{
+		Webhooks: webhookResponses,
+		Total:    len(webhookResponses),
+	}
+}
+
+func FromDeliveryEntity(delivery *entity.WebhookDelivery) *DeliveryResponse {
+	return &DeliveryResponse{
+		ID:             delivery.Id,
+		WebhookID:      delivery.WebhookId,
+		EventID:        delivery.EventId,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		NextAttemptAt:  delivery.NextAttemptAt,
+	}
+}
+
+func FromDeliveryEntities(deliveries []*entity.WebhookDelivery) *DeliveryListResponse {
+	deliveryResponses := make([]DeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryResponses[i] = *FromDeliveryEntity(delivery)
+	}
+
+	return &DeliveryListResponse{
+		Deliveries: deliveryResponses,
+		Total:      len(deliveryResponses),
+	}
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+type SuccessResponse struct {
+	Message string `json:"message"`
+}
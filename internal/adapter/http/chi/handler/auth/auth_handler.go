@@ -46,7 +46,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.LoginUseCase.Execute(req.Email, req.Password)
+	result, err := h.LoginUseCase.Execute(r.Context(), req.Email, req.Password)
 	if err != nil {
 		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
 		return
@@ -83,7 +83,7 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.RefreshTokenUseCase.Execute(req.RefreshToken)
+	result, err := h.RefreshTokenUseCase.Execute(r.Context(), req.RefreshToken)
 	if err != nil {
 		utils.RespondWithJSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
 		return
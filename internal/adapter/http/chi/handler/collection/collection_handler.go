@@ -0,0 +1,357 @@
+package collection
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	collectionDto "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/dto/collection"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/utils"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/collection"
+)
+
+type CollectionHandler struct {
+	FindAllUseCase        *collection.FindAllCollectionUseCase
+	FindByIdUseCase       *collection.FindByIdCollectionUseCase
+	CreateUseCase         *collection.CreateCollectionUseCase
+	EditUseCase           *collection.EditCollectionUseCase
+	DeleteUseCase         *collection.DeleteCollectionUseCase
+	AddFavoriteUseCase    *collection.AddFavoriteCollectionUseCase
+	RemoveFavoriteUseCase *collection.RemoveFavoriteCollectionUseCase
+	TransferUseCase       *collection.TransferFavoriteCollectionUseCase
+	validator             *validator.Validate
+}
+
+func NewCollectionHandler(
+	findAllUseCase *collection.FindAllCollectionUseCase,
+	findByIdUseCase *collection.FindByIdCollectionUseCase,
+	createUseCase *collection.CreateCollectionUseCase,
+	editUseCase *collection.EditCollectionUseCase,
+	deleteUseCase *collection.DeleteCollectionUseCase,
+	addFavoriteUseCase *collection.AddFavoriteCollectionUseCase,
+	removeFavoriteUseCase *collection.RemoveFavoriteCollectionUseCase,
+	transferUseCase *collection.TransferFavoriteCollectionUseCase,
+) *CollectionHandler {
+	return &CollectionHandler{
+		FindAllUseCase:        findAllUseCase,
+		FindByIdUseCase:       findByIdUseCase,
+		CreateUseCase:         createUseCase,
+		EditUseCase:           editUseCase,
+		DeleteUseCase:         deleteUseCase,
+		AddFavoriteUseCase:    addFavoriteUseCase,
+		RemoveFavoriteUseCase: removeFavoriteUseCase,
+		TransferUseCase:       transferUseCase,
+		validator:             validator.New(),
+	}
+}
+
+// GetCollections godoc
+// @Summary List favorite collections
+// @Description Get the customer's favorite collections, default collection first
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Success 200 {object} collectionDto.CollectionListResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections [get]
+func (h *CollectionHandler) GetCollections(w http.ResponseWriter, r *http.Request) {
+	collections, err := h.FindAllUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"))
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, collectionDto.FromEntities(collections))
+}
+
+// GetCollection godoc
+// @Summary Get favorite collection
+// @Description Get a favorite collection with its favorites
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param collection_id path string true "Collection ID"
+// @Param sort query string false "Sort favorites by created_at, title, price, quantity or priority; prefix with - for descending"
+// @Success 200 {object} collectionDto.CollectionDetailResponse
+// @Failure 400 {object} collectionDto.ErrorResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections/{collection_id} [get]
+func (h *CollectionHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	collectionEntity, err := h.FindByIdUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"), chi.URLParam(r, "collection_id"), r.URL.Query().Get("sort"))
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, collectionDto.FromEntityWithFavorites(collectionEntity))
+}
+
+// CreateCollection godoc
+// @Summary Create favorite collection
+// @Description Create a named list of favorites for the customer
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param request body collectionDto.CollectionRequest true "Collection data"
+// @Success 201 {object} collectionDto.CollectionResponse
+// @Failure 400 {object} collectionDto.ErrorResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Failure 409 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections [post]
+func (h *CollectionHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	var req collectionDto.CollectionRequest
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	collectionEntity, err := h.CreateUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"), req.Name)
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, collectionDto.FromEntity(collectionEntity))
+}
+
+// UpdateCollection godoc
+// @Summary Rename favorite collection
+// @Description Rename a favorite collection. The default collection cannot be renamed.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param collection_id path string true "Collection ID"
+// @Param request body collectionDto.CollectionRequest true "Collection data"
+// @Success 200 {object} collectionDto.CollectionResponse
+// @Failure 400 {object} collectionDto.ErrorResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Failure 409 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections/{collection_id} [put]
+func (h *CollectionHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	var req collectionDto.CollectionRequest
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	collectionEntity, err := h.EditUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"), chi.URLParam(r, "collection_id"), req.Name)
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, collectionDto.FromEntity(collectionEntity))
+}
+
+// DeleteCollection godoc
+// @Summary Delete favorite collection
+// @Description Delete a favorite collection and the favorites in it. The default collection cannot be deleted.
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param collection_id path string true "Collection ID"
+// @Success 200 {object} collectionDto.SuccessResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Failure 409 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections/{collection_id} [delete]
+func (h *CollectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	err := h.DeleteUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"), chi.URLParam(r, "collection_id"))
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, collectionDto.SuccessResponse{Message: "collection deleted successfully"})
+}
+
+// AddFavorite godoc
+// @Summary Add product to collection
+// @Description Add a catalog product to a favorite collection
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param collection_id path string true "Collection ID"
+// @Param product_id path int true "Product ID"
+// @Success 201 {object} collectionDto.SuccessResponse
+// @Failure 400 {object} collectionDto.ErrorResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Failure 409 {object} collectionDto.ErrorResponse
+// @Failure 422 {object} collectionDto.ErrorResponse
+// @Failure 503 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections/{collection_id}/favorites/{product_id} [post]
+func (h *CollectionHandler) AddFavorite(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "product_id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	err = h.AddFavoriteUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"), chi.URLParam(r, "collection_id"), productID)
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, collectionDto.SuccessResponse{Message: "product added to collection successfully"})
+}
+
+// RemoveFavorite godoc
+// @Summary Remove product from collection
+// @Description Remove a product from a favorite collection
+// @Tags collections
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param collection_id path string true "Collection ID"
+// @Param product_id path int true "Product ID"
+// @Success 200 {object} collectionDto.SuccessResponse
+// @Failure 400 {object} collectionDto.ErrorResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections/{collection_id}/favorites/{product_id} [delete]
+func (h *CollectionHandler) RemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "product_id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	err = h.RemoveFavoriteUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"), chi.URLParam(r, "collection_id"), productID)
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, collectionDto.SuccessResponse{Message: "product removed from collection successfully"})
+}
+
+// MoveFavorite godoc
+// @Summary Move favorite to another collection
+// @Description Move a favorite to another collection of the same customer
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param collection_id path string true "Source collection ID"
+// @Param product_id path int true "Product ID"
+// @Param request body collectionDto.TransferFavoriteRequest true "Target collection"
+// @Success 200 {object} collectionDto.TransferFavoriteResponse
+// @Failure 400 {object} collectionDto.ErrorResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Failure 409 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections/{collection_id}/favorites/{product_id}/move [post]
+func (h *CollectionHandler) MoveFavorite(w http.ResponseWriter, r *http.Request) {
+	h.transferFavorite(w, r, collection.TransferMove, "favorite moved successfully")
+}
+
+// CopyFavorite godoc
+// @Summary Copy favorite to another collection
+// @Description Copy a favorite to another collection of the same customer, keeping it in the source collection
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param collection_id path string true "Source collection ID"
+// @Param product_id path int true "Product ID"
+// @Param request body collectionDto.TransferFavoriteRequest true "Target collection"
+// @Success 200 {object} collectionDto.TransferFavoriteResponse
+// @Failure 400 {object} collectionDto.ErrorResponse
+// @Failure 401 {object} collectionDto.ErrorResponse
+// @Failure 404 {object} collectionDto.ErrorResponse
+// @Failure 409 {object} collectionDto.ErrorResponse
+// @Router /customers/{customer_id}/collections/{collection_id}/favorites/{product_id}/copy [post]
+func (h *CollectionHandler) CopyFavorite(w http.ResponseWriter, r *http.Request) {
+	h.transferFavorite(w, r, collection.TransferCopy, "favorite copied successfully")
+}
+
+func (h *CollectionHandler) transferFavorite(w http.ResponseWriter, r *http.Request, mode collection.TransferMode, message string) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "product_id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	var req collectionDto.TransferFavoriteRequest
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	sourceID := chi.URLParam(r, "collection_id")
+	err = h.TransferUseCase.Execute(r.Context(), mode, chi.URLParam(r, "customer_id"), sourceID, req.TargetCollectionID, productID)
+	if err != nil {
+		h.writeUseCaseError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, collectionDto.TransferFavoriteResponse{
+		ProductID:          productID,
+		SourceCollectionID: sourceID,
+		TargetCollectionID: req.TargetCollectionID,
+		Message:            message,
+	})
+}
+
+func (h *CollectionHandler) writeUseCaseError(w http.ResponseWriter, err error) {
+	switch {
+	case err.Error() == "customer not found" || err.Error() == "collection not found" || err.Error() == "product not found":
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, collection.ErrFavoriteNotInCollection):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrCollectionNameTaken), errors.Is(err, repository.ErrFavoriteAlreadyInCollection), errors.Is(err, collection.ErrDefaultCollection):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, collection.ErrSameCollection), errors.Is(err, entity.ErrCollectionNameEmpty), errors.Is(err, entity.ErrCollectionNameTooLong),
+		errors.Is(err, entity.ErrFavoriteSortInvalid):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrFavoritesLimitReached):
+		h.writeErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, repository.ErrProductServiceUnavailable):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func (h *CollectionHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *CollectionHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, error string) {
+	response := collectionDto.ErrorResponse{
+		Error: error,
+	}
+	h.writeJSONResponse(w, statusCode, response)
+}
@@ -3,21 +3,47 @@ package customer
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/customerfile"
 	customerDto "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/dto/customer"
 	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/utils"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/customer"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/recommendation"
 )
 
+const (
+	defaultRecommendationsLimit = 10
+	// exportFlushEvery is how many customers are streamed between flushes.
+	exportFlushEvery = 100
+)
+
+var errImportTooManyRows = errors.New("import file has too many rows")
+
 type CustomerHandler struct {
-	CreateUseCase   *customer.CreateCustomerUseCase
-	FindByIdUseCase *customer.FindByIdCustomerUseCase
-	EditUseCase     *customer.EditCustomerUseCase
-	DeleteUseCase   *customer.DeleteCustomerUseCase
-	validator       *validator.Validate
+	CreateUseCase          *customer.CreateCustomerUseCase
+	FindByIdUseCase        *customer.FindByIdCustomerUseCase
+	EditUseCase            *customer.EditCustomerUseCase
+	DeleteUseCase          *customer.DeleteCustomerUseCase
+	RecommendationsUseCase *recommendation.FindRecommendationsUseCase
+	ImportUseCase          *customer.ImportCustomersUseCase
+	ExportUseCase          *customer.ExportCustomersUseCase
+	// ImportMaxBytes and ImportMaxRows bound an import file; a larger one
+	// is rejected with 413 before any customer is created.
+	ImportMaxBytes int
+	ImportMaxRows  int
+	validator      *validator.Validate
 }
 
 func NewCustomerHandler(
@@ -25,14 +51,24 @@ func NewCustomerHandler(
 	findByIdUseCase *customer.FindByIdCustomerUseCase,
 	editUseCase *customer.EditCustomerUseCase,
 	deleteUseCase *customer.DeleteCustomerUseCase,
+	recommendationsUseCase *recommendation.FindRecommendationsUseCase,
+	importUseCase *customer.ImportCustomersUseCase,
+	exportUseCase *customer.ExportCustomersUseCase,
+	importMaxBytes int,
+	importMaxRows int,
 ) *CustomerHandler {
 	validator := validator.New()
 	return &CustomerHandler{
-		CreateUseCase:   createUseCase,
-		FindByIdUseCase: findByIdUseCase,
-		EditUseCase:     editUseCase,
-		DeleteUseCase:   deleteUseCase,
-		validator:       validator,
+		CreateUseCase:          createUseCase,
+		FindByIdUseCase:        findByIdUseCase,
+		EditUseCase:            editUseCase,
+		DeleteUseCase:          deleteUseCase,
+		RecommendationsUseCase: recommendationsUseCase,
+		ImportUseCase:          importUseCase,
+		ExportUseCase:          exportUseCase,
+		ImportMaxBytes:         importMaxBytes,
+		ImportMaxRows:          importMaxRows,
+		validator:              validator,
 	}
 }
 
@@ -65,7 +101,7 @@ func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	createdCustomer, err := h.CreateUseCase.Execute(customerEntity)
+	createdCustomer, err := h.CreateUseCase.Execute(r.Context(), customerEntity)
 	if err != nil {
 		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
@@ -83,6 +119,7 @@ func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request)
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Customer ID"
+// @Param sort query string false "Sort favorites by created_at, title, price, quantity or priority; prefix with - for descending"
 // @Success 200 {object} customerDto.CustomerResponse
 // @Failure 400 {object} customerDto.ErrorResponse
 // @Failure 401 {object} customerDto.ErrorResponse
@@ -95,7 +132,12 @@ func (h *CustomerHandler) GetCustomer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	customerEntity, err := h.FindByIdUseCase.Execute(customerID)
+	customerEntity, err := h.FindByIdUseCase.Execute(r.Context(), customerID, r.URL.Query().Get("sort"))
+	if errors.Is(err, entity.ErrFavoriteSortInvalid) {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if err != nil {
 		utils.RespondWithValidationError(w, err)
 		return
@@ -110,6 +152,51 @@ func (h *CustomerHandler) GetCustomer(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// GetRecommendations godoc
+// @Summary Get product recommendations
+// @Description Recommend products favorited by customers who share favorites with this customer, then products of the customer's favorite categories. Favorited products are never recommended.
+// @Tags customers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Param limit query int false "Number of recommendations, 1 to 50" default(10)
+// @Success 200 {object} customerDto.RecommendationListResponse
+// @Failure 400 {object} customerDto.ErrorResponse
+// @Failure 401 {object} customerDto.ErrorResponse
+// @Failure 404 {object} customerDto.ErrorResponse
+// @Failure 500 {object} customerDto.ErrorResponse
+// @Failure 503 {object} customerDto.ErrorResponse
+// @Router /customers/{id}/recommendations [get]
+func (h *CustomerHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	customerID := chi.URLParam(r, "id")
+
+	limit := defaultRecommendationsLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "limit must be an integer")
+			return
+		}
+	}
+
+	recommendations, err := h.RecommendationsUseCase.Execute(r.Context(), customerID, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, recommendation.ErrInvalidLimit):
+			h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		case err.Error() == "customer not found":
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, repository.ErrProductServiceUnavailable):
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+		default:
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, customerDto.FromRecommendations(customerID, recommendations))
+}
+
 // UpdateCustomer godoc
 // @Summary Update customer
 // @Description Update customer information
@@ -147,7 +234,7 @@ func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.EditUseCase.Execute(customerEntity)
+	err = h.EditUseCase.Execute(r.Context(), customerEntity)
 	if err != nil {
 		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
 		return
@@ -177,7 +264,7 @@ func (h *CustomerHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.DeleteUseCase.Execute(customerID)
+	err := h.DeleteUseCase.Execute(r.Context(), customerID)
 	if err != nil {
 		if err.Error() == "customer not found" {
 			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
@@ -192,6 +279,159 @@ func (h *CustomerHandler) DeleteCustomer(w http.ResponseWriter, r *http.Request)
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// ImportCustomers godoc
+// @Summary Import customers
+// @Description Create customers in bulk from CSV (header with name and email columns) or NDJSON (one {"name","email"} object per line). Rows are validated one by one, duplicated emails are skipped and the report lists every row that was not created.
+// @Tags customers
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "csv or ndjson; defaults to the Content-Type"
+// @Success 200 {object} customerDto.ImportReportResponse
+// @Failure 400 {object} customerDto.ErrorResponse
+// @Failure 401 {object} customerDto.ErrorResponse
+// @Failure 413 {object} customerDto.ErrorResponse
+// @Failure 500 {object} customerDto.ImportReportResponse
+// @Router /customers/import [post]
+func (h *CustomerHandler) ImportCustomers(w http.ResponseWriter, r *http.Request) {
+	format, err := customerfile.FormatFromContentType(r.Header.Get("Content-Type"))
+	if raw := r.URL.Query().Get("format"); raw != "" {
+		format, err = customerfile.ParseFormat(raw)
+	}
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// A large file takes longer than the server timeouts allow to upload and save.
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Time{})
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	body := http.MaxBytesReader(w, r.Body, int64(h.ImportMaxBytes))
+	rows, err := customerfile.ReadRows(format, body)
+	if err != nil {
+		h.writeImportReadError(w, err)
+		return
+	}
+
+	collected, err := h.readImportRows(rows)
+	if err != nil {
+		h.writeImportReadError(w, err)
+		return
+	}
+
+	report, err := h.ImportUseCase.Execute(r.Context(), slices.Values(collected))
+	if err != nil {
+		logger.FromContext(r.Context()).Error("importing customers", slog.Int("total", report.Total), slog.Any("error", err))
+
+		response := customerDto.FromImportReport(report)
+		response.Error = "import interrupted"
+		h.writeJSONResponse(w, http.StatusInternalServerError, response)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, customerDto.FromImportReport(report))
+}
+
+// readImportRows reads the whole file before anything is saved, so a file over
+// the limits is rejected without importing part of it. The body is bounded by
+// ImportMaxBytes, which also bounds the rows kept in memory.
+func (h *CustomerHandler) readImportRows(rows iter.Seq[customer.ImportRow]) ([]customer.ImportRow, error) {
+	var collected []customer.ImportRow
+	for row := range rows {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(row.Err, &maxBytesErr) {
+			return nil, maxBytesErr
+		}
+
+		if len(collected) == h.ImportMaxRows {
+			return nil, errImportTooManyRows
+		}
+		collected = append(collected, row)
+	}
+
+	return collected, nil
+}
+
+func (h *CustomerHandler) writeImportReadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("import file must not be larger than %d bytes", maxBytesErr.Limit))
+	case errors.Is(err, errImportTooManyRows):
+		h.writeErrorResponse(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("import file must not have more than %d rows", h.ImportMaxRows))
+	default:
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// ExportCustomers godoc
+// @Summary Export customers
+// @Description Stream every customer with the ids of the products they favorited, as NDJSON or CSV (id, name, email, favorite_ids separated by semicolons)
+// @Tags customers
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Security BearerAuth
+// @Param format query string false "ndjson (default) or csv"
+// @Success 200 {string} string "customers"
+// @Failure 400 {object} customerDto.ErrorResponse
+// @Failure 401 {object} customerDto.ErrorResponse
+// @Router /customers/export [get]
+func (h *CustomerHandler) ExportCustomers(w http.ResponseWriter, r *http.Request) {
+	format := customerfile.FormatNDJSON
+	if raw := r.URL.Query().Get("format"); raw != "" {
+		var err error
+		if format, err = customerfile.ParseFormat(raw); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	writer, _ := customerfile.NewWriter(format, w)
+	flusher, _ := w.(http.Flusher)
+
+	// The export streams for as long as the table takes to read.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="customers.%s"`, format))
+
+	written := 0
+	err := h.ExportUseCase.Execute(r.Context(), func(export *entity.CustomerExport) error {
+		if err := writer.Write(export); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushEvery == 0 && flusher != nil {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	// The status line is gone once rows were streamed, so a later failure
+	// aborts the connection: the client gets a broken response, never a file
+	// that merely looks complete.
+	if err != nil {
+		logger.FromContext(r.Context()).Error("exporting customers", slog.Int("written", written), slog.Any("error", err))
+		if written == 0 {
+			w.Header().Del("Content-Disposition")
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		panic(http.ErrAbortHandler)
+	}
+}
+
 func (h *CustomerHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -2,26 +2,55 @@ package favorite
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 	favoriteDto "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/dto/favorite"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/utils"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/favorite"
 )
 
+const streamHeartbeatInterval = 15 * time.Second
+
 type FavoriteHandler struct {
-	CreateUseCase *favorite.CreateFavoriteUseCase
-	DeleteUseCase *favorite.DeleteFavoriteUseCase
+	CreateUseCase       *favorite.CreateFavoriteUseCase
+	DeleteUseCase       *favorite.DeleteFavoriteUseCase
+	StreamUseCase       *favorite.StreamFavoriteUseCase
+	FindOrphansUseCase  *favorite.FindOrphanFavoritesUseCase
+	PruneOrphansUseCase *favorite.PruneOrphanFavoritesUseCase
+	UpdateUseCase       *favorite.UpdateFavoriteUseCase
+	FindQuotaUseCase    *favorite.FindQuotaFavoriteUseCase
+	SetQuotaUseCase     *favorite.SetQuotaFavoriteUseCase
+	validator           *validator.Validate
 }
 
 func NewFavoriteHandler(
 	createUseCase *favorite.CreateFavoriteUseCase,
 	deleteUseCase *favorite.DeleteFavoriteUseCase,
+	streamUseCase *favorite.StreamFavoriteUseCase,
+	findOrphansUseCase *favorite.FindOrphanFavoritesUseCase,
+	pruneOrphansUseCase *favorite.PruneOrphanFavoritesUseCase,
+	updateUseCase *favorite.UpdateFavoriteUseCase,
+	findQuotaUseCase *favorite.FindQuotaFavoriteUseCase,
+	setQuotaUseCase *favorite.SetQuotaFavoriteUseCase,
 ) *FavoriteHandler {
 	return &FavoriteHandler{
-		CreateUseCase: createUseCase,
-		DeleteUseCase: deleteUseCase,
+		CreateUseCase:       createUseCase,
+		DeleteUseCase:       deleteUseCase,
+		StreamUseCase:       streamUseCase,
+		FindOrphansUseCase:  findOrphansUseCase,
+		PruneOrphansUseCase: pruneOrphansUseCase,
+		UpdateUseCase:       updateUseCase,
+		FindQuotaUseCase:    findQuotaUseCase,
+		SetQuotaUseCase:     setQuotaUseCase,
+		validator:           validator.New(),
 	}
 }
 
@@ -38,6 +67,8 @@ func NewFavoriteHandler(
 // @Failure 400 {object} favorite.ErrorResponse
 // @Failure 401 {object} favorite.ErrorResponse
 // @Failure 404 {object} favorite.ErrorResponse
+// @Failure 422 {object} favorite.ErrorResponse
+// @Failure 503 {object} favorite.ErrorResponse
 // @Router /customers/{customer_id}/favorites/{product_id} [post]
 func (h *FavoriteHandler) CreateFavorite(w http.ResponseWriter, r *http.Request) {
 	customerID := chi.URLParam(r, "customer_id")
@@ -58,12 +89,16 @@ func (h *FavoriteHandler) CreateFavorite(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.CreateUseCase.Execute(customerID, productID)
+	err = h.CreateUseCase.Execute(r.Context(), customerID, productID)
 	if err != nil {
-		if err.Error() == "customer not found" || err.Error() == "product not found" {
+		if err.Error() == "customer not found" || errors.Is(err, repository.ErrProductNotFound) {
 			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if errors.Is(err, repository.ErrProductServiceUnavailable) {
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
 		} else if err.Error() == "product already in favorites" {
 			h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else if errors.Is(err, repository.ErrFavoritesLimitReached) {
+			h.writeErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
 		} else {
 			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
 		}
@@ -78,6 +113,128 @@ func (h *FavoriteHandler) CreateFavorite(w http.ResponseWriter, r *http.Request)
 	h.writeJSONResponse(w, http.StatusCreated, response)
 }
 
+// UpdateFavorite godoc
+// @Summary Update favorite note, quantity and priority
+// @Description Update the shopping-list fields of a favorite in the customer's default collection. Only the fields sent are changed.
+// @Tags favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param product_id path int true "Product ID"
+// @Param request body favoriteDto.UpdateFavoriteRequest true "Fields to update"
+// @Success 200 {object} favoriteDto.UpdateFavoriteResponse
+// @Failure 400 {object} favoriteDto.ErrorResponse
+// @Failure 401 {object} favoriteDto.ErrorResponse
+// @Failure 404 {object} favoriteDto.ErrorResponse
+// @Router /customers/{customer_id}/favorites/{product_id} [patch]
+func (h *FavoriteHandler) UpdateFavorite(w http.ResponseWriter, r *http.Request) {
+	customerID := chi.URLParam(r, "customer_id")
+
+	productID, err := strconv.ParseInt(chi.URLParam(r, "product_id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	var req favoriteDto.UpdateFavoriteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	updated, err := h.UpdateUseCase.Execute(r.Context(), customerID, productID, favorite.FavoriteChanges{
+		Note:     req.Note,
+		Quantity: req.Quantity,
+		Priority: req.Priority,
+	})
+	if err != nil {
+		switch {
+		case err.Error() == "customer not found", errors.Is(err, favorite.ErrFavoriteNotFound):
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, entity.ErrFavoriteNoteTooLong), errors.Is(err, entity.ErrFavoriteQuantityInvalid), errors.Is(err, entity.ErrFavoritePriorityInvalid):
+			h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		default:
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, favoriteDto.FromUpdatedFavorite(customerID, updated))
+}
+
+// GetQuota godoc
+// @Summary Get favorites quota
+// @Description Get how many products the customer can favorite and how many are used
+// @Tags favorites
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Success 200 {object} favoriteDto.QuotaResponse
+// @Failure 401 {object} favoriteDto.ErrorResponse
+// @Failure 404 {object} favoriteDto.ErrorResponse
+// @Router /customers/{customer_id}/favorites/quota [get]
+func (h *FavoriteHandler) GetQuota(w http.ResponseWriter, r *http.Request) {
+	quota, err := h.FindQuotaUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"))
+	if err != nil {
+		h.writeQuotaError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, favoriteDto.FromQuota(quota))
+}
+
+// SetQuota godoc
+// @Summary Set favorites quota
+// @Description Override the customer's favorites limit, e.g. for premium accounts. A null quota restores FAVORITES_MAX_PER_CUSTOMER.
+// @Tags favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param request body favoriteDto.SetQuotaRequest true "New quota"
+// @Success 200 {object} favoriteDto.QuotaResponse
+// @Failure 400 {object} favoriteDto.ErrorResponse
+// @Failure 401 {object} favoriteDto.ErrorResponse
+// @Failure 404 {object} favoriteDto.ErrorResponse
+// @Router /customers/{customer_id}/favorites/quota [put]
+func (h *FavoriteHandler) SetQuota(w http.ResponseWriter, r *http.Request) {
+	var req favoriteDto.SetQuotaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	quota, err := h.SetQuotaUseCase.Execute(r.Context(), chi.URLParam(r, "customer_id"), req.Quota)
+	if err != nil {
+		h.writeQuotaError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, favoriteDto.FromQuota(quota))
+}
+
+func (h *FavoriteHandler) writeQuotaError(w http.ResponseWriter, err error) {
+	switch {
+	case err.Error() == "customer not found":
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, entity.ErrCustomerFavoritesQuotaInvalid):
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
 // DeleteFavorite godoc
 // @Summary Remove product from favorites
 // @Description Remove a product from customer's favorites list
@@ -91,6 +248,7 @@ func (h *FavoriteHandler) CreateFavorite(w http.ResponseWriter, r *http.Request)
 // @Failure 400 {object} favorite.ErrorResponse
 // @Failure 401 {object} favorite.ErrorResponse
 // @Failure 404 {object} favorite.ErrorResponse
+// @Failure 503 {object} favorite.ErrorResponse
 // @Router /customers/{customer_id}/favorites/{product_id} [delete]
 func (h *FavoriteHandler) DeleteFavorite(w http.ResponseWriter, r *http.Request) {
 	customerID := chi.URLParam(r, "customer_id")
@@ -111,10 +269,12 @@ func (h *FavoriteHandler) DeleteFavorite(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.DeleteUseCase.Execute(customerID, productID)
+	err = h.DeleteUseCase.Execute(r.Context(), customerID, productID)
 	if err != nil {
-		if err.Error() == "customer not found" || err.Error() == "product not found" {
+		if err.Error() == "customer not found" || errors.Is(err, repository.ErrProductNotFound) {
 			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if errors.Is(err, repository.ErrProductServiceUnavailable) {
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
 		} else {
 			h.writeErrorResponse(w, http.StatusInternalServerError, err.Error())
 		}
@@ -129,6 +289,137 @@ func (h *FavoriteHandler) DeleteFavorite(w http.ResponseWriter, r *http.Request)
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// StreamFavorites godoc
+// @Summary Stream favorite changes
+// @Description Server-Sent Events stream of the products added to or removed from the customer's favorites. Send the Last-Event-ID header to resume after a disconnect.
+// @Tags favorites
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param customer_id path string true "Customer ID"
+// @Param Last-Event-ID header string false "Id of the last event received"
+// @Success 200 {string} string "event stream"
+// @Failure 401 {object} favorite.ErrorResponse
+// @Failure 404 {object} favorite.ErrorResponse
+// @Router /customers/{customer_id}/favorites/stream [get]
+func (h *FavoriteHandler) StreamFavorites(w http.ResponseWriter, r *http.Request) {
+	customerID := chi.URLParam(r, "customer_id")
+	if customerID == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "customer id is required")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	events, unsubscribe, err := h.StreamUseCase.Execute(r.Context(), customerID, r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		if err.Error() == "customer not found" {
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+	defer unsubscribe()
+
+	// The stream outlives the server write timeout by design.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.Id, e.Type, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// GetOrphanFavorites godoc
+// @Summary Report orphan favorites
+// @Description List the favorited products that no longer exist in the catalog, with how many favorites point to each
+// @Tags favorites
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} favorite.OrphanFavoritesResponse
+// @Failure 401 {object} favorite.ErrorResponse
+// @Failure 500 {object} favorite.ErrorResponse
+// @Failure 503 {object} favorite.ErrorResponse
+// @Router /favorites/orphans [get]
+func (h *FavoriteHandler) GetOrphanFavorites(w http.ResponseWriter, r *http.Request) {
+	orphans, err := h.FindOrphansUseCase.Execute(r.Context())
+	if err != nil {
+		h.writeOrphanError(w, err)
+		return
+	}
+
+	response := favoriteDto.OrphanFavoritesResponse{
+		Products: favoriteDto.FromOrphans(orphans),
+		Total:    len(orphans),
+	}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// PruneOrphanFavorites godoc
+// @Summary Prune orphan favorites
+// @Description Delete every favorite of a product that no longer exists in the catalog
+// @Tags favorites
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} favorite.PruneOrphanFavoritesResponse
+// @Failure 401 {object} favorite.ErrorResponse
+// @Failure 500 {object} favorite.ErrorResponse
+// @Failure 503 {object} favorite.ErrorResponse
+// @Router /favorites/orphans [delete]
+func (h *FavoriteHandler) PruneOrphanFavorites(w http.ResponseWriter, r *http.Request) {
+	orphans, removed, err := h.PruneOrphansUseCase.Execute(r.Context())
+	if err != nil {
+		h.writeOrphanError(w, err)
+		return
+	}
+
+	response := favoriteDto.PruneOrphanFavoritesResponse{
+		Products: favoriteDto.FromOrphans(orphans),
+		Removed:  removed,
+	}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *FavoriteHandler) writeOrphanError(w http.ResponseWriter, err error) {
+	if errors.Is(err, repository.ErrProductServiceUnavailable) {
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+	} else {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
 func (h *FavoriteHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -0,0 +1,55 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	healthDto "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/dto/health"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/health"
+)
+
+type HealthHandler struct {
+	ReadinessUseCase *health.ReadinessUseCase
+}
+
+func NewHealthHandler(readinessUseCase *health.ReadinessUseCase) *HealthHandler {
+	return &HealthHandler{
+		ReadinessUseCase: readinessUseCase,
+	}
+}
+
+// Liveness godoc
+// @Summary Liveness probe
+// @Description Reports that the process is up and serving requests
+// @Tags health
+// @Produce json
+// @Success 200 {object} healthDto.StatusResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	h.writeJSONResponse(w, http.StatusOK, healthDto.StatusResponse{Status: "ok"})
+}
+
+// Readiness godoc
+// @Summary Readiness probe
+// @Description Checks the database and the fakestoreapi before accepting traffic
+// @Tags health
+// @Produce json
+// @Success 200 {object} healthDto.ReadinessResponse
+// @Failure 503 {object} healthDto.ReadinessResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	results, ready := h.ReadinessUseCase.Execute(r.Context())
+
+	statusCode := http.StatusOK
+	if !ready {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	h.writeJSONResponse(w, statusCode, healthDto.FromResults(results, ready))
+}
+
+func (h *HealthHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
@@ -2,51 +2,151 @@ package product
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
 	productDto "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/dto/product"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/utils"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/price"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/product"
 )
 
+const (
+	includeFavoriteCount = "favorite_count"
+
+	defaultMostFavoritedDays  = 30
+	defaultMostFavoritedLimit = 10
+)
+
 type ProductHandler struct {
-	FindAllUseCase  *product.FindAllProductUseCase
-	FindByIdUseCase *product.FindByIdProductUseCase
+	FindAllUseCase        *product.FindAllProductUseCase
+	FindByIdUseCase       *product.FindByIdProductUseCase
+	FindCategoriesUseCase *product.FindCategoriesProductUseCase
+	CreateUseCase         *product.CreateProductUseCase
+	EditUseCase           *product.EditProductUseCase
+	DeleteUseCase         *product.DeleteProductUseCase
+	PriceHistoryUseCase   *price.FindPriceHistoryUseCase
+	CountFavoritesUseCase *product.CountFavoritesProductUseCase
+	MostFavoritedUseCase  *product.MostFavoritedProductUseCase
+	validator             *validator.Validate
 }
 
 func NewProductHandler(
 	findAllUseCase *product.FindAllProductUseCase,
 	findByIdUseCase *product.FindByIdProductUseCase,
+	findCategoriesUseCase *product.FindCategoriesProductUseCase,
+	createUseCase *product.CreateProductUseCase,
+	editUseCase *product.EditProductUseCase,
+	deleteUseCase *product.DeleteProductUseCase,
+	priceHistoryUseCase *price.FindPriceHistoryUseCase,
+	countFavoritesUseCase *product.CountFavoritesProductUseCase,
+	mostFavoritedUseCase *product.MostFavoritedProductUseCase,
 ) *ProductHandler {
 	return &ProductHandler{
-		FindAllUseCase:  findAllUseCase,
-		FindByIdUseCase: findByIdUseCase,
+		FindAllUseCase:        findAllUseCase,
+		FindByIdUseCase:       findByIdUseCase,
+		FindCategoriesUseCase: findCategoriesUseCase,
+		CreateUseCase:         createUseCase,
+		EditUseCase:           editUseCase,
+		DeleteUseCase:         deleteUseCase,
+		PriceHistoryUseCase:   priceHistoryUseCase,
+		CountFavoritesUseCase: countFavoritesUseCase,
+		MostFavoritedUseCase:  mostFavoritedUseCase,
+		validator:             validator.New(),
 	}
 }
 
 // GetProducts godoc
 // @Summary List all products
-// @Description Get all available products
+// @Description Search, filter, sort and paginate the product catalog
 // @Tags products
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param category query string false "Category name, e.g. electronics"
+// @Param q query string false "Case-insensitive text search on the title"
+// @Param min_price query number false "Minimum price"
+// @Param max_price query number false "Maximum price"
+// @Param min_rating query number false "Minimum rating"
+// @Param max_rating query number false "Maximum rating"
+// @Param sort query string false "price, rating or rate_count; prefix with - for descending" Enums(price, -price, rating, -rating, rate_count, -rate_count)
+// @Param limit query int false "Page size, 1 to 100; 20 when omitted"
+// @Param offset query int false "Number of products to skip"
+// @Param include query string false "Embed extra data in each product" Enums(favorite_count)
 // @Success 200 {object} product.ProductListResponse
+// @Failure 400 {object} product.ErrorResponse
 // @Failure 401 {object} product.ErrorResponse
 // @Failure 500 {object} product.ErrorResponse
+// @Failure 503 {object} product.ErrorResponse
 // @Router /products [get]
 func (h *ProductHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
-	products, err := h.FindAllUseCase.Execute()
+	query, err := parseProductQuery(r.URL.Query())
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	response := productDto.FromEntities(products)
+	page, err := h.FindAllUseCase.Execute(r.Context(), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, product.ErrInvalidSort),
+			errors.Is(err, product.ErrInvalidPriceRange),
+			errors.Is(err, product.ErrInvalidRatingRange),
+			errors.Is(err, product.ErrInvalidLimit),
+			errors.Is(err, product.ErrInvalidOffset):
+			h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, repository.ErrProductServiceUnavailable):
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+		default:
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	response := productDto.FromEntities(page.Products)
+	response.Total = page.Total
+	response.Limit = page.Limit
+	response.Offset = page.Offset
+	if page.FavoriteCounts != nil {
+		response.WithFavoriteCounts(page.FavoriteCounts)
+	}
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// GetCategories godoc
+// @Summary List product categories
+// @Description Get the distinct categories of the product catalog
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} product.CategoryListResponse
+// @Failure 401 {object} product.ErrorResponse
+// @Failure 500 {object} product.ErrorResponse
+// @Failure 503 {object} product.ErrorResponse
+// @Router /products/categories [get]
+func (h *ProductHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.FindCategoriesUseCase.Execute(r.Context())
+	if err != nil {
+		if errors.Is(err, repository.ErrProductServiceUnavailable) {
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, productDto.CategoryListResponse{Categories: categories})
+}
+
 // GetProduct godoc
 // @Summary Get product by ID
 // @Description Get a specific product by ID
@@ -55,10 +155,13 @@ func (h *ProductHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Product ID"
+// @Param include query string false "Embed extra data in the product" Enums(favorite_count)
 // @Success 200 {object} product.ProductResponse
 // @Failure 400 {object} product.ErrorResponse
 // @Failure 401 {object} product.ErrorResponse
 // @Failure 404 {object} product.ErrorResponse
+// @Failure 500 {object} product.ErrorResponse
+// @Failure 503 {object} product.ErrorResponse
 // @Router /products/{id} [get]
 func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 	productIDStr := chi.URLParam(r, "id")
@@ -73,9 +176,39 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	productEntity, err := h.FindByIdUseCase.Execute(productID)
-	if err != nil || productEntity == nil {
-		h.writeErrorResponse(w, http.StatusNotFound, "product not found")
+	includeFavoriteCount, err := parseInclude(r.URL.Query())
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if includeFavoriteCount {
+		productEntity, count, err := h.CountFavoritesUseCase.Execute(r.Context(), productID)
+		if err != nil {
+			h.writeReadError(w, err)
+			return
+		}
+
+		response := productDto.FromEntity(productEntity)
+		response.FavoriteCount = &count
+		h.writeJSONResponse(w, http.StatusOK, response)
+		return
+	}
+
+	productEntity, err := h.FindByIdUseCase.Execute(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			h.writeErrorResponse(w, http.StatusNotFound, repository.ErrProductNotFound.Error())
+		} else if errors.Is(err, repository.ErrProductServiceUnavailable) {
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	if productEntity == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, repository.ErrProductNotFound.Error())
 		return
 	}
 
@@ -83,6 +216,335 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 	h.writeJSONResponse(w, http.StatusOK, response)
 }
 
+// GetPriceHistory godoc
+// @Summary Get product price history
+// @Description Get every price recorded for a product, oldest first. Prices are recorded by the price tracker (PRICE_TRACK_INTERVAL).
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} product.PriceHistoryResponse
+// @Failure 400 {object} product.ErrorResponse
+// @Failure 401 {object} product.ErrorResponse
+// @Failure 404 {object} product.ErrorResponse
+// @Failure 500 {object} product.ErrorResponse
+// @Failure 503 {object} product.ErrorResponse
+// @Router /products/{id}/price-history [get]
+func (h *ProductHandler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	history, err := h.PriceHistoryUseCase.Execute(r.Context(), productID)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			h.writeErrorResponse(w, http.StatusNotFound, repository.ErrProductNotFound.Error())
+		} else if errors.Is(err, repository.ErrProductServiceUnavailable) {
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+		} else {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, productDto.FromPricePoints(productID, history))
+}
+
+// GetFavoriteCount godoc
+// @Summary Count product favorites
+// @Description Get how many customers have the product in their favorites
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} product.FavoriteCountResponse
+// @Failure 400 {object} product.ErrorResponse
+// @Failure 401 {object} product.ErrorResponse
+// @Failure 404 {object} product.ErrorResponse
+// @Failure 500 {object} product.ErrorResponse
+// @Failure 503 {object} product.ErrorResponse
+// @Router /products/{id}/favorites/count [get]
+func (h *ProductHandler) GetFavoriteCount(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	_, count, err := h.CountFavoritesUseCase.Execute(r.Context(), productID)
+	if err != nil {
+		h.writeReadError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, productDto.FavoriteCountResponse{ProductID: productID, FavoriteCount: count})
+}
+
+// GetMostFavorited godoc
+// @Summary List the most favorited products
+// @Description Rank the catalog by how many customers favorited each product in the last days. Products that left the catalog are skipped.
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Window in days, 1 to 365" default(30)
+// @Param limit query int false "Number of products, 1 to 100" default(10)
+// @Success 200 {object} product.MostFavoritedResponse
+// @Failure 400 {object} product.ErrorResponse
+// @Failure 401 {object} product.ErrorResponse
+// @Failure 500 {object} product.ErrorResponse
+// @Failure 503 {object} product.ErrorResponse
+// @Router /products/most-favorited [get]
+func (h *ProductHandler) GetMostFavorited(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+
+	days, err := parseIntParam(values, "days")
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !values.Has("days") {
+		days = defaultMostFavoritedDays
+	}
+
+	limit, err := parseIntParam(values, "limit")
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !values.Has("limit") {
+		limit = defaultMostFavoritedLimit
+	}
+
+	ranking, err := h.MostFavoritedUseCase.Execute(r.Context(), time.Duration(days)*24*time.Hour, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, product.ErrInvalidWindow), errors.Is(err, product.ErrInvalidLimit):
+			h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, repository.ErrProductServiceUnavailable):
+			h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+		default:
+			h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, productDto.FromMostFavorited(ranking))
+}
+
+// CreateProduct godoc
+// @Summary Create a product
+// @Description Add a product to the local catalog. Only available when PRODUCT_PROVIDER is postgres.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body product.CreateProductRequest true "Product data"
+// @Success 201 {object} product.ProductResponse
+// @Failure 400 {object} product.ErrorResponse
+// @Failure 401 {object} product.ErrorResponse
+// @Failure 409 {object} product.ErrorResponse
+// @Router /products [post]
+func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	var req productDto.CreateProductRequest
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	productEntity, err := req.ToEntity()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdProduct, err := h.CreateUseCase.Execute(r.Context(), productEntity)
+	if err != nil {
+		h.writeWriteError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusCreated, productDto.FromEntity(createdProduct))
+}
+
+// UpdateProduct godoc
+// @Summary Update a product
+// @Description Update a product of the local catalog. Only available when PRODUCT_PROVIDER is postgres.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body product.UpdateProductRequest true "Product data"
+// @Success 200 {object} product.ProductResponse
+// @Failure 400 {object} product.ErrorResponse
+// @Failure 401 {object} product.ErrorResponse
+// @Failure 404 {object} product.ErrorResponse
+// @Failure 409 {object} product.ErrorResponse
+// @Router /products/{id} [put]
+func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	var req productDto.UpdateProductRequest
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	if err := h.validator.Struct(&req); err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	productEntity, err := req.ToEntityWithId(productID)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.EditUseCase.Execute(r.Context(), productEntity); err != nil {
+		h.writeWriteError(w, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, productDto.FromEntity(productEntity))
+}
+
+// DeleteProduct godoc
+// @Summary Delete a product
+// @Description Remove a product from the local catalog. Only available when PRODUCT_PROVIDER is postgres.
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} product.SuccessResponse
+// @Failure 400 {object} product.ErrorResponse
+// @Failure 401 {object} product.ErrorResponse
+// @Failure 404 {object} product.ErrorResponse
+// @Failure 409 {object} product.ErrorResponse
+// @Router /products/{id} [delete]
+func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	productID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "product id must be a number")
+		return
+	}
+
+	if err := h.DeleteUseCase.Execute(r.Context(), productID); err != nil {
+		h.writeWriteError(w, err)
+		return
+	}
+
+	response := productDto.SuccessResponse{Message: "product deleted successfully"}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+func parseProductQuery(values url.Values) (product.ProductQuery, error) {
+	query := product.ProductQuery{
+		Category: strings.TrimSpace(values.Get("category")),
+		Search:   strings.TrimSpace(values.Get("q")),
+		Sort:     strings.TrimSpace(values.Get("sort")),
+	}
+
+	var err error
+	if query.MinPrice, err = parseFloatParam(values, "min_price"); err != nil {
+		return query, err
+	}
+	if query.MaxPrice, err = parseFloatParam(values, "max_price"); err != nil {
+		return query, err
+	}
+	if query.MinRating, err = parseFloatParam(values, "min_rating"); err != nil {
+		return query, err
+	}
+	if query.MaxRating, err = parseFloatParam(values, "max_rating"); err != nil {
+		return query, err
+	}
+	if query.Limit, err = parseIntParam(values, "limit"); err != nil {
+		return query, err
+	}
+	if query.Offset, err = parseIntParam(values, "offset"); err != nil {
+		return query, err
+	}
+	if query.IncludeFavoriteCount, err = parseInclude(values); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseInclude reports whether favorite_count was asked for in the
+// comma separated include parameter.
+func parseInclude(values url.Values) (bool, error) {
+	raw := strings.TrimSpace(values.Get("include"))
+	if raw == "" {
+		return false, nil
+	}
+
+	for _, field := range strings.Split(raw, ",") {
+		if strings.TrimSpace(field) != includeFavoriteCount {
+			return false, fmt.Errorf("include must be %s", includeFavoriteCount)
+		}
+	}
+
+	return true, nil
+}
+
+func parseFloatParam(values url.Values, key string) (*float64, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%s must be a number", key)
+	}
+
+	return &value, nil
+}
+
+func parseIntParam(values url.Values, key string) (int, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer", key)
+	}
+
+	return value, nil
+}
+
+func (h *ProductHandler) writeReadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound):
+		h.writeErrorResponse(w, http.StatusNotFound, repository.ErrProductNotFound.Error())
+	case errors.Is(err, repository.ErrProductServiceUnavailable):
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, repository.ErrProductServiceUnavailable.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func (h *ProductHandler) writeWriteError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound):
+		h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, repository.ErrProductCatalogReadOnly):
+		h.writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
 func (h *ProductHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -0,0 +1,279 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	webhookDto "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/dto/webhook"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/utils"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/webhook"
+)
+
+type WebhookHandler struct {
+	CreateUseCase         *webhook.CreateWebhookUseCase
+	FindAllUseCase        *webhook.FindAllWebhookUseCase
+	FindByIdUseCase       *webhook.FindByIdWebhookUseCase
+	EditUseCase           *webhook.EditWebhookUseCase
+	DeleteUseCase         *webhook.DeleteWebhookUseCase
+	FindDeliveriesUseCase *webhook.FindDeliveriesWebhookUseCase
+	RedeliverUseCase      *webhook.RedeliverWebhookUseCase
+	validator             *validator.Validate
+}
+
+func NewWebhookHandler(
+	createUseCase *webhook.CreateWebhookUseCase,
+	findAllUseCase *webhook.FindAllWebhookUseCase,
+	findByIdUseCase *webhook.FindByIdWebhookUseCase,
+	editUseCase *webhook.EditWebhookUseCase,
+	deleteUseCase *webhook.DeleteWebhookUseCase,
+	findDeliveriesUseCase *webhook.FindDeliveriesWebhookUseCase,
+	redeliverUseCase *webhook.RedeliverWebhookUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		CreateUseCase:         createUseCase,
+		FindAllUseCase:        findAllUseCase,
+		FindByIdUseCase:       findByIdUseCase,
+		EditUseCase:           editUseCase,
+		DeleteUseCase:         deleteUseCase,
+		FindDeliveriesUseCase: findDeliveriesUseCase,
+		RedeliverUseCase:      redeliverUseCase,
+		validator:             validator.New(),
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Description Subscribe a URL to favorite events. The secret used to sign deliveries is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body webhookDto.CreateWebhookRequest true "Webhook data"
+// @Success 201 {object} webhookDto.WebhookResponse
+// @Failure 400 {object} webhookDto.ErrorResponse
+// @Failure 401 {object} webhookDto.ErrorResponse
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhookDto.CreateWebhookRequest
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	err := h.validator.Struct(&req)
+	if err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	webhookEntity, err := req.ToEntity()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	createdWebhook, err := h.CreateUseCase.Execute(r.Context(), webhookEntity)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	response := webhookDto.FromEntityWithSecret(createdWebhook)
+	h.writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetWebhooks godoc
+// @Summary List webhook subscriptions
+// @Description Get all webhook subscriptions
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} webhookDto.WebhookListResponse
+// @Failure 401 {object} webhookDto.ErrorResponse
+// @Failure 500 {object} webhookDto.ErrorResponse
+// @Router /webhooks [get]
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.FindAllUseCase.Execute(r.Context())
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	response := webhookDto.FromEntities(webhooks)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetWebhook godoc
+// @Summary Get webhook subscription by ID
+// @Description Get a webhook subscription by its ID
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} webhookDto.WebhookResponse
+// @Failure 401 {object} webhookDto.ErrorResponse
+// @Failure 404 {object} webhookDto.ErrorResponse
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookEntity, err := h.FindByIdUseCase.Execute(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if webhookEntity == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	response := webhookDto.FromEntity(webhookEntity)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateWebhook godoc
+// @Summary Update webhook subscription
+// @Description Update the URL, events and active flag of a webhook subscription
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param request body webhookDto.UpdateWebhookRequest true "Updated webhook data"
+// @Success 200 {object} webhookDto.WebhookResponse
+// @Failure 400 {object} webhookDto.ErrorResponse
+// @Failure 401 {object} webhookDto.ErrorResponse
+// @Failure 404 {object} webhookDto.ErrorResponse
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req webhookDto.UpdateWebhookRequest
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	err := h.validator.Struct(&req)
+	if err != nil {
+		utils.RespondWithValidationError(w, err)
+		return
+	}
+
+	webhookEntity, err := h.EditUseCase.Execute(r.Context(), chi.URLParam(r, "id"), req.Url, req.Events, *req.Active)
+	if err != nil {
+		if err.Error() == "webhook not found" {
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		h.writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := webhookDto.FromEntity(webhookEntity)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete webhook subscription
+// @Description Delete a webhook subscription and its delivery log
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} webhookDto.SuccessResponse
+// @Failure 401 {object} webhookDto.ErrorResponse
+// @Failure 404 {object} webhookDto.ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	err := h.DeleteUseCase.Execute(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if err.Error() == "webhook not found" {
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	response := webhookDto.SuccessResponse{Message: "webhook deleted successfully"}
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Get the delivery log of a webhook subscription, most recent first
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} webhookDto.DeliveryListResponse
+// @Failure 401 {object} webhookDto.ErrorResponse
+// @Failure 404 {object} webhookDto.ErrorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.FindDeliveriesUseCase.Execute(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if err.Error() == "webhook not found" {
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	response := webhookDto.FromDeliveryEntities(deliveries)
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
+
+// RedeliverDelivery godoc
+// @Summary Redeliver a webhook delivery
+// @Description Send a finished delivery again, with the same payload and a fresh signature. A delivery still pending is already being retried.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Success 202 {object} webhookDto.DeliveryResponse
+// @Failure 401 {object} webhookDto.ErrorResponse
+// @Failure 404 {object} webhookDto.ErrorResponse
+// @Failure 409 {object} webhookDto.ErrorResponse
+// @Router /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *WebhookHandler) RedeliverDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.RedeliverUseCase.Execute(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "delivery_id"))
+	if err != nil {
+		if err.Error() == "webhook not found" || err.Error() == "delivery not found" {
+			h.writeErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		if errors.Is(err, entity.ErrWebhookDeliveryAlreadyPending) {
+			h.writeErrorResponse(w, http.StatusConflict, err.Error())
+			return
+		}
+
+		h.writeErrorResponse(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	response := webhookDto.FromDeliveryEntity(delivery)
+	h.writeJSONResponse(w, http.StatusAccepted, response)
+}
+
+func (h *WebhookHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+func (h *WebhookHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, error string) {
+	response := webhookDto.ErrorResponse{
+		Error: error,
+	}
+	h.writeJSONResponse(w, statusCode, response)
+}
@@ -2,11 +2,13 @@ package middleware
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/utils"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/auth"
 )
 
@@ -44,6 +46,8 @@ func JWTAuth(jwtSecret string) func(http.Handler) http.Handler {
 
 			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
 			ctx = context.WithValue(ctx, EmailKey, claims.Email)
+			ctx = logger.With(ctx, slog.String("user_id", claims.UserID))
+			setLogUserID(ctx, claims.UserID)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+const logStateKey contextKey = "log_state"
+
+// logState collects values set deeper in the chain, such as the user id from
+// JWTAuth, so they reach the access log written on the way out.
+type logState struct {
+	userID string
+}
+
+// Logger writes one structured access log entry per request and stores a
+// request scoped logger, carrying the request id, in the context.
+func Logger() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			state := &logState{}
+
+			ctx := logger.With(r.Context(), slog.String("request_id", middleware.GetReqID(r.Context())))
+			ctx = context.WithValue(ctx, logStateKey, state)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", chi.RouteContext(r.Context()).RoutePattern()),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("remote_addr", r.RemoteAddr),
+			}
+			if state.userID != "" {
+				attrs = append(attrs, slog.String("user_id", state.userID))
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+
+			logger.FromContext(ctx).LogAttrs(ctx, level, "http request", attrs...)
+		})
+	}
+}
+
+// Recovery turns a panic into a 500 response and logs it with the stack trace.
+func Recovery() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					if rec == http.ErrAbortHandler {
+						panic(rec)
+					}
+
+					logger.FromContext(r.Context()).Error("panic recovered",
+						slog.Any("panic", rec),
+						slog.String("stack", string(debug.Stack())),
+					)
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func setLogUserID(ctx context.Context, userID string) {
+	if state, ok := ctx.Value(logStateKey).(*logState); ok {
+		state.userID = userID
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_WritesAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer slog.SetDefault(previous)
+
+	r := chi.NewRouter()
+	r.Use(RequestID())
+	r.Use(Logger())
+	r.Use(Recovery())
+	r.Get("/api/customers/{id}", func(w http.ResponseWriter, r *http.Request) {
+		setLogUserID(r.Context(), "user-1")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/customers/42", nil))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "/api/customers/{id}", entry["route"])
+	assert.Equal(t, float64(http.StatusNotFound), entry["status"])
+	assert.Equal(t, "user-1", entry["user_id"])
+	assert.NotEmpty(t, entry["request_id"])
+}
+
+func TestRecovery_RespondsWithInternalServerError(t *testing.T) {
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil)))
+	defer slog.SetDefault(previous)
+
+	handler := Recovery()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -1,29 +1,21 @@
 package middleware
 
 import (
-	"log"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 )
 
-func CORS() func(http.Handler) http.Handler {
+func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 	return cors.Handler(cors.Options{
-		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
 		AllowedHeaders: []string{"*"},
 	})
 }
 
-func Logger() func(http.Handler) http.Handler {
-	return middleware.Logger
-}
-
-func Recovery() func(http.Handler) http.Handler {
-	return middleware.Recoverer
-}
-
 func ContentType() func(http.Handler) http.Handler {
 	return middleware.SetHeader("Content-Type", "application/json")
 }
@@ -32,18 +24,8 @@ func RequestID() func(http.Handler) http.Handler {
 	return middleware.RequestID
 }
 
+// Timeout bounds a request to 60 seconds. The router leaves it off the
+// routes meant to stay open, such as the Server-Sent Events stream.
 func Timeout() func(http.Handler) http.Handler {
-	return middleware.Timeout(60)
-}
-
-func LogInfo(message string) {
-	log.Printf("[INFO] %s", message)
-}
-
-func LogError(message string, err error) {
-	if err != nil {
-		log.Printf("[ERROR] %s: %v", message, err)
-	} else {
-		log.Printf("[ERROR] %s", message)
-	}
+	return middleware.Timeout(60 * time.Second)
 }
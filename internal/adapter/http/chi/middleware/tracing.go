@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var untracedPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Tracing starts a server span per request, continuing the trace from an
+// incoming traceparent header. The span is renamed after the chi route
+// pattern once routing is done and carries the request id.
+func Tracing() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			span := trace.SpanFromContext(r.Context())
+			if requestID := middleware.GetReqID(r.Context()); requestID != "" {
+				span.SetAttributes(attribute.String("http.request.id", requestID))
+			}
+
+			next.ServeHTTP(w, r)
+
+			if route := chi.RouteContext(r.Context()).RoutePattern(); route != "" {
+				span.SetName(r.Method + " " + route)
+				span.SetAttributes(attribute.String("http.route", route))
+			}
+		})
+
+		return otelhttp.NewHandler(handler, "http.request",
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method
+			}),
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return !untracedPaths[r.URL.Path]
+			}),
+		)
+	}
+}
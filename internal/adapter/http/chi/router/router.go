@@ -6,80 +6,159 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	authHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/auth"
+	collectionHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/collection"
 	customerHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/customer"
 	favoriteHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/favorite"
+	healthHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/health"
 	productHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/product"
+	webhookHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/webhook"
 	appMiddleware "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 type Router struct {
-	CustomerHandler *customerHandler.CustomerHandler
-	ProductHandler  *productHandler.ProductHandler
-	FavoriteHandler *favoriteHandler.FavoriteHandler
-	AuthHandler     *authHandler.AuthHandler
-	JWTSecret       string
+	CustomerHandler   *customerHandler.CustomerHandler
+	ProductHandler    *productHandler.ProductHandler
+	FavoriteHandler   *favoriteHandler.FavoriteHandler
+	CollectionHandler *collectionHandler.CollectionHandler
+	AuthHandler       *authHandler.AuthHandler
+	WebhookHandler    *webhookHandler.WebhookHandler
+	HealthHandler     *healthHandler.HealthHandler
+	JWTSecret         string
+	AllowedOrigins    []string
 }
 
 func NewRouter(
 	customerHandler *customerHandler.CustomerHandler,
 	productHandler *productHandler.ProductHandler,
 	favoriteHandler *favoriteHandler.FavoriteHandler,
+	collectionHandler *collectionHandler.CollectionHandler,
 	authHandler *authHandler.AuthHandler,
+	webhookHandler *webhookHandler.WebhookHandler,
+	healthHandler *healthHandler.HealthHandler,
 	jwtSecret string,
+	allowedOrigins []string,
 ) *Router {
 	return &Router{
-		CustomerHandler: customerHandler,
-		ProductHandler:  productHandler,
-		FavoriteHandler: favoriteHandler,
-		AuthHandler:     authHandler,
-		JWTSecret:       jwtSecret,
+		CustomerHandler:   customerHandler,
+		ProductHandler:    productHandler,
+		FavoriteHandler:   favoriteHandler,
+		CollectionHandler: collectionHandler,
+		AuthHandler:       authHandler,
+		WebhookHandler:    webhookHandler,
+		HealthHandler:     healthHandler,
+		JWTSecret:         jwtSecret,
+		AllowedOrigins:    allowedOrigins,
 	}
 }
 
 func (rt *Router) SetupRoutes() http.Handler {
 	r := chi.NewRouter()
 
-	r.Use(appMiddleware.CORS())
+	r.Use(appMiddleware.CORS(rt.AllowedOrigins))
+	r.Use(appMiddleware.Metrics())
+	r.Use(appMiddleware.RequestID())
+	r.Use(appMiddleware.Tracing())
 	r.Use(appMiddleware.Logger())
 	r.Use(appMiddleware.Recovery())
-	r.Use(appMiddleware.RequestID())
-	r.Use(appMiddleware.Timeout())
 	r.Use(middleware.Compress(5))
 
-	// Swagger documentation
-	r.Get("/swagger/*", httpSwagger.Handler(
-		httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
-	))
+	r.Group(func(r chi.Router) {
+		r.Use(appMiddleware.Timeout())
+
+		// Health probes
+		r.Get("/healthz", rt.HealthHandler.Liveness)
+		r.Get("/readyz", rt.HealthHandler.Readiness)
+
+		// Prometheus metrics
+		r.Handle("/metrics", promhttp.Handler())
+
+		// Swagger documentation
+		r.Get("/swagger/*", httpSwagger.Handler(
+			httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
+		))
+	})
 
 	r.Route("/api", func(r chi.Router) {
 		r.Use(appMiddleware.ContentType())
 
-		// Auth routes (public)
-		r.Route("/auth", func(r chi.Router) {
-			r.Post("/login", rt.AuthHandler.Login)
-			r.Post("/refresh", rt.AuthHandler.RefreshToken)
+		// Long-lived routes, left out of the request timeout
+		r.Group(func(r chi.Router) {
+			r.Use(appMiddleware.JWTAuth(rt.JWTSecret))
+
+			r.Post("/customers/import", rt.CustomerHandler.ImportCustomers)
+			r.Get("/customers/export", rt.CustomerHandler.ExportCustomers)
+			r.Get("/customers/{customer_id}/favorites/stream", rt.FavoriteHandler.StreamFavorites)
 		})
 
-		// Protected routes
 		r.Group(func(r chi.Router) {
-			r.Use(appMiddleware.JWTAuth(rt.JWTSecret))
+			r.Use(appMiddleware.Timeout())
 
-			r.Route("/customers", func(r chi.Router) {
-				r.Post("/", rt.CustomerHandler.CreateCustomer)
-				r.Get("/{id}", rt.CustomerHandler.GetCustomer)
-				r.Put("/{id}", rt.CustomerHandler.UpdateCustomer)
-				r.Delete("/{id}", rt.CustomerHandler.DeleteCustomer)
+			// Auth routes (public)
+			r.Route("/auth", func(r chi.Router) {
+				r.Post("/login", rt.AuthHandler.Login)
+				r.Post("/refresh", rt.AuthHandler.RefreshToken)
+			})
 
-				r.Route("/{customer_id}/favorites", func(r chi.Router) {
-					r.Post("/{product_id}", rt.FavoriteHandler.CreateFavorite)
-					r.Delete("/{product_id}", rt.FavoriteHandler.DeleteFavorite)
+			// Protected routes
+			r.Group(func(r chi.Router) {
+				r.Use(appMiddleware.JWTAuth(rt.JWTSecret))
+
+				r.Route("/customers", func(r chi.Router) {
+					r.Post("/", rt.CustomerHandler.CreateCustomer)
+					r.Get("/{id}", rt.CustomerHandler.GetCustomer)
+					r.Put("/{id}", rt.CustomerHandler.UpdateCustomer)
+					r.Delete("/{id}", rt.CustomerHandler.DeleteCustomer)
+					r.Get("/{id}/recommendations", rt.CustomerHandler.GetRecommendations)
+
+					r.Route("/{customer_id}/favorites", func(r chi.Router) {
+						r.Get("/quota", rt.FavoriteHandler.GetQuota)
+						r.Put("/quota", rt.FavoriteHandler.SetQuota)
+						r.Post("/{product_id}", rt.FavoriteHandler.CreateFavorite)
+						r.Patch("/{product_id}", rt.FavoriteHandler.UpdateFavorite)
+						r.Delete("/{product_id}", rt.FavoriteHandler.DeleteFavorite)
+					})
+
+					r.Route("/{customer_id}/collections", func(r chi.Router) {
+						r.Get("/", rt.CollectionHandler.GetCollections)
+						r.Post("/", rt.CollectionHandler.CreateCollection)
+						r.Get("/{collection_id}", rt.CollectionHandler.GetCollection)
+						r.Put("/{collection_id}", rt.CollectionHandler.UpdateCollection)
+						r.Delete("/{collection_id}", rt.CollectionHandler.DeleteCollection)
+						r.Post("/{collection_id}/favorites/{product_id}", rt.CollectionHandler.AddFavorite)
+						r.Delete("/{collection_id}/favorites/{product_id}", rt.CollectionHandler.RemoveFavorite)
+						r.Post("/{collection_id}/favorites/{product_id}/move", rt.CollectionHandler.MoveFavorite)
+						r.Post("/{collection_id}/favorites/{product_id}/copy", rt.CollectionHandler.CopyFavorite)
+					})
+				})
+
+				r.Route("/favorites/orphans", func(r chi.Router) {
+					r.Get("/", rt.FavoriteHandler.GetOrphanFavorites)
+					r.Delete("/", rt.FavoriteHandler.PruneOrphanFavorites)
 				})
-			})
 
-			r.Route("/products", func(r chi.Router) {
-				r.Get("/", rt.ProductHandler.GetProducts)
-				r.Get("/{id}", rt.ProductHandler.GetProduct)
+				r.Route("/products", func(r chi.Router) {
+					r.Get("/", rt.ProductHandler.GetProducts)
+					r.Post("/", rt.ProductHandler.CreateProduct)
+					r.Get("/categories", rt.ProductHandler.GetCategories)
+					r.Get("/most-favorited", rt.ProductHandler.GetMostFavorited)
+					r.Get("/{id}", rt.ProductHandler.GetProduct)
+					r.Get("/{id}/price-history", rt.ProductHandler.GetPriceHistory)
+					r.Get("/{id}/favorites/count", rt.ProductHandler.GetFavoriteCount)
+					r.Put("/{id}", rt.ProductHandler.UpdateProduct)
+					r.Delete("/{id}", rt.ProductHandler.DeleteProduct)
+				})
+
+				r.Route("/webhooks", func(r chi.Router) {
+					r.Post("/", rt.WebhookHandler.CreateWebhook)
+					r.Get("/", rt.WebhookHandler.GetWebhooks)
+					r.Get("/{id}", rt.WebhookHandler.GetWebhook)
+					r.Put("/{id}", rt.WebhookHandler.UpdateWebhook)
+					r.Delete("/{id}", rt.WebhookHandler.DeleteWebhook)
+					r.Get("/{id}/deliveries", rt.WebhookHandler.GetDeliveries)
+					r.Post("/{id}/deliveries/{delivery_id}/redeliver", rt.WebhookHandler.RedeliverDelivery)
+				})
 			})
 		})
 	})
@@ -89,21 +168,59 @@ func (rt *Router) SetupRoutes() http.Handler {
 
 func (rt *Router) GetAPIRoutes() []RouteInfo {
 	return []RouteInfo{
+		// Health probes
+		{Method: "GET", Path: "/healthz", Description: "Liveness probe"},
+		{Method: "GET", Path: "/readyz", Description: "Readiness probe"},
+		{Method: "GET", Path: "/metrics", Description: "Prometheus metrics"},
+
 		// Auth routes
 		{Method: "POST", Path: "/api/auth/login", Description: "Login user"},
 		{Method: "POST", Path: "/api/auth/refresh", Description: "Refresh access token"},
 
 		// Protected routes
 		{Method: "POST", Path: "/api/customers", Description: "Create a new customer"},
+		{Method: "POST", Path: "/api/customers/import", Description: "Import customers from CSV or NDJSON"},
+		{Method: "GET", Path: "/api/customers/export", Description: "Export customers with their favorite ids"},
 		{Method: "GET", Path: "/api/customers/{id}", Description: "Get customer by ID"},
 		{Method: "PUT", Path: "/api/customers/{id}", Description: "Update customer"},
 		{Method: "DELETE", Path: "/api/customers/{id}", Description: "Delete customer"},
+		{Method: "GET", Path: "/api/customers/{id}/recommendations", Description: "Get product recommendations"},
 
 		{Method: "GET", Path: "/api/products", Description: "List all products"},
+		{Method: "POST", Path: "/api/products", Description: "Create product (postgres catalog)"},
+		{Method: "GET", Path: "/api/products/categories", Description: "List product categories"},
+		{Method: "GET", Path: "/api/products/most-favorited", Description: "List the most favorited products"},
 		{Method: "GET", Path: "/api/products/{id}", Description: "Get product by ID"},
+		{Method: "GET", Path: "/api/products/{id}/price-history", Description: "Get product price history"},
+		{Method: "GET", Path: "/api/products/{id}/favorites/count", Description: "Count product favorites"},
+		{Method: "PUT", Path: "/api/products/{id}", Description: "Update product (postgres catalog)"},
+		{Method: "DELETE", Path: "/api/products/{id}", Description: "Delete product (postgres catalog)"},
 
 		{Method: "POST", Path: "/api/customers/{customer_id}/favorites/{product_id}", Description: "Add product to favorites"},
+		{Method: "PATCH", Path: "/api/customers/{customer_id}/favorites/{product_id}", Description: "Update favorite note, quantity and priority"},
 		{Method: "DELETE", Path: "/api/customers/{customer_id}/favorites/{product_id}", Description: "Remove product from favorites"},
+		{Method: "GET", Path: "/api/customers/{customer_id}/favorites/stream", Description: "Stream favorite changes (SSE)"},
+		{Method: "GET", Path: "/api/customers/{customer_id}/favorites/quota", Description: "Get favorites quota"},
+		{Method: "PUT", Path: "/api/customers/{customer_id}/favorites/quota", Description: "Set favorites quota"},
+		{Method: "GET", Path: "/api/customers/{customer_id}/collections", Description: "List favorite collections"},
+		{Method: "POST", Path: "/api/customers/{customer_id}/collections", Description: "Create a favorite collection"},
+		{Method: "GET", Path: "/api/customers/{customer_id}/collections/{collection_id}", Description: "Get favorite collection with its favorites"},
+		{Method: "PUT", Path: "/api/customers/{customer_id}/collections/{collection_id}", Description: "Rename favorite collection"},
+		{Method: "DELETE", Path: "/api/customers/{customer_id}/collections/{collection_id}", Description: "Delete favorite collection"},
+		{Method: "POST", Path: "/api/customers/{customer_id}/collections/{collection_id}/favorites/{product_id}", Description: "Add product to collection"},
+		{Method: "DELETE", Path: "/api/customers/{customer_id}/collections/{collection_id}/favorites/{product_id}", Description: "Remove product from collection"},
+		{Method: "POST", Path: "/api/customers/{customer_id}/collections/{collection_id}/favorites/{product_id}/move", Description: "Move favorite to another collection"},
+		{Method: "POST", Path: "/api/customers/{customer_id}/collections/{collection_id}/favorites/{product_id}/copy", Description: "Copy favorite to another collection"},
+		{Method: "GET", Path: "/api/favorites/orphans", Description: "Report favorites of products missing from the catalog"},
+		{Method: "DELETE", Path: "/api/favorites/orphans", Description: "Prune favorites of products missing from the catalog"},
+
+		{Method: "POST", Path: "/api/webhooks", Description: "Create a webhook subscription"},
+		{Method: "GET", Path: "/api/webhooks", Description: "List webhook subscriptions"},
+		{Method: "GET", Path: "/api/webhooks/{id}", Description: "Get webhook subscription by ID"},
+		{Method: "PUT", Path: "/api/webhooks/{id}", Description: "Update webhook subscription"},
+		{Method: "DELETE", Path: "/api/webhooks/{id}", Description: "Delete webhook subscription"},
+		{Method: "GET", Path: "/api/webhooks/{id}/deliveries", Description: "List webhook deliveries"},
+		{Method: "POST", Path: "/api/webhooks/{id}/deliveries/{delivery_id}/redeliver", Description: "Redeliver a webhook delivery"},
 	}
 }
 
@@ -0,0 +1,24 @@
+package metrics
+
+// BusinessMetrics records the business counters exposed on /metrics.
+type BusinessMetrics struct{}
+
+func NewBusinessMetrics() *BusinessMetrics {
+	return &BusinessMetrics{}
+}
+
+func (b *BusinessMetrics) CustomerCreated() {
+	CustomersCreatedTotal.Inc()
+}
+
+func (b *BusinessMetrics) FavoriteAdded() {
+	FavoritesAddedTotal.Inc()
+}
+
+func (b *BusinessMetrics) FavoriteRemoved() {
+	FavoritesRemovedTotal.Inc()
+}
+
+func (b *BusinessMetrics) LoginFailed() {
+	LoginFailuresTotal.Inc()
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterDatabase exposes the pgxpool connection pool statistics.
+func RegisterDatabase(pool *pgxpool.Pool, dbName string) error {
+	return prometheus.Register(newPoolCollector(pool, dbName))
+}
+
+// poolCollector reads pool.Stat on every scrape.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	maxConns              *prometheus.Desc
+	totalConns            *prometheus.Desc
+	idleConns             *prometheus.Desc
+	acquiredConns         *prometheus.Desc
+	acquiresTotal         *prometheus.Desc
+	emptyAcquiresTotal    *prometheus.Desc
+	canceledAcquiresTotal *prometheus.Desc
+	acquireSeconds        *prometheus.Desc
+	newConnsTotal         *prometheus.Desc
+	lifetimeClosedTotal   *prometheus.Desc
+	idleClosedTotal       *prometheus.Desc
+}
+
+func newPoolCollector(pool *pgxpool.Pool, dbName string) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "db_pool", name),
+			help,
+			nil,
+			prometheus.Labels{"db_name": dbName},
+		)
+	}
+
+	return &poolCollector{
+		pool:                  pool,
+		maxConns:              desc("max_conns", "Maximum size of the pool."),
+		totalConns:            desc("conns", "Connections currently open, idle or in use."),
+		idleConns:             desc("idle_conns", "Idle connections."),
+		acquiredConns:         desc("acquired_conns", "Connections in use."),
+		acquiresTotal:         desc("acquires_total", "Connections acquired from the pool."),
+		emptyAcquiresTotal:    desc("empty_acquires_total", "Acquires that waited for a connection because the pool was empty."),
+		canceledAcquiresTotal: desc("canceled_acquires_total", "Acquires canceled by their context."),
+		acquireSeconds:        desc("acquire_duration_seconds_total", "Time spent acquiring connections."),
+		newConnsTotal:         desc("new_conns_total", "Connections opened."),
+		lifetimeClosedTotal:   desc("max_lifetime_closed_total", "Connections closed for exceeding DB_CONN_MAX_LIFETIME."),
+		idleClosedTotal:       desc("max_idle_closed_total", "Connections closed for exceeding DB_CONN_MAX_IDLE_TIME."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxConns
+	ch <- c.totalConns
+	ch <- c.idleConns
+	ch <- c.acquiredConns
+	ch <- c.acquiresTotal
+	ch <- c.emptyAcquiresTotal
+	ch <- c.canceledAcquiresTotal
+	ch <- c.acquireSeconds
+	ch <- c.newConnsTotal
+	ch <- c.lifetimeClosedTotal
+	ch <- c.idleClosedTotal
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquiresTotal, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquiresTotal, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquiresTotal, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireSeconds, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.newConnsTotal, prometheus.CounterValue, float64(stat.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.lifetimeClosedTotal, prometheus.CounterValue, float64(stat.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.idleClosedTotal, prometheus.CounterValue, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transport records latency and outcome of outbound requests to an upstream.
+type Transport struct {
+	Upstream string
+	Next     http.RoundTripper
+}
+
+func NewTransport(upstream string, next http.RoundTripper) *Transport {
+	return &Transport{
+		Upstream: upstream,
+		Next:     next,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := req.Method + " " + EndpointPattern(req.URL.Path)
+	start := time.Now()
+
+	resp, err := t.Next.RoundTrip(req)
+
+	UpstreamRequestDuration.WithLabelValues(t.Upstream, endpoint).Observe(time.Since(start).Seconds())
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	UpstreamRequestsTotal.WithLabelValues(t.Upstream, endpoint, status).Inc()
+
+	return resp, err
+}
+
+// EndpointPattern replaces numeric path segments with {id} and category names
+// with {category} to keep label cardinality bounded, e.g. /products/12 becomes
+// /products/{id}.
+func EndpointPattern(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if i > 0 && segments[i-1] == "category" {
+			segments[i] = "{category}"
+			continue
+		}
+
+		if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointPattern(t *testing.T) {
+	assert.Equal(t, "/products", EndpointPattern("/products"))
+	assert.Equal(t, "/products/{id}", EndpointPattern("/products/12"))
+	assert.Equal(t, "/products/category/{category}", EndpointPattern("/products/category/3"))
+	assert.Equal(t, "/products/category/{category}", EndpointPattern("/products/category/men's%20clothing"))
+	assert.Equal(t, "/products/categories", EndpointPattern("/products/categories"))
+}
+
+func TestTransport_RecordsUpstreamRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport("test-upstream", http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/products/42")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(UpstreamRequestsTotal.WithLabelValues("test-upstream", "GET /products/{id}", "404")))
+}
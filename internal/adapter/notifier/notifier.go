@@ -0,0 +1,65 @@
+// Package notifier holds the service.PriceDropNotifier implementations
+// selected by PRICE_DROP_NOTIFIER.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+const (
+	KindEvent = "event"
+	KindLog   = "log"
+)
+
+// New returns the notifier for kind.
+func New(kind string, publisher event.Publisher) (service.PriceDropNotifier, error) {
+	switch kind {
+	case KindEvent:
+		return NewEventNotifier(publisher), nil
+	case KindLog:
+		return NewLogNotifier(), nil
+	default:
+		return nil, fmt.Errorf("unknown price drop notifier %q", kind)
+	}
+}
+
+// EventNotifier publishes a favorite.price_dropped event, which reaches the
+// customer's SSE stream and every webhook subscribed to it.
+type EventNotifier struct {
+	Publisher event.Publisher
+}
+
+func NewEventNotifier(publisher event.Publisher) *EventNotifier {
+	return &EventNotifier{
+		Publisher: publisher,
+	}
+}
+
+func (n *EventNotifier) NotifyPriceDrop(ctx context.Context, drop *entity.PriceDrop) error {
+	return n.Publisher.Publish(ctx, event.NewFavoritePriceDroppedEvent(drop.CustomerId, drop.ProductId, drop.Title, drop.OldPrice, drop.NewPrice))
+}
+
+// LogNotifier only logs the price drop, for development.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) NotifyPriceDrop(ctx context.Context, drop *entity.PriceDrop) error {
+	logger.FromContext(ctx).Info("favorite price dropped",
+		slog.String("customer_id", drop.CustomerId),
+		slog.Int64("product_id", drop.ProductId),
+		slog.Float64("old_price", drop.OldPrice),
+		slog.Float64("new_price", drop.NewPrice),
+	)
+
+	return nil
+}
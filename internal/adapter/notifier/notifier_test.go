@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	events []*event.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, e *event.Event) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	publisher := &recordingPublisher{}
+
+	n, err := New(KindEvent, publisher)
+	require.NoError(t, err)
+	assert.IsType(t, &EventNotifier{}, n)
+
+	n, err = New(KindLog, publisher)
+	require.NoError(t, err)
+	assert.IsType(t, &LogNotifier{}, n)
+
+	_, err = New("sms", publisher)
+	assert.Error(t, err)
+}
+
+func TestEventNotifier_NotifyPriceDrop(t *testing.T) {
+	publisher := &recordingPublisher{}
+	drop := &entity.PriceDrop{CustomerId: "customer-1", ProductId: 1, Title: "Backpack", OldPrice: 109.95, NewPrice: 89.9}
+
+	require.NoError(t, NewEventNotifier(publisher).NotifyPriceDrop(context.Background(), drop))
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, event.FavoritePriceDropped, publisher.events[0].Type)
+	assert.Equal(t, event.PriceDropData{CustomerId: "customer-1", ProductId: 1, Title: "Backpack", OldPrice: 109.95, NewPrice: 89.9}, publisher.events[0].Data)
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/circuitbreaker"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/metrics"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const DefaultBaseURL = "https://fakestoreapi.com"
+
+var (
+	// ErrEmptyResponse is returned when a 200 response has no body, which is
+	// how fakestoreapi answers unknown product ids.
+	ErrEmptyResponse = errors.New("fakestoreapi returned an empty response")
+	// ErrMalformedResponse is returned when the body cannot be decoded.
+	ErrMalformedResponse = errors.New("fakestoreapi returned a malformed response")
+)
+
+// StatusError is returned for responses the client does not retry, such as 404.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("fakestoreapi responded with status %d", e.StatusCode)
+}
+
+type ClientOptions struct {
+	BaseURL          string
+	Timeout          time.Duration
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// Client calls fakestoreapi with a per attempt timeout, retrying network
+// errors, 429 and 5xx responses with jittered exponential backoff. After
+// repeated failures the circuit breaker fails fast with
+// repository.ErrProductServiceUnavailable until upstream recovers.
+type Client struct {
+	BaseURL      string
+	HTTPClient   *http.Client
+	MaxRetries   int
+	RetryBackoff time.Duration
+	Breaker      *circuitbreaker.Breaker
+}
+
+func NewClient(options ClientOptions) *Client {
+	baseURL := options.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Client{
+		BaseURL: baseURL,
+		HTTPClient: &http.Client{
+			Timeout:   options.Timeout,
+			Transport: otelhttp.NewTransport(metrics.NewTransport("fakestoreapi", http.DefaultTransport)),
+		},
+		MaxRetries:   options.MaxRetries,
+		RetryBackoff: options.RetryBackoff,
+		Breaker:      circuitbreaker.New(options.BreakerThreshold, options.BreakerCooldown),
+	}
+}
+
+// GetJSON requests path and decodes a 200 response body into out. Besides
+// repository.ErrProductServiceUnavailable it returns a *StatusError for other
+// non retryable statuses, ErrEmptyResponse or ErrMalformedResponse.
+func (c *Client) GetJSON(ctx context.Context, path string, out any) error {
+	if err := c.Breaker.Allow(); err != nil {
+		return fmt.Errorf("%w: %s", repository.ErrProductServiceUnavailable, err)
+	}
+
+	body, err := c.getWithRetry(ctx, path)
+	if err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) {
+			// The upstream answered, so it is healthy from the breaker point of view.
+			c.Breaker.Success()
+			return err
+		}
+
+		if ctx.Err() != nil {
+			c.Breaker.Cancel()
+			return ctx.Err()
+		}
+
+		c.Breaker.Failure()
+		return fmt.Errorf("%w: %s", repository.ErrProductServiceUnavailable, err)
+	}
+	c.Breaker.Success()
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ErrEmptyResponse
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("%w: %s", ErrMalformedResponse, err)
+	}
+
+	return nil
+}
+
+func (c *Client) getWithRetry(ctx context.Context, path string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if attempt > 0 {
+			logger.FromContext(ctx).Warn("fakestoreapi: retrying request",
+				slog.String("path", path),
+				slog.Int("attempt", attempt),
+				slog.Any("error", lastErr),
+			)
+
+			select {
+			case <-time.After(c.backoff(attempt)):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		body, retryable, err := c.get(ctx, path)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("error while creating request: %s", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, fmt.Errorf("error while sending request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("error while reading response: %s", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return body, false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, true, fmt.Errorf("fakestoreapi responded with status %d", resp.StatusCode)
+	default:
+		return nil, false, &StatusError{StatusCode: resp.StatusCode}
+	}
+}
+
+// backoff returns a random wait in [0, RetryBackoff * 2^(retry-1)], the "full
+// jitter" strategy, so concurrent callers do not retry in lockstep.
+func (c *Client) backoff(retry int) time.Duration {
+	ceiling := c.RetryBackoff << (retry - 1)
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling + 1)
+}
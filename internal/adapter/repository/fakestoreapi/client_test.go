@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/circuitbreaker"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(url string) *Client {
+	return NewClient(ClientOptions{
+		BaseURL:          url,
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+	})
+}
+
+func TestClient_GetJSON_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "title": "Backpack"}`))
+	}))
+	defer server.Close()
+
+	var product FakestoreapiProductResponse
+	err := newTestClient(server.URL).GetJSON(context.Background(), "/products/1", &product)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, "Backpack", product.Title)
+}
+
+func TestClient_GetJSON_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	err := client.GetJSON(context.Background(), "/products/99", &FakestoreapiProductResponse{})
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, circuitbreaker.Closed, client.Breaker.State())
+}
+
+func TestClient_GetJSON_OpensCircuit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	for i := 0; i < 2; i++ {
+		err := client.GetJSON(context.Background(), "/products", &[]FakestoreapiProductResponse{})
+		assert.ErrorIs(t, err, repository.ErrProductServiceUnavailable)
+	}
+	assert.Equal(t, int32(6), calls.Load())
+
+	err := client.GetJSON(context.Background(), "/products", &[]FakestoreapiProductResponse{})
+	assert.ErrorIs(t, err, repository.ErrProductServiceUnavailable)
+	assert.ErrorContains(t, err, circuitbreaker.ErrOpen.Error())
+	assert.Equal(t, int32(6), calls.Load())
+}
+
+func TestClient_GetJSON_TimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.HTTPClient.Timeout = 10 * time.Millisecond
+	client.MaxRetries = 0
+
+	err := client.GetJSON(context.Background(), "/products", &[]FakestoreapiProductResponse{})
+	assert.ErrorIs(t, err, repository.ErrProductServiceUnavailable)
+}
@@ -1,5 +1,11 @@
 package repository
 
+import (
+	"fmt"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
 type FakestoreapiProductResponse struct {
 	ID          int64   `json:"id"`
 	Title       string  `json:"title"`
@@ -12,3 +18,12 @@ type FakestoreapiProductResponse struct {
 		Count int64   `json:"count"`
 	} `json:"rating"`
 }
+
+func (r FakestoreapiProductResponse) toEntity() (*entity.Product, error) {
+	product, err := entity.NewProduct(r.ID, r.Title, r.Description, r.Category, r.Image, r.Price, r.Rating.Rate, r.Rating.Count)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrMalformedResponse, err)
+	}
+
+	return product, nil
+}
@@ -1,46 +1,83 @@
 package repository
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
 )
 
 type ProductRepositoryImpl struct {
-	BaseURL string
+	Client *Client
 }
 
-func NewProductRepository() *ProductRepositoryImpl {
+func NewProductRepository(client *Client) *ProductRepositoryImpl {
 	return &ProductRepositoryImpl{
-		BaseURL: "https://fakestoreapi.com",
+		Client: client,
 	}
 }
 
-func (p *ProductRepositoryImpl) FindAll() ([]*entity.Product, error) {
-	var fakestoreapiResponse []FakestoreapiProductResponse
-	var productsResponse []*entity.Product
+func (p *ProductRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	return p.findProducts(ctx, "/products")
+}
 
-	url := fmt.Sprintf("%s/products", p.BaseURL)
-	resp, err := http.DefaultClient.Get(url)
+func (p *ProductRepositoryImpl) FindByCategory(ctx context.Context, category string) ([]*entity.Product, error) {
+	return p.findProducts(ctx, "/products/category/"+url.PathEscape(category))
+}
+
+// FindByIds reads the whole catalog in one request, as fakestoreapi has no
+// batch endpoint and the catalog is small.
+func (p *ProductRepositoryImpl) FindByIds(ctx context.Context, ids []int64) (map[int64]*entity.Product, error) {
+	products, err := p.FindAll(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("fakestoreapi error: %s", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("fakestoreapi error %d", resp.StatusCode)
+	found := make(map[int64]*entity.Product, len(ids))
+	for _, product := range products {
+		if slices.Contains(ids, product.Id) {
+			found[product.Id] = product
+		}
+	}
+
+	return found, nil
+}
+
+func (p *ProductRepositoryImpl) FindCategories(ctx context.Context) ([]string, error) {
+	var categories []string
+
+	if err := p.Client.GetJSON(ctx, "/products/categories", &categories); err != nil {
+		if errors.Is(err, ErrEmptyResponse) {
+			return nil, fmt.Errorf("%w: empty category list", ErrMalformedResponse)
+		}
+
+		return nil, err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&fakestoreapiResponse); err != nil {
-		return nil, fmt.Errorf("fakestoreapi error while parsing response: %s", err)
+	return categories, nil
+}
+
+func (p *ProductRepositoryImpl) findProducts(ctx context.Context, path string) ([]*entity.Product, error) {
+	var fakestoreapiResponse []FakestoreapiProductResponse
+	var productsResponse []*entity.Product
+
+	if err := p.Client.GetJSON(ctx, path, &fakestoreapiResponse); err != nil {
+		if errors.Is(err, ErrEmptyResponse) {
+			return nil, fmt.Errorf("%w: empty product list", ErrMalformedResponse)
+		}
+
+		return nil, err
 	}
 
 	for _, productResponse := range fakestoreapiResponse {
-		productEntity, err := entity.NewProduct(productResponse.ID, productResponse.Title, productResponse.Image, productResponse.Price, productResponse.Rating.Rate, productResponse.Rating.Count)
+		productEntity, err := productResponse.toEntity()
 		if err != nil {
-			return nil, fmt.Errorf("fakestoreapi error while creating product entity: %s", err)
+			return nil, err
 		}
 		productsResponse = append(productsResponse, productEntity)
 	}
@@ -48,31 +85,22 @@ func (p *ProductRepositoryImpl) FindAll() ([]*entity.Product, error) {
 	return productsResponse, nil
 }
 
-func (p *ProductRepositoryImpl) FindById(id int64) (*entity.Product, error) {
+func (p *ProductRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.Product, error) {
 	var fakestoreapiResponse FakestoreapiProductResponse
-	var productEntity *entity.Product
 
-	url := fmt.Sprintf("%s/products/%d", p.BaseURL, id)
-	resp, err := http.DefaultClient.Get(url)
+	err := p.Client.GetJSON(ctx, fmt.Sprintf("/products/%d", id), &fakestoreapiResponse)
 	if err != nil {
-		return nil, fmt.Errorf("fakestoreapi error: %s", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("fakestoreapi error %d", resp.StatusCode)
-	}
+		var statusErr *StatusError
+		if errors.Is(err, ErrEmptyResponse) || (errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound) {
+			return nil, repository.ErrProductNotFound
+		}
 
-	if err := json.NewDecoder(resp.Body).Decode(&fakestoreapiResponse); err != nil {
-		return nil, nil
+		return nil, err
 	}
 
-	if fakestoreapiResponse.ID != 0 {
-		productEntity, err = entity.NewProduct(fakestoreapiResponse.ID, fakestoreapiResponse.Title, fakestoreapiResponse.Image, fakestoreapiResponse.Price, fakestoreapiResponse.Rating.Rate, fakestoreapiResponse.Rating.Count)
-		if err != nil {
-			return nil, fmt.Errorf("fakestoreapi error while creating product entity: %s", err)
-		}
+	if fakestoreapiResponse.ID != id {
+		return nil, fmt.Errorf("%w: expected product %d, got %d", ErrMalformedResponse, id, fakestoreapiResponse.ID)
 	}
 
-	return productEntity, nil
+	return fakestoreapiResponse.toEntity()
 }
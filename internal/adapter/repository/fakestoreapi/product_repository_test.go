@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFixtureServer serves the recorded fakestoreapi responses in testdata,
+// keyed by request path.
+func newFixtureServer(t *testing.T, status int, fixtures map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+
+		body, err := os.ReadFile(filepath.Join("testdata", fixture))
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newFixtureRepository(t *testing.T, status int, fixtures map[string]string) *ProductRepositoryImpl {
+	client := newTestClient(newFixtureServer(t, status, fixtures).URL)
+	client.MaxRetries = 0
+
+	return NewProductRepository(client)
+}
+
+func TestProductRepository_FindAll(t *testing.T) {
+	repo := newFixtureRepository(t, http.StatusOK, map[string]string{"/products": "products.json"})
+
+	products, err := repo.FindAll(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, products, 3)
+	assert.Equal(t, int64(1), products[0].Id)
+	assert.Equal(t, "Fjallraven - Foldsack No. 1 Backpack, Fits 15 Laptops", products[0].Title)
+	assert.Equal(t, "https://fakestoreapi.com/img/81fPKd-2AYL._AC_SL1500_.jpg", products[0].Image)
+	assert.Equal(t, 109.95, products[0].Price)
+	assert.Equal(t, 3.9, products[0].Rate)
+	assert.Equal(t, int64(120), products[0].RateCount)
+	assert.Equal(t, int64(3), products[2].Id)
+	assert.Equal(t, "men's clothing", products[0].Category)
+	assert.Contains(t, products[0].Description, "Your perfect pack for everyday use")
+}
+
+func TestProductRepository_FindByCategory(t *testing.T) {
+	repo := newFixtureRepository(t, http.StatusOK, map[string]string{"/products/category/men's clothing": "products.json"})
+
+	products, err := repo.FindByCategory(context.Background(), "men's clothing")
+
+	require.NoError(t, err)
+	require.Len(t, products, 3)
+	for _, product := range products {
+		assert.Equal(t, "men's clothing", product.Category)
+	}
+}
+
+func TestProductRepository_FindCategories(t *testing.T) {
+	repo := newFixtureRepository(t, http.StatusOK, map[string]string{"/products/categories": "categories.json"})
+
+	categories, err := repo.FindCategories(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"electronics", "jewelery", "men's clothing", "women's clothing"}, categories)
+}
+
+func TestProductRepository_FindAll_Malformed(t *testing.T) {
+	tests := map[string]string{
+		"html body":       "malformed.html",
+		"empty body":      "product_unknown.json",
+		"invalid product": "products_invalid.json",
+	}
+
+	for name, fixture := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := newFixtureRepository(t, http.StatusOK, map[string]string{"/products": fixture})
+
+			products, err := repo.FindAll(context.Background())
+
+			assert.ErrorIs(t, err, ErrMalformedResponse)
+			assert.NotErrorIs(t, err, repository.ErrProductServiceUnavailable)
+			assert.Nil(t, products)
+		})
+	}
+}
+
+func TestProductRepository_FindById(t *testing.T) {
+	repo := newFixtureRepository(t, http.StatusOK, map[string]string{"/products/1": "product_1.json"})
+
+	product, err := repo.FindById(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), product.Id)
+	assert.Equal(t, "Fjallraven - Foldsack No. 1 Backpack, Fits 15 Laptops", product.Title)
+	assert.Equal(t, 109.95, product.Price)
+}
+
+func TestProductRepository_FindById_NotFound(t *testing.T) {
+	t.Run("empty body", func(t *testing.T) {
+		repo := newFixtureRepository(t, http.StatusOK, map[string]string{"/products/999": "product_unknown.json"})
+
+		product, err := repo.FindById(context.Background(), 999)
+
+		assert.ErrorIs(t, err, repository.ErrProductNotFound)
+		assert.Nil(t, product)
+	})
+
+	t.Run("404 status", func(t *testing.T) {
+		repo := newFixtureRepository(t, http.StatusNotFound, map[string]string{"/products/999": "product_unknown.json"})
+
+		product, err := repo.FindById(context.Background(), 999)
+
+		assert.ErrorIs(t, err, repository.ErrProductNotFound)
+		assert.Nil(t, product)
+	})
+}
+
+func TestProductRepository_FindById_Malformed(t *testing.T) {
+	tests := map[string]struct {
+		id      int64
+		fixture string
+	}{
+		"html body":       {id: 1, fixture: "malformed.html"},
+		"invalid product": {id: 1, fixture: "product_invalid.json"},
+		"mismatched id":   {id: 2, fixture: "product_1.json"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := newFixtureRepository(t, http.StatusOK, map[string]string{fmt.Sprintf("/products/%d", tt.id): tt.fixture})
+
+			product, err := repo.FindById(context.Background(), tt.id)
+
+			assert.ErrorIs(t, err, ErrMalformedResponse)
+			assert.NotErrorIs(t, err, repository.ErrProductNotFound)
+			assert.Nil(t, product)
+		})
+	}
+}
+
+func TestProductRepository_FindById_UpstreamFailure(t *testing.T) {
+	repo := newFixtureRepository(t, http.StatusServiceUnavailable, map[string]string{"/products/1": "malformed.html"})
+
+	product, err := repo.FindById(context.Background(), 1)
+
+	assert.ErrorIs(t, err, repository.ErrProductServiceUnavailable)
+	assert.NotErrorIs(t, err, repository.ErrProductNotFound)
+	assert.Nil(t, product)
+}
+
+func TestProductRepository_FindByIds(t *testing.T) {
+	repo := newFixtureRepository(t, http.StatusOK, map[string]string{"/products": "products.json"})
+
+	products, err := repo.FindByIds(context.Background(), []int64{3, 1, 42})
+
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, int64(1), products[1].Id)
+	assert.Equal(t, int64(3), products[3].Id)
+	assert.NotContains(t, products, int64(42))
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// productRecord follows the fakestoreapi product format, so a dump of
+// https://fakestoreapi.com/products can be used as is.
+type productRecord struct {
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Price       float64 `json:"price"`
+	Description string  `json:"description"`
+	Category    string  `json:"category"`
+	Image       string  `json:"image"`
+	Rating      struct {
+		Rate  float64 `json:"rate"`
+		Count int64   `json:"count"`
+	} `json:"rating"`
+}
+
+// ProductRepositoryImpl serves a read-only catalog loaded from a JSON file,
+// for offline development and tests.
+type ProductRepositoryImpl struct {
+	products []*entity.Product
+	byId     map[int64]*entity.Product
+}
+
+func NewProductRepository(path string) (*ProductRepositoryImpl, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading product catalog: %s", err)
+	}
+
+	var records []productRecord
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, fmt.Errorf("error while parsing product catalog %s: %s", path, err)
+	}
+
+	repo := &ProductRepositoryImpl{
+		byId: make(map[int64]*entity.Product, len(records)),
+	}
+	for _, record := range records {
+		product, err := entity.NewProduct(record.ID, record.Title, record.Description, record.Category, record.Image, record.Price, record.Rating.Rate, record.Rating.Count)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing product %d from catalog: %s", record.ID, err)
+		}
+
+		if _, exists := repo.byId[product.Id]; exists {
+			return nil, fmt.Errorf("product %d is duplicated in catalog", product.Id)
+		}
+
+		repo.products = append(repo.products, product)
+		repo.byId[product.Id] = product
+	}
+
+	return repo, nil
+}
+
+func (p *ProductRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	products := make([]*entity.Product, len(p.products))
+	for i, product := range p.products {
+		copied := *product
+		products[i] = &copied
+	}
+
+	return products, nil
+}
+
+func (p *ProductRepositoryImpl) FindByCategory(ctx context.Context, category string) ([]*entity.Product, error) {
+	products := []*entity.Product{}
+	for _, product := range p.products {
+		if product.Category == category {
+			copied := *product
+			products = append(products, &copied)
+		}
+	}
+
+	return products, nil
+}
+
+func (p *ProductRepositoryImpl) FindByIds(ctx context.Context, ids []int64) (map[int64]*entity.Product, error) {
+	found := make(map[int64]*entity.Product, len(ids))
+	for _, id := range ids {
+		if product, ok := p.byId[id]; ok {
+			copied := *product
+			found[id] = &copied
+		}
+	}
+
+	return found, nil
+}
+
+func (p *ProductRepositoryImpl) FindCategories(ctx context.Context) ([]string, error) {
+	var categories []string
+	for _, product := range p.products {
+		if product.Category != "" && !slices.Contains(categories, product.Category) {
+			categories = append(categories, product.Category)
+		}
+	}
+	slices.Sort(categories)
+
+	return categories, nil
+}
+
+func (p *ProductRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.Product, error) {
+	product, ok := p.byId[id]
+	if !ok {
+		return nil, repository.ErrProductNotFound
+	}
+
+	copied := *product
+	return &copied, nil
+}
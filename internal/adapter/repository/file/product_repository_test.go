@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCatalog(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "products.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func TestProductRepository_LoadsCatalog(t *testing.T) {
+	repo, err := NewProductRepository(filepath.Join("..", "..", "..", "..", "database", "catalog", "products.json"))
+	require.NoError(t, err)
+
+	products, err := repo.FindAll(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, products)
+
+	product, err := repo.FindById(context.Background(), products[0].Id)
+	require.NoError(t, err)
+	assert.Equal(t, products[0].Title, product.Title)
+}
+
+func TestProductRepository_Categories(t *testing.T) {
+	repo, err := NewProductRepository(writeCatalog(t, `[
+		{"id": 1, "title": "A", "price": 10, "category": "jewelery", "image": "https://placehold.co/600x400"},
+		{"id": 2, "title": "B", "price": 10, "category": "electronics", "image": "https://placehold.co/600x400"},
+		{"id": 3, "title": "C", "price": 10, "category": "jewelery", "image": "https://placehold.co/600x400"}
+	]`))
+	require.NoError(t, err)
+
+	categories, err := repo.FindCategories(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"electronics", "jewelery"}, categories)
+
+	products, err := repo.FindByCategory(context.Background(), "jewelery")
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, int64(1), products[0].Id)
+	assert.Equal(t, int64(3), products[1].Id)
+
+	products, err = repo.FindByCategory(context.Background(), "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, products)
+}
+
+func TestProductRepository_FindById_NotFound(t *testing.T) {
+	repo, err := NewProductRepository(writeCatalog(t, `[]`))
+	require.NoError(t, err)
+
+	product, err := repo.FindById(context.Background(), 1)
+
+	assert.ErrorIs(t, err, repository.ErrProductNotFound)
+	assert.Nil(t, product)
+}
+
+func TestProductRepository_InvalidCatalog(t *testing.T) {
+	tests := map[string]string{
+		"malformed json":  `{`,
+		"invalid product": `[{"id": 1, "title": "", "price": 10, "image": "https://placehold.co/600x400"}]`,
+		"duplicated id":   `[{"id": 1, "title": "A", "price": 10, "image": "https://placehold.co/600x400"}, {"id": 1, "title": "B", "price": 10, "image": "https://placehold.co/600x400"}]`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewProductRepository(writeCatalog(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewProductRepository(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// catalogSyncLockKey is the advisory lock that keeps the sync command and the
+// in-process scheduler from importing at the same time.
+const catalogSyncLockKey = 4_210_035
+
+type CatalogRepositoryImpl struct {
+	DB      *pgxpool.Pool
+	Queries *database.Queries
+}
+
+func NewCatalogRepository(db *pgxpool.Pool, queries *database.Queries) *CatalogRepositoryImpl {
+	return &CatalogRepositoryImpl{
+		DB:      db,
+		Queries: queries,
+	}
+}
+
+func (c *CatalogRepositoryImpl) Sync(ctx context.Context, products []*entity.Product, run *entity.CatalogSyncRun) error {
+	tx, queries, err := begin(ctx, c.DB)
+	if err != nil {
+		return fmt.Errorf("error while starting catalog sync: %s", err)
+	}
+	defer tx.Rollback(ctx)
+
+	locked, err := queries.LockCatalogSync(ctx, catalogSyncLockKey)
+	if err != nil {
+		return fmt.Errorf("error while locking catalog sync: %s", err)
+	}
+
+	if !locked {
+		return repository.ErrCatalogSyncInProgress
+	}
+
+	upstreamIds := make([]int64, len(products))
+	for i, product := range products {
+		upstreamIds[i] = product.Id
+	}
+
+	localIds, err := queries.FindLocalProductIds(ctx, upstreamIds)
+	if err != nil {
+		return fmt.Errorf("error while checking product id conflicts: %s", err)
+	}
+	run.Conflicts = localIds
+
+	syncedIds := make([]int64, 0, len(products))
+	for _, product := range products {
+		if slices.Contains(localIds, product.Id) {
+			continue
+		}
+
+		contentHash := product.ContentHash()
+		inserted, err := queries.UpsertSyncedProduct(ctx, database.UpsertSyncedProductParams{
+			ID:          product.Id,
+			Title:       product.Title,
+			Description: product.Description,
+			Category:    product.Category,
+			Image:       product.Image,
+			Price:       product.Price,
+			Rate:        product.Rate,
+			RateCount:   product.RateCount,
+			ContentHash: &contentHash,
+		})
+
+		// No row comes back when the product is unchanged.
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			run.Unchanged++
+		case err != nil:
+			return fmt.Errorf("error while upserting product %d: %s", product.Id, err)
+		case inserted:
+			run.Inserted++
+		default:
+			run.Updated++
+		}
+
+		syncedIds = append(syncedIds, product.Id)
+	}
+
+	discontinued, err := queries.DiscontinueMissingProducts(ctx, syncedIds)
+	if err != nil {
+		return fmt.Errorf("error while discontinuing products: %s", err)
+	}
+	run.Discontinued = int(discontinued)
+
+	// Synced products keep the upstream ids, so move the sequence past them
+	// before anyone creates a product through the API.
+	if err = queries.ResetProductIdSequence(ctx); err != nil {
+		return fmt.Errorf("error while resetting product id sequence: %s", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error while committing catalog sync: %s", err)
+	}
+
+	return nil
+}
+
+func (c *CatalogRepositoryImpl) CreateSyncRun(ctx context.Context, run *entity.CatalogSyncRun) error {
+	id, err := c.Queries.InsertCatalogSyncRun(ctx, database.InsertCatalogSyncRunParams{
+		Status:    run.Status,
+		StartedAt: run.StartedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error while inserting catalog sync run: %s", err)
+	}
+
+	run.Id = id
+	return nil
+}
+
+func (c *CatalogRepositoryImpl) FinishSyncRun(ctx context.Context, run *entity.CatalogSyncRun) error {
+	var finishedAt *time.Time
+	if !run.FinishedAt.IsZero() {
+		finishedAt = &run.FinishedAt
+	}
+
+	err := c.Queries.FinishCatalogSyncRun(ctx, database.FinishCatalogSyncRunParams{
+		Status:       run.Status,
+		Fetched:      int32(run.Fetched),
+		Inserted:     int32(run.Inserted),
+		Updated:      int32(run.Updated),
+		Unchanged:    int32(run.Unchanged),
+		Discontinued: int32(run.Discontinued),
+		Conflicts:    int32(len(run.Conflicts)),
+		Error:        run.Error,
+		FinishedAt:   finishedAt,
+		ID:           run.Id,
+	})
+	if err != nil {
+		return fmt.Errorf("error while updating catalog sync run: %s", err)
+	}
+
+	return nil
+}
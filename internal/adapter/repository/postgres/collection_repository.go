@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type CollectionRepositoryImpl struct {
+	DB      *pgxpool.Pool
+	Queries *database.Queries
+}
+
+func NewCollectionRepository(db *pgxpool.Pool, queries *database.Queries) *CollectionRepositoryImpl {
+	return &CollectionRepositoryImpl{
+		DB:      db,
+		Queries: queries,
+	}
+}
+
+func (c *CollectionRepositoryImpl) FindAllByCustomer(ctx context.Context, customer *entity.Customer) ([]*entity.Collection, error) {
+	customerUUID, err := uuid.Parse(customer.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing customer uuid: %s", err)
+	}
+
+	rows, err := c.Queries.FindFavoriteCollectionsByCustomer(ctx, customerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting collections: %s", err)
+	}
+
+	collections := make([]*entity.Collection, 0, len(rows))
+	for _, row := range rows {
+		collection, err := toCollectionEntity(database.FavoriteCollection{
+			ID:         row.ID,
+			CustomerID: row.CustomerID,
+			Name:       row.Name,
+			IsDefault:  row.IsDefault,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		collection.FavoritesCount = row.Favorites
+		collections = append(collections, collection)
+	}
+
+	return collections, nil
+}
+
+func (c *CollectionRepositoryImpl) FindById(ctx context.Context, customer *entity.Customer, id string) (*entity.Collection, error) {
+	collectionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	customerUUID, err := uuid.Parse(customer.Id)
+	if err != nil {
+		return nil, nil
+	}
+
+	collection, err := c.Queries.FindFavoriteCollectionById(ctx, database.FindFavoriteCollectionByIdParams{
+		ID:         collectionUUID,
+		CustomerID: customerUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error while getting collection: %s", err)
+	}
+
+	return toCollectionEntity(collection)
+}
+
+func (c *CollectionRepositoryImpl) FindOrCreateDefault(ctx context.Context, customer *entity.Customer) (*entity.Collection, error) {
+	customerUUID, err := uuid.Parse(customer.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing customer uuid: %s", err)
+	}
+
+	collection, err := ensureDefaultCollection(ctx, c.Queries, customerUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCollectionEntity(collection)
+}
+
+func (c *CollectionRepositoryImpl) Create(ctx context.Context, collection *entity.Collection) error {
+	collectionUUID, err := uuid.Parse(collection.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	customerUUID, err := uuid.Parse(collection.CustomerId)
+	if err != nil {
+		return fmt.Errorf("error while parsing customer uuid: %s", err)
+	}
+
+	err = c.Queries.InsertFavoriteCollection(ctx, database.InsertFavoriteCollectionParams{
+		ID:         collectionUUID,
+		CustomerID: customerUUID,
+		Name:       collection.Name,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrCollectionNameTaken
+		}
+
+		return fmt.Errorf("error while inserting collection: %s", err)
+	}
+
+	return nil
+}
+
+func (c *CollectionRepositoryImpl) Update(ctx context.Context, collection *entity.Collection) error {
+	collectionUUID, err := uuid.Parse(collection.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	err = c.Queries.UpdateFavoriteCollection(ctx, database.UpdateFavoriteCollectionParams{
+		Name: collection.Name,
+		ID:   collectionUUID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrCollectionNameTaken
+		}
+
+		return fmt.Errorf("error while updating collection: %s", err)
+	}
+
+	return nil
+}
+
+func (c *CollectionRepositoryImpl) Delete(ctx context.Context, collection *entity.Collection) error {
+	collectionUUID, err := uuid.Parse(collection.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	if err := c.Queries.DeleteFavoriteCollection(ctx, collectionUUID); err != nil {
+		return fmt.Errorf("error while deleting collection: %s", err)
+	}
+
+	return nil
+}
+
+func (c *CollectionRepositoryImpl) FindFavorites(ctx context.Context, collection *entity.Collection) ([]*entity.Favorite, error) {
+	collectionUUID, err := uuid.Parse(collection.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	favorites, err := c.Queries.FindFavoritesByCollection(ctx, collectionUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting collection favorites: %s", err)
+	}
+
+	return toFavoriteEntities(favorites), nil
+}
+
+func (c *CollectionRepositoryImpl) AddFavorite(ctx context.Context, collection *entity.Collection, favorite *entity.Favorite, limit int) error {
+	collectionUUID, err := uuid.Parse(collection.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	customerUUID, err := uuid.Parse(collection.CustomerId)
+	if err != nil {
+		return fmt.Errorf("error while parsing customer uuid: %s", err)
+	}
+
+	err = insertFavorite(ctx, c.DB, c.Queries, database.InsertFavoriteCustomerProductParams{
+		CollectionID: collectionUUID,
+		CustomerID:   customerUUID,
+		ProductID:    favorite.ProductId,
+		Title:        favorite.Title,
+		Image:        favorite.Image,
+		Price:        favorite.Price,
+		Note:         favorite.Note,
+		Quantity:     int32(favorite.Quantity),
+		Priority:     int16(favorite.Priority),
+	}, limit)
+	if err != nil {
+		if errors.Is(err, repository.ErrFavoritesLimitReached) {
+			return err
+		}
+
+		if isUniqueViolation(err) {
+			return repository.ErrFavoriteAlreadyInCollection
+		}
+
+		if err := favoriteParentNotFound(err); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("error while inserting favorite product: %s", err)
+	}
+
+	return nil
+}
+
+func (c *CollectionRepositoryImpl) RemoveFavorite(ctx context.Context, collection *entity.Collection, productId int64) (bool, error) {
+	collectionUUID, err := uuid.Parse(collection.Id)
+	if err != nil {
+		return false, fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	rows, err := c.Queries.DeleteFavoriteFromCollection(ctx, database.DeleteFavoriteFromCollectionParams{
+		CollectionID: collectionUUID,
+		ProductID:    productId,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error while deleting favorite product: %s", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (c *CollectionRepositoryImpl) MoveFavorite(ctx context.Context, source, target *entity.Collection, productId int64) (bool, error) {
+	sourceUUID, targetUUID, err := parseCollectionPair(source, target)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := c.Queries.MoveFavoriteToCollection(ctx, database.MoveFavoriteToCollectionParams{
+		TargetID:  targetUUID,
+		SourceID:  sourceUUID,
+		ProductID: productId,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, repository.ErrFavoriteAlreadyInCollection
+		}
+
+		return false, fmt.Errorf("error while moving favorite product: %s", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (c *CollectionRepositoryImpl) CopyFavorite(ctx context.Context, source, target *entity.Collection, productId int64) (bool, error) {
+	sourceUUID, targetUUID, err := parseCollectionPair(source, target)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := c.Queries.CopyFavoriteToCollection(ctx, database.CopyFavoriteToCollectionParams{
+		TargetID:  targetUUID,
+		SourceID:  sourceUUID,
+		ProductID: productId,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return false, repository.ErrFavoriteAlreadyInCollection
+		}
+
+		return false, fmt.Errorf("error while copying favorite product: %s", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ensureDefaultCollection returns the customer's default collection, creating
+// it when the customer has none yet.
+func ensureDefaultCollection(ctx context.Context, queries *database.Queries, customerUUID uuid.UUID) (database.FavoriteCollection, error) {
+	collection, err := queries.EnsureDefaultFavoriteCollection(ctx, database.EnsureDefaultFavoriteCollectionParams{
+		ID:         uuid.Must(uuid.NewV7()),
+		CustomerID: customerUUID,
+		Name:       entity.DefaultCollectionName,
+	})
+	if err != nil {
+		return collection, fmt.Errorf("error while getting default collection: %s", err)
+	}
+
+	return collection, nil
+}
+
+func parseCollectionPair(source, target *entity.Collection) (uuid.UUID, uuid.UUID, error) {
+	sourceUUID, err := uuid.Parse(source.Id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	targetUUID, err := uuid.Parse(target.Id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("error while parsing collection uuid: %s", err)
+	}
+
+	return sourceUUID, targetUUID, nil
+}
+
+func toCollectionEntity(collection database.FavoriteCollection) (*entity.Collection, error) {
+	collectionEntity, err := entity.NewCollectionWithId(collection.ID.String(), collection.CustomerID.String(), collection.Name, collection.IsDefault)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing entity: %s", err)
+	}
+
+	return collectionEntity, nil
+}
@@ -2,12 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
-	"github.com/lib/pq"
 )
 
 type CustomerRepositoryImpl struct {
@@ -20,8 +21,7 @@ func NewCustomerRepository(queries *database.Queries) *CustomerRepositoryImpl {
 	}
 }
 
-func (c *CustomerRepositoryImpl) FindById(id string) (*entity.Customer, error) {
-	ctx := context.Background()
+func (c *CustomerRepositoryImpl) FindById(ctx context.Context, id string) (*entity.Customer, error) {
 	customerUUID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, nil
@@ -29,23 +29,30 @@ func (c *CustomerRepositoryImpl) FindById(id string) (*entity.Customer, error) {
 
 	customer, err := c.Queries.FindCustomerById(ctx, customerUUID)
 	if err != nil {
-		if err.Error() == "sql: no rows in result set" {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 
 		return nil, err
 	}
 
-	customerEntity, err := entity.NewCustomerWithId(customer.ID.String(), customer.Name, customer.Email)
+	return toCustomerEntity(customer)
+}
+
+func (c *CustomerRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entity.Customer, error) {
+	customer, err := c.Queries.FindCustomerByEmail(ctx, email)
 	if err != nil {
-		return nil, fmt.Errorf("error while parsing entity: %s", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
 	}
 
-	return customerEntity, nil
+	return toCustomerEntity(customer)
 }
 
-func (c *CustomerRepositoryImpl) Create(customer *entity.Customer) (*entity.Customer, error) {
-	ctx := context.Background()
+func (c *CustomerRepositoryImpl) Create(ctx context.Context, customer *entity.Customer) (*entity.Customer, error) {
 	customerUUID, err := uuid.NewV7()
 	if err != nil {
 		return nil, fmt.Errorf("error while creating new uuid: %s", err)
@@ -57,10 +64,8 @@ func (c *CustomerRepositoryImpl) Create(customer *entity.Customer) (*entity.Cust
 		Email: customer.Email,
 	})
 	if err != nil {
-		if pgErr, ok := err.(*pq.Error); ok {
-			if pgErr.Code == "23505" {
-				return nil, fmt.Errorf("customer with email %s already exists", customer.Email)
-			}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("customer with email %s already exists", customer.Email)
 		}
 
 		return nil, fmt.Errorf("error while inserting customer: %s", err)
@@ -69,22 +74,119 @@ func (c *CustomerRepositoryImpl) Create(customer *entity.Customer) (*entity.Cust
 	return customer, nil
 }
 
-func (c *CustomerRepositoryImpl) Update(customer *entity.Customer) error {
-	ctx := context.Background()
+func (c *CustomerRepositoryImpl) CreateMany(ctx context.Context, customers []*entity.Customer) ([]string, error) {
+	params := database.InsertCustomersParams{
+		Ids:    make([]uuid.UUID, len(customers)),
+		Names:  make([]string, len(customers)),
+		Emails: make([]string, len(customers)),
+	}
+
+	for i, customer := range customers {
+		customerUUID, err := uuid.Parse(customer.Id)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing customer uuid: %s", err)
+		}
+
+		params.Ids[i] = customerUUID
+		params.Names[i] = customer.Name
+		params.Emails[i] = customer.Email
+	}
+
+	// A single statement, so the batch is committed or rolled back as a whole.
+	inserted, err := c.Queries.InsertCustomers(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("error while inserting customers: %s", err)
+	}
+
+	created := make(map[string]bool, len(inserted))
+	for _, email := range inserted {
+		created[email] = true
+	}
+
+	var skipped []string
+	for _, customer := range customers {
+		if !created[customer.Email] {
+			skipped = append(skipped, customer.Email)
+		}
+	}
+
+	return skipped, nil
+}
+
+func (c *CustomerRepositoryImpl) FindPageWithFavoriteIds(ctx context.Context, afterId string, limit int) ([]*entity.CustomerExport, error) {
+	afterUUID := uuid.Nil
+	if afterId != "" {
+		var err error
+		if afterUUID, err = uuid.Parse(afterId); err != nil {
+			return nil, fmt.Errorf("error while parsing customer uuid: %s", err)
+		}
+	}
+
+	rows, err := c.Queries.FindCustomersWithFavoriteIds(ctx, database.FindCustomersWithFavoriteIdsParams{
+		AfterID:  afterUUID,
+		PageSize: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while getting customers: %s", err)
+	}
+
+	exports := make([]*entity.CustomerExport, len(rows))
+	for i, row := range rows {
+		exports[i] = &entity.CustomerExport{
+			Customer: &entity.Customer{
+				Id:    row.ID.String(),
+				Name:  row.Name,
+				Email: row.Email,
+			},
+			FavoriteIds: row.FavoriteIds,
+		}
+	}
+
+	return exports, nil
+}
+
+func (c *CustomerRepositoryImpl) Update(ctx context.Context, customer *entity.Customer) error {
 	customerUUID, err := uuid.Parse(customer.Id)
 	if err != nil {
 		return fmt.Errorf("error while parsing customer uuid: %s", err)
 	}
 
-	return c.Queries.UpdateCustomer(ctx, database.UpdateCustomerParams{
+	err = c.Queries.UpdateCustomer(ctx, database.UpdateCustomerParams{
 		Name:  customer.Name,
 		Email: customer.Email,
 		ID:    customerUUID,
 	})
+	if isUniqueViolation(err) {
+		return fmt.Errorf("customer with email %s already exists", customer.Email)
+	}
+
+	return err
 }
 
-func (c *CustomerRepositoryImpl) Delete(customer *entity.Customer) error {
-	ctx := context.Background()
+func (c *CustomerRepositoryImpl) UpdateFavoritesQuota(ctx context.Context, customer *entity.Customer) error {
+	customerUUID, err := uuid.Parse(customer.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing customer uuid: %s", err)
+	}
+
+	var quota *int32
+	if customer.FavoritesQuota != nil {
+		value := int32(*customer.FavoritesQuota)
+		quota = &value
+	}
+
+	err = c.Queries.UpdateCustomerFavoritesQuota(ctx, database.UpdateCustomerFavoritesQuotaParams{
+		FavoritesQuota: quota,
+		ID:             customerUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("error while updating customer favorites quota: %s", err)
+	}
+
+	return nil
+}
+
+func (c *CustomerRepositoryImpl) Delete(ctx context.Context, customer *entity.Customer) error {
 	customerUUID, err := uuid.Parse(customer.Id)
 	if err != nil {
 		uuid.Parse(customer.Id)
@@ -92,3 +194,17 @@ func (c *CustomerRepositoryImpl) Delete(customer *entity.Customer) error {
 
 	return c.Queries.DeleteCustomer(ctx, customerUUID)
 }
+
+func toCustomerEntity(customer database.Customer) (*entity.Customer, error) {
+	customerEntity, err := entity.NewCustomerWithId(customer.ID.String(), customer.Name, customer.Email)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing entity: %s", err)
+	}
+
+	if customer.FavoritesQuota != nil {
+		quota := int(*customer.FavoritesQuota)
+		customerEntity.FavoritesQuota = &quota
+	}
+
+	return customerEntity, nil
+}
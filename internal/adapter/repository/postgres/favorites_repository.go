@@ -2,60 +2,78 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
-	"github.com/lib/pq"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
 )
 
 type FavoritesRepositoryImpl struct {
+	DB      *pgxpool.Pool
 	Queries *database.Queries
 }
 
-func NewFavoritesRepository(queries *database.Queries) *FavoritesRepositoryImpl {
+func NewFavoritesRepository(db *pgxpool.Pool, queries *database.Queries) *FavoritesRepositoryImpl {
 	return &FavoritesRepositoryImpl{
+		DB:      db,
 		Queries: queries,
 	}
 }
 
-func (f *FavoritesRepositoryImpl) FindAllByCustomer(customer *entity.Customer) ([]*int64, error) {
-	var productsIds []*int64
-	ctx := context.Background()
+func (f *FavoritesRepositoryImpl) FindAllByCustomer(ctx context.Context, customer *entity.Customer) ([]*entity.Favorite, error) {
+	var favoriteEntities []*entity.Favorite
 
 	customerUUID, err := uuid.Parse(customer.Id)
 	if err != nil {
-		return productsIds, nil
+		return favoriteEntities, nil
 	}
 
 	favorites, err := f.Queries.FindAllFavoriteProdutsFromCustomer(ctx, customerUUID)
 
 	if err != nil {
-		return productsIds, fmt.Errorf("error while getting customer favorites: %s", err)
+		return favoriteEntities, fmt.Errorf("error while getting customer favorites: %s", err)
 	}
 
-	for _, favorite := range favorites {
-		productsIds = append(productsIds, &favorite.ProductID)
-	}
-
-	return productsIds, nil
+	return toFavoriteEntities(favorites), nil
 }
 
-func (f *FavoritesRepositoryImpl) AddToCustomer(customer *entity.Customer, productId *int64) error {
-	ctx := context.Background()
+// AddToCustomer adds the favorite to the customer's default collection.
+func (f *FavoritesRepositoryImpl) AddToCustomer(ctx context.Context, customer *entity.Customer, favorite *entity.Favorite, limit int) error {
 	customerUUID, _ := uuid.Parse(customer.Id)
 
-	err := f.Queries.InsertFavoriteCustomerProduct(ctx, database.InsertFavoriteCustomerProductParams{
-		CustomerID: customerUUID,
-		ProductID:  *productId,
-	})
+	collection, err := ensureDefaultCollection(ctx, f.Queries, customerUUID)
+	if err != nil {
+		return err
+	}
+
+	err = insertFavorite(ctx, f.DB, f.Queries, database.InsertFavoriteCustomerProductParams{
+		CollectionID: collection.ID,
+		CustomerID:   customerUUID,
+		ProductID:    favorite.ProductId,
+		Title:        favorite.Title,
+		Image:        favorite.Image,
+		Price:        favorite.Price,
+		Note:         favorite.Note,
+		Quantity:     int32(favorite.Quantity),
+		Priority:     int16(favorite.Priority),
+	}, limit)
 
 	if err != nil {
-		if pgErr, ok := err.(*pq.Error); ok {
-			if pgErr.Code == "23505" {
-				return fmt.Errorf("product already in favorites")
-			}
+		if errors.Is(err, repository.ErrFavoritesLimitReached) {
+			return err
+		}
+
+		if isUniqueViolation(err) {
+			return fmt.Errorf("product already in favorites")
+		}
+
+		if err := favoriteParentNotFound(err); err != nil {
+			return err
 		}
 
 		return fmt.Errorf("error while inserting favorite product: %s", err)
@@ -64,18 +82,206 @@ func (f *FavoritesRepositoryImpl) AddToCustomer(customer *entity.Customer, produ
 	return nil
 }
 
-func (f *FavoritesRepositoryImpl) RemoveFromCustomer(customer *entity.Customer, productId *int64) error {
-	ctx := context.Background()
+// UpdateForCustomer updates the favorite in the customer's default collection.
+func (f *FavoritesRepositoryImpl) UpdateForCustomer(ctx context.Context, customer *entity.Customer, favorite *entity.Favorite) (bool, error) {
 	customerUUID, _ := uuid.Parse(customer.Id)
 
-	err := f.Queries.DeleteFavoriteCustomerProduct(ctx, database.DeleteFavoriteCustomerProductParams{
+	rows, err := f.Queries.UpdateFavoriteCustomerProduct(ctx, database.UpdateFavoriteCustomerProductParams{
+		CustomerID: customerUUID,
+		ProductID:  favorite.ProductId,
+		Note:       favorite.Note,
+		Quantity:   int32(favorite.Quantity),
+		Priority:   int16(favorite.Priority),
+	})
+	if err != nil {
+		return false, fmt.Errorf("error while updating favorite product: %s", err)
+	}
+
+	return rows > 0, nil
+}
+
+// RemoveFromCustomer removes the product from the customer's default collection.
+func (f *FavoritesRepositoryImpl) RemoveFromCustomer(ctx context.Context, customer *entity.Customer, productId *int64) (bool, error) {
+	customerUUID, _ := uuid.Parse(customer.Id)
+
+	rows, err := f.Queries.DeleteFavoriteCustomerProduct(ctx, database.DeleteFavoriteCustomerProductParams{
 		CustomerID: customerUUID,
 		ProductID:  *productId,
 	})
 
 	if err != nil {
-		return fmt.Errorf("error while deleting favorite product: %s", err)
+		return false, fmt.Errorf("error while deleting favorite product: %s", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (f *FavoritesRepositoryImpl) CountByCustomer(ctx context.Context, customer *entity.Customer) (int64, error) {
+	customerUUID, err := uuid.Parse(customer.Id)
+	if err != nil {
+		return 0, nil
+	}
+
+	count, err := f.Queries.CountFavoriteProductsByCustomer(ctx, customerUUID)
+	if err != nil {
+		return 0, fmt.Errorf("error while counting customer favorites: %s", err)
+	}
+
+	return count, nil
+}
+
+func (f *FavoritesRepositoryImpl) CountByProduct(ctx context.Context) ([]*entity.FavoritedProduct, error) {
+	rows, err := f.Queries.CountFavoritesByProduct(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while counting favorites: %s", err)
+	}
+
+	favoritedProducts := make([]*entity.FavoritedProduct, len(rows))
+	for i, row := range rows {
+		favoritedProducts[i] = &entity.FavoritedProduct{
+			ProductId: row.ProductID,
+			Favorites: row.Favorites,
+		}
+	}
+
+	return favoritedProducts, nil
+}
+
+func (f *FavoritesRepositoryImpl) CountByProductIds(ctx context.Context, productIds []int64) (map[int64]int64, error) {
+	rows, err := f.Queries.CountFavoritesByProductIds(ctx, productIds)
+	if err != nil {
+		return nil, fmt.Errorf("error while counting favorites: %s", err)
+	}
+
+	counts := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ProductID] = row.Favorites
+	}
+
+	return counts, nil
+}
+
+func (f *FavoritesRepositoryImpl) CountBySince(ctx context.Context, since time.Time, limit, offset int) ([]*entity.FavoritedProduct, error) {
+	rows, err := f.Queries.CountFavoritesByProductSince(ctx, database.CountFavoritesByProductSinceParams{
+		Since:      since,
+		PageSize:   int32(limit),
+		PageOffset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while counting favorites: %s", err)
+	}
+
+	favoritedProducts := make([]*entity.FavoritedProduct, len(rows))
+	for i, row := range rows {
+		favoritedProducts[i] = &entity.FavoritedProduct{
+			ProductId: row.ProductID,
+			Favorites: row.Favorites,
+		}
+	}
+
+	return favoritedProducts, nil
+}
+
+func (f *FavoritesRepositoryImpl) FindCustomerIdsByProduct(ctx context.Context, productId int64) ([]string, error) {
+	customerUUIDs, err := f.Queries.FindCustomerIdsByFavoriteProduct(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting customers who favorited product: %s", err)
+	}
+
+	customerIds := make([]string, len(customerUUIDs))
+	for i, customerUUID := range customerUUIDs {
+		customerIds[i] = customerUUID.String()
+	}
+
+	return customerIds, nil
+}
+
+func (f *FavoritesRepositoryImpl) RemoveByProducts(ctx context.Context, productIds []int64) (int64, error) {
+	rows, err := f.Queries.DeleteFavoritesByProducts(ctx, productIds)
+	if err != nil {
+		return 0, fmt.Errorf("error while deleting favorites: %s", err)
+	}
+
+	return rows, nil
+}
+
+// favoriteParentNotFound tells apart the customer and the collection being
+// deleted after the use case found them, by the foreign key the insert broke.
+func favoriteParentNotFound(err error) error {
+	switch foreignKeyViolation(err) {
+	case "fk_favorites_customer":
+		return fmt.Errorf("customer not found")
+	case "fk_favorites_collection":
+		return fmt.Errorf("collection not found")
+	default:
+		return nil
+	}
+}
+
+// insertFavorite inserts the favorite unless it is a new product for a customer
+// who already favorited limit products. The customer row stays locked until the
+// insert commits, so concurrent requests cannot both take the last slot.
+// Insert errors are returned as is for the caller to translate.
+func insertFavorite(ctx context.Context, db *pgxpool.Pool, queries *database.Queries, params database.InsertFavoriteCustomerProductParams, limit int) error {
+	if limit <= 0 {
+		return queries.InsertFavoriteCustomerProduct(ctx, params)
+	}
+
+	tx, txQueries, err := begin(ctx, db)
+	if err != nil {
+		return fmt.Errorf("error while starting favorite insert: %s", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = txQueries.LockCustomerFavorites(ctx, params.CustomerID); err != nil {
+		return fmt.Errorf("error while locking customer favorites: %s", err)
+	}
+
+	favorited, err := txQueries.IsProductFavoritedByCustomer(ctx, database.IsProductFavoritedByCustomerParams{
+		CustomerID: params.CustomerID,
+		ProductID:  params.ProductID,
+	})
+	if err != nil {
+		return fmt.Errorf("error while checking customer favorite: %s", err)
+	}
+
+	// A product already favorited in another collection does not take a new slot.
+	if !favorited {
+		count, err := txQueries.CountFavoriteProductsByCustomer(ctx, params.CustomerID)
+		if err != nil {
+			return fmt.Errorf("error while counting customer favorites: %s", err)
+		}
+
+		if count >= int64(limit) {
+			return repository.ErrFavoritesLimitReached
+		}
+	}
+
+	if err = txQueries.InsertFavoriteCustomerProduct(ctx, params); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error while committing favorite insert: %s", err)
 	}
 
 	return nil
 }
+
+func toFavoriteEntities(favorites []database.Favorite) []*entity.Favorite {
+	var favoriteEntities []*entity.Favorite
+	for _, favorite := range favorites {
+		favoriteEntities = append(favoriteEntities, &entity.Favorite{
+			ProductId: favorite.ProductID,
+			Title:     favorite.Title,
+			Image:     favorite.Image,
+			Price:     favorite.Price,
+			CreatedAt: timeOrZero(favorite.CreatedAt),
+			Note:      favorite.Note,
+			Quantity:  int(favorite.Quantity),
+			Priority:  entity.FavoritePriority(favorite.Priority),
+		})
+	}
+
+	return favoriteEntities
+}
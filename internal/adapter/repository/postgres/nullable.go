@@ -0,0 +1,22 @@
+package repository
+
+import "time"
+
+// timeOrZero reads a nullable timestamp column, such as the created_at columns
+// filled by their default.
+func timeOrZero(value *time.Time) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+
+	return *value
+}
+
+// stringOrNull maps an empty string to NULL, for optional filters.
+func stringOrNull(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
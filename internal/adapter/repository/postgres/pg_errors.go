@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func hasErrorCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+func isUniqueViolation(err error) bool {
+	return hasErrorCode(err, pgerrcode.UniqueViolation)
+}
+
+// foreignKeyViolation returns the constraint a foreign key violation broke,
+// or an empty string for any other error.
+func foreignKeyViolation(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return pgErr.ConstraintName
+	}
+
+	return ""
+}
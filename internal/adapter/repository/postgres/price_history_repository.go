@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// priceTrackingLockKey keeps two replicas from recording the same price
+// change twice.
+const priceTrackingLockKey = 4_210_038
+
+type PriceHistoryRepositoryImpl struct {
+	DB      *pgxpool.Pool
+	Queries *database.Queries
+}
+
+func NewPriceHistoryRepository(db *pgxpool.Pool, queries *database.Queries) *PriceHistoryRepositoryImpl {
+	return &PriceHistoryRepositoryImpl{
+		DB:      db,
+		Queries: queries,
+	}
+}
+
+func (p *PriceHistoryRepositoryImpl) FindLatest(ctx context.Context) (map[int64]float64, error) {
+	rows, err := p.Queries.FindLatestProductPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting latest prices: %s", err)
+	}
+
+	latest := make(map[int64]float64, len(rows))
+	for _, row := range rows {
+		latest[row.ProductID] = row.Price
+	}
+
+	return latest, nil
+}
+
+// Record checks the points against the latest prices again under the lock,
+// since another replica may have recorded the same change since they were
+// computed.
+func (p *PriceHistoryRepositoryImpl) Record(ctx context.Context, points []*entity.PricePoint) ([]*entity.PriceChange, error) {
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	tx, queries, err := begin(ctx, p.DB)
+	if err != nil {
+		return nil, fmt.Errorf("error while starting price recording: %s", err)
+	}
+	defer tx.Rollback(ctx)
+
+	locked, err := queries.LockPriceTracking(ctx, priceTrackingLockKey)
+	if err != nil {
+		return nil, fmt.Errorf("error while locking price tracking: %s", err)
+	}
+
+	if !locked {
+		return nil, repository.ErrPriceTrackingInProgress
+	}
+
+	rows, err := queries.FindLatestProductPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting latest prices: %s", err)
+	}
+
+	latest := make(map[int64]float64, len(rows))
+	for _, row := range rows {
+		latest[row.ProductID] = row.Price
+	}
+
+	var recorded []*entity.PriceChange
+	params := database.InsertProductPricesParams{}
+	for _, point := range points {
+		change := &entity.PriceChange{Point: point}
+		if price, tracked := latest[point.ProductId]; tracked {
+			if entity.SamePrice(price, point.Price) {
+				continue
+			}
+			change.Previous = &price
+		}
+
+		recorded = append(recorded, change)
+		params.ProductIds = append(params.ProductIds, point.ProductId)
+		params.Prices = append(params.Prices, point.Price)
+		params.RecordedAts = append(params.RecordedAts, point.RecordedAt)
+	}
+
+	if len(recorded) == 0 {
+		return nil, nil
+	}
+
+	if err = queries.InsertProductPrices(ctx, params); err != nil {
+		return nil, fmt.Errorf("error while recording prices: %s", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error while committing price recording: %s", err)
+	}
+
+	return recorded, nil
+}
+
+func (p *PriceHistoryRepositoryImpl) FindByProduct(ctx context.Context, productId int64) ([]*entity.PricePoint, error) {
+	rows, err := p.Queries.FindProductPriceHistory(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting price history: %s", err)
+	}
+
+	points := make([]*entity.PricePoint, len(rows))
+	for i, row := range rows {
+		points[i] = &entity.PricePoint{
+			ProductId:  row.ProductID,
+			Price:      row.Price,
+			RecordedAt: row.RecordedAt,
+		}
+	}
+
+	return points, nil
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type ProductRepositoryImpl struct {
+	Queries *database.Queries
+}
+
+func NewProductRepository(queries *database.Queries) *ProductRepositoryImpl {
+	return &ProductRepositoryImpl{
+		Queries: queries,
+	}
+}
+
+func (p *ProductRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	products, err := p.Queries.FindAllProducts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting products: %s", err)
+	}
+
+	return toProductEntities(products)
+}
+
+func (p *ProductRepositoryImpl) FindByCategory(ctx context.Context, category string) ([]*entity.Product, error) {
+	products, err := p.Queries.FindProductsByCategory(ctx, category)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting products by category: %s", err)
+	}
+
+	return toProductEntities(products)
+}
+
+func (p *ProductRepositoryImpl) FindCategories(ctx context.Context) ([]string, error) {
+	categories, err := p.Queries.FindProductCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting product categories: %s", err)
+	}
+
+	return categories, nil
+}
+
+func (p *ProductRepositoryImpl) FindById(ctx context.Context, id int64) (*entity.Product, error) {
+	product, err := p.Queries.FindProductById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrProductNotFound
+		}
+
+		return nil, fmt.Errorf("error while getting product: %s", err)
+	}
+
+	return toProductEntity(product)
+}
+
+func (p *ProductRepositoryImpl) FindByIds(ctx context.Context, ids []int64) (map[int64]*entity.Product, error) {
+	products, err := p.Queries.FindProductsByIds(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting products by id: %s", err)
+	}
+
+	found := make(map[int64]*entity.Product, len(products))
+	for _, product := range products {
+		productEntity, err := toProductEntity(product)
+		if err != nil {
+			return nil, err
+		}
+		found[productEntity.Id] = productEntity
+	}
+
+	return found, nil
+}
+
+// Search filters, sorts and pages the catalog in SQL, returning the page and
+// how many products matched.
+func (p *ProductRepositoryImpl) Search(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int, error) {
+	products, err := p.Queries.SearchProducts(ctx, database.SearchProductsParams{
+		Category:   stringOrNull(filter.Category),
+		Search:     stringOrNull(filter.Search),
+		MinPrice:   filter.MinPrice,
+		MaxPrice:   filter.MaxPrice,
+		MinRating:  filter.MinRating,
+		MaxRating:  filter.MaxRating,
+		SortField:  filter.SortField,
+		SortDesc:   filter.SortDesc,
+		PageSize:   int32(filter.Limit),
+		PageOffset: int32(filter.Offset),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error while searching products: %s", err)
+	}
+
+	total, err := p.Queries.CountSearchedProducts(ctx, database.CountSearchedProductsParams{
+		Category:  stringOrNull(filter.Category),
+		Search:    stringOrNull(filter.Search),
+		MinPrice:  filter.MinPrice,
+		MaxPrice:  filter.MaxPrice,
+		MinRating: filter.MinRating,
+		MaxRating: filter.MaxRating,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error while counting products: %s", err)
+	}
+
+	productEntities, err := toProductEntities(products)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return productEntities, int(total), nil
+}
+
+func (p *ProductRepositoryImpl) Create(ctx context.Context, product *entity.Product) (*entity.Product, error) {
+	id, err := p.Queries.InsertProduct(ctx, database.InsertProductParams{
+		Title:     product.Title,
+		Image:     product.Image,
+		Price:     product.Price,
+		Rate:      product.Rate,
+		RateCount: product.RateCount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while inserting product: %s", err)
+	}
+
+	product.Id = id
+	return product, nil
+}
+
+func (p *ProductRepositoryImpl) Update(ctx context.Context, product *entity.Product) error {
+	rows, err := p.Queries.UpdateProduct(ctx, database.UpdateProductParams{
+		Title:       product.Title,
+		Description: product.Description,
+		Category:    product.Category,
+		Image:       product.Image,
+		Price:       product.Price,
+		Rate:        product.Rate,
+		RateCount:   product.RateCount,
+		ID:          product.Id,
+	})
+	if err != nil {
+		return fmt.Errorf("error while updating product: %s", err)
+	}
+
+	if rows == 0 {
+		return repository.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (p *ProductRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	rows, err := p.Queries.DeleteProduct(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error while deleting product: %s", err)
+	}
+
+	if rows == 0 {
+		return repository.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func toProductEntities(products []database.Product) ([]*entity.Product, error) {
+	var productEntities []*entity.Product
+	for _, product := range products {
+		productEntity, err := toProductEntity(product)
+		if err != nil {
+			return nil, err
+		}
+		productEntities = append(productEntities, productEntity)
+	}
+
+	return productEntities, nil
+}
+
+func toProductEntity(product database.Product) (*entity.Product, error) {
+	productEntity, err := entity.NewProduct(product.ID, product.Title, product.Description, product.Category, product.Image, product.Price, product.Rate, product.RateCount)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing entity: %s", err)
+	}
+
+	return productEntity, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// recommendationsRefreshLockKey keeps two replicas from rebuilding the
+// co-favorite pairs at the same time.
+const recommendationsRefreshLockKey = 4_210_036
+
+type RecommendationRepositoryImpl struct {
+	DB      *pgxpool.Pool
+	Queries *database.Queries
+}
+
+func NewRecommendationRepository(db *pgxpool.Pool, queries *database.Queries) *RecommendationRepositoryImpl {
+	return &RecommendationRepositoryImpl{
+		DB:      db,
+		Queries: queries,
+	}
+}
+
+// Refresh swaps the pairs in a single transaction, so readers keep seeing the
+// previous ones until the new ones are committed.
+func (r *RecommendationRepositoryImpl) Refresh(ctx context.Context) (int64, error) {
+	tx, queries, err := begin(ctx, r.DB)
+	if err != nil {
+		return 0, fmt.Errorf("error while starting recommendations refresh: %s", err)
+	}
+	defer tx.Rollback(ctx)
+
+	locked, err := queries.LockProductCoFavoritesRefresh(ctx, recommendationsRefreshLockKey)
+	if err != nil {
+		return 0, fmt.Errorf("error while locking recommendations refresh: %s", err)
+	}
+
+	if !locked {
+		return 0, repository.ErrRecommendationsRefreshInProgress
+	}
+
+	if err = queries.DeleteProductCoFavorites(ctx); err != nil {
+		return 0, fmt.Errorf("error while deleting co-favorites: %s", err)
+	}
+
+	pairs, err := queries.InsertProductCoFavorites(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error while inserting co-favorites: %s", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("error while committing recommendations refresh: %s", err)
+	}
+
+	return pairs, nil
+}
+
+func (r *RecommendationRepositoryImpl) FindCoFavorited(ctx context.Context, customer *entity.Customer) ([]*entity.FavoritedProduct, error) {
+	var coFavorited []*entity.FavoritedProduct
+
+	customerUUID, err := uuid.Parse(customer.Id)
+	if err != nil {
+		return coFavorited, nil
+	}
+
+	rows, err := r.Queries.FindCoFavoritedProductsForCustomer(ctx, customerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting co-favorited products: %s", err)
+	}
+
+	for _, row := range rows {
+		coFavorited = append(coFavorited, &entity.FavoritedProduct{ProductId: row.RelatedProductID, Favorites: row.Customers})
+	}
+
+	return coFavorited, nil
+}
+
+func (r *RecommendationRepositoryImpl) FindFavoritedProductIds(ctx context.Context, customer *entity.Customer) ([]int64, error) {
+	customerUUID, err := uuid.Parse(customer.Id)
+	if err != nil {
+		return nil, nil
+	}
+
+	productIds, err := r.Queries.FindFavoriteProductIdsByCustomer(ctx, customerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting customer favorite product ids: %s", err)
+	}
+
+	return productIds, nil
+}
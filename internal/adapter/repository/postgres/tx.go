@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/tracing"
+)
+
+// begin starts a transaction and returns the queries that run in it. They are
+// traced like the ones outside transactions, which Queries.WithTx would skip
+// by handing sqlc the bare transaction.
+func begin(ctx context.Context, db *pgxpool.Pool) (pgx.Tx, *database.Queries, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, database.New(tracing.NewDB(tx, "postgresql")), nil
+}
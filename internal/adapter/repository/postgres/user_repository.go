@@ -2,11 +2,11 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
 )
@@ -21,11 +21,10 @@ func NewUserRepository(queries *database.Queries) *UserRepositoryImpl {
 	}
 }
 
-func (u *UserRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
-	ctx := context.Background()
+func (u *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	user, err := u.Queries.FindUserByEmail(ctx, email)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 
@@ -40,8 +39,7 @@ func (u *UserRepositoryImpl) FindByEmail(email string) (*entity.User, error) {
 	return userEntity, nil
 }
 
-func (u *UserRepositoryImpl) FindByID(id string) (*entity.User, error) {
-	ctx := context.Background()
+func (u *UserRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.User, error) {
 
 	userUUID, err := uuid.Parse(id)
 	if err != nil {
@@ -50,7 +48,7 @@ func (u *UserRepositoryImpl) FindByID(id string) (*entity.User, error) {
 
 	user, err := u.Queries.FindUserById(ctx, userUUID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 
@@ -64,3 +62,26 @@ func (u *UserRepositoryImpl) FindByID(id string) (*entity.User, error) {
 
 	return userEntity, nil
 }
+
+func (u *UserRepositoryImpl) Create(ctx context.Context, user *entity.User) error {
+	userUUID, err := uuid.Parse(user.Id)
+	if err != nil {
+		return fmt.Errorf("invalid user ID format: %s", err.Error())
+	}
+
+	err = u.Queries.InsertUser(ctx, database.InsertUserParams{
+		ID:       userUUID,
+		Name:     user.Name,
+		Email:    user.Email,
+		Password: user.Password,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user with email %s already exists", user.Email)
+		}
+
+		return fmt.Errorf("error while inserting user: %s", err)
+	}
+
+	return nil
+}
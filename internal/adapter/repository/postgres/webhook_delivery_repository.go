@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type WebhookDeliveryRepositoryImpl struct {
+	Queries *database.Queries
+}
+
+func NewWebhookDeliveryRepository(queries *database.Queries) *WebhookDeliveryRepositoryImpl {
+	return &WebhookDeliveryRepositoryImpl{
+		Queries: queries,
+	}
+}
+
+func (w *WebhookDeliveryRepositoryImpl) FindById(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	deliveryUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	delivery, err := w.Queries.FindWebhookDeliveryById(ctx, deliveryUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return toWebhookDeliveryEntity(delivery), nil
+}
+
+func (w *WebhookDeliveryRepositoryImpl) FindAllByWebhook(ctx context.Context, webhook *entity.Webhook) ([]*entity.WebhookDelivery, error) {
+	webhookUUID, err := uuid.Parse(webhook.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing webhook uuid: %s", err)
+	}
+
+	deliveries, err := w.Queries.FindAllWebhookDeliveriesFromWebhook(ctx, webhookUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting webhook deliveries: %s", err)
+	}
+
+	deliveryEntities := make([]*entity.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryEntities[i] = toWebhookDeliveryEntity(delivery)
+	}
+
+	return deliveryEntities, nil
+}
+
+func (w *WebhookDeliveryRepositoryImpl) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	deliveryUUID, webhookUUID, eventUUID, err := parseWebhookDeliveryIds(delivery)
+	if err != nil {
+		return err
+	}
+
+	err = w.Queries.InsertWebhookDelivery(ctx, database.InsertWebhookDeliveryParams{
+		ID:             deliveryUUID,
+		WebhookID:      webhookUUID,
+		EventID:        eventUUID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       int32(delivery.Attempts),
+		AttemptsLeft:   int32(delivery.AttemptsLeft),
+		ResponseStatus: int32(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error while inserting webhook delivery: %s", err)
+	}
+
+	return nil
+}
+
+func (w *WebhookDeliveryRepositoryImpl) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	deliveryUUID, err := uuid.Parse(delivery.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing webhook delivery uuid: %s", err)
+	}
+
+	return w.Queries.UpdateWebhookDelivery(ctx, database.UpdateWebhookDeliveryParams{
+		Status:         delivery.Status,
+		Attempts:       int32(delivery.Attempts),
+		AttemptsLeft:   int32(delivery.AttemptsLeft),
+		ResponseStatus: int32(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ID:             deliveryUUID,
+	})
+}
+
+func (w *WebhookDeliveryRepositoryImpl) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	deliveries, err := w.Queries.ClaimDueWebhookDeliveries(ctx, database.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil:    leaseUntil,
+		Now:           now,
+		MaxDeliveries: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while claiming webhook deliveries: %s", err)
+	}
+
+	deliveryEntities := make([]*entity.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryEntities[i] = toWebhookDeliveryEntity(delivery)
+	}
+
+	return deliveryEntities, nil
+}
+
+func (w *WebhookDeliveryRepositoryImpl) Requeue(ctx context.Context, delivery *entity.WebhookDelivery) (bool, error) {
+	deliveryUUID, err := uuid.Parse(delivery.Id)
+	if err != nil {
+		return false, fmt.Errorf("error while parsing webhook delivery uuid: %s", err)
+	}
+
+	var nextAttemptAt time.Time
+	if delivery.NextAttemptAt != nil {
+		nextAttemptAt = *delivery.NextAttemptAt
+	}
+
+	requeued, err := w.Queries.RequeueWebhookDelivery(ctx, database.RequeueWebhookDeliveryParams{
+		AttemptsLeft:  int32(delivery.AttemptsLeft),
+		NextAttemptAt: nextAttemptAt,
+		ID:            deliveryUUID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error while requeueing webhook delivery: %s", err)
+	}
+
+	return requeued > 0, nil
+}
+
+func parseWebhookDeliveryIds(delivery *entity.WebhookDelivery) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	deliveryUUID, err := uuid.Parse(delivery.Id)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("error while parsing webhook delivery uuid: %s", err)
+	}
+
+	webhookUUID, err := uuid.Parse(delivery.WebhookId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("error while parsing webhook uuid: %s", err)
+	}
+
+	eventUUID, err := uuid.Parse(delivery.EventId)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("error while parsing event uuid: %s", err)
+	}
+
+	return deliveryUUID, webhookUUID, eventUUID, nil
+}
+
+func toWebhookDeliveryEntity(delivery database.WebhookDelivery) *entity.WebhookDelivery {
+	deliveryEntity := &entity.WebhookDelivery{
+		Id:             delivery.ID.String(),
+		WebhookId:      delivery.WebhookID.String(),
+		EventId:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       int(delivery.Attempts),
+		AttemptsLeft:   int(delivery.AttemptsLeft),
+		ResponseStatus: int(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		CreatedAt:      timeOrZero(delivery.CreatedAt),
+		DeliveredAt:    delivery.DeliveredAt,
+		NextAttemptAt:  delivery.NextAttemptAt,
+	}
+
+	return deliveryEntity
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type WebhookRepositoryImpl struct {
+	Queries *database.Queries
+}
+
+func NewWebhookRepository(queries *database.Queries) *WebhookRepositoryImpl {
+	return &WebhookRepositoryImpl{
+		Queries: queries,
+	}
+}
+
+func (w *WebhookRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	webhooks, err := w.Queries.FindAllWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting webhooks: %s", err)
+	}
+
+	return toWebhookEntities(webhooks)
+}
+
+func (w *WebhookRepositoryImpl) FindById(ctx context.Context, id string) (*entity.Webhook, error) {
+	webhookUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	webhook, err := w.Queries.FindWebhookById(ctx, webhookUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return toWebhookEntity(webhook)
+}
+
+func (w *WebhookRepositoryImpl) FindActiveByEvent(ctx context.Context, eventType string) ([]*entity.Webhook, error) {
+	webhooks, err := w.Queries.FindActiveWebhooksByEvent(ctx, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting webhooks for event %s: %s", eventType, err)
+	}
+
+	return toWebhookEntities(webhooks)
+}
+
+func (w *WebhookRepositoryImpl) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	webhookUUID, err := uuid.Parse(webhook.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing webhook uuid: %s", err)
+	}
+
+	err = w.Queries.InsertWebhook(ctx, database.InsertWebhookParams{
+		ID:     webhookUUID,
+		Url:    webhook.Url,
+		Events: webhook.Events,
+		Secret: webhook.Secret,
+		Active: webhook.Active,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while inserting webhook: %s", err)
+	}
+
+	return webhook, nil
+}
+
+func (w *WebhookRepositoryImpl) Update(ctx context.Context, webhook *entity.Webhook) error {
+	webhookUUID, err := uuid.Parse(webhook.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing webhook uuid: %s", err)
+	}
+
+	return w.Queries.UpdateWebhook(ctx, database.UpdateWebhookParams{
+		Url:    webhook.Url,
+		Events: webhook.Events,
+		Secret: webhook.Secret,
+		Active: webhook.Active,
+		ID:     webhookUUID,
+	})
+}
+
+func (w *WebhookRepositoryImpl) Delete(ctx context.Context, webhook *entity.Webhook) error {
+	webhookUUID, err := uuid.Parse(webhook.Id)
+	if err != nil {
+		return fmt.Errorf("error while parsing webhook uuid: %s", err)
+	}
+
+	return w.Queries.DeleteWebhook(ctx, webhookUUID)
+}
+
+func toWebhookEntity(webhook database.Webhook) (*entity.Webhook, error) {
+	webhookEntity, err := entity.NewWebhookWithId(webhook.ID.String(), webhook.Url, webhook.Events, webhook.Secret, webhook.Active)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing entity: %s", err)
+	}
+
+	return webhookEntity, nil
+}
+
+func toWebhookEntities(webhooks []database.Webhook) ([]*entity.Webhook, error) {
+	webhookEntities := make([]*entity.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		webhookEntity, err := toWebhookEntity(webhook)
+		if err != nil {
+			return nil, err
+		}
+		webhookEntities = append(webhookEntities, webhookEntity)
+	}
+
+	return webhookEntities, nil
+}
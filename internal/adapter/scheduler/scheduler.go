@@ -0,0 +1,70 @@
+// Package scheduler runs background jobs on a fixed interval inside the API process.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Job func(ctx context.Context) error
+
+type Scheduler struct {
+	Name     string
+	Interval time.Duration
+	Job      Job
+	Logger   *slog.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(name string, interval time.Duration, job Job) *Scheduler {
+	return &Scheduler{
+		Name:     name,
+		Interval: interval,
+		Job:      job,
+		Logger:   slog.Default(),
+	}
+}
+
+// Start runs the job right away and then once per interval until Stop is called.
+// A run that takes longer than the interval delays the next one instead of overlapping.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		for {
+			s.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop cancels the running job and waits for it to return.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	if err := s.Job(ctx); err != nil && ctx.Err() == nil {
+		s.Logger.Error("scheduled job failed", slog.String("job", s.Name), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_RunsImmediatelyAndOnInterval(t *testing.T) {
+	var runs atomic.Int32
+	s := New("test", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	s.Start(context.Background())
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+	s.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestScheduler_KeepsRunningAfterFailure(t *testing.T) {
+	var runs atomic.Int32
+	s := New("test", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("boom")
+	})
+	s.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+}
+
+func TestScheduler_StopCancelsRunningJob(t *testing.T) {
+	started := make(chan struct{})
+	s := New("test", time.Hour, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	s.Start(context.Background())
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not cancel the running job")
+	}
+}
+
+func TestScheduler_StopWithoutStart(t *testing.T) {
+	New("test", time.Second, func(ctx context.Context) error { return nil }).Stop()
+}
@@ -0,0 +1,137 @@
+package sse
+
+import (
+	"context"
+	"sync"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+)
+
+const (
+	DefaultHistorySize = 1000
+	subscriberBuffer   = 64
+)
+
+type subscriber struct {
+	customerId string
+	events     chan *event.Event
+}
+
+// Broker keeps the most recent events in memory and fans them out to the
+// customers streams open in this instance.
+type Broker struct {
+	mu          sync.Mutex
+	history     []*event.Event
+	historySize int
+	subscribers map[*subscriber]struct{}
+}
+
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		historySize: historySize,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+func (b *Broker) Publish(_ context.Context, e *event.Event) error {
+	customerId := customerIdOf(e)
+	if customerId == "" {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.history = append(b.history, e)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for s := range b.subscribers {
+		if s.customerId != customerId {
+			continue
+		}
+
+		select {
+		case s.events <- e:
+		default:
+			// A consumer that cannot keep up is dropped; it reconnects with
+			// Last-Event-ID and resumes from the history.
+			b.remove(s)
+		}
+	}
+
+	return nil
+}
+
+func (b *Broker) Subscribe(customerId, lastEventId string) (<-chan *event.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []*event.Event
+	if lastEventId != "" {
+		found := false
+		// Event ids are UUIDv7, so their string form sorts by publication time.
+		for _, e := range b.history {
+			found = found || e.Id == lastEventId
+			if e.Id > lastEventId && customerIdOf(e) == customerId {
+				replay = append(replay, e)
+			}
+		}
+
+		// The event was trimmed from the history, or published before this
+		// instance started, so there may be a gap the history cannot fill.
+		if !found {
+			latestId := ""
+			if len(b.history) > 0 {
+				latestId = b.history[len(b.history)-1].Id
+			}
+			replay = []*event.Event{event.NewStreamResetEvent(latestId, customerId)}
+		}
+	}
+
+	s := &subscriber{
+		customerId: customerId,
+		events:     make(chan *event.Event, len(replay)+subscriberBuffer),
+	}
+	for _, e := range replay {
+		s.events <- e
+	}
+	b.subscribers[s] = struct{}{}
+
+	return s.events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(s)
+	}
+}
+
+// Close ends every open stream so the server can shut down without waiting on them.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subscribers {
+		b.remove(s)
+	}
+}
+
+func (b *Broker) remove(s *subscriber) {
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+
+	delete(b.subscribers, s)
+	close(s.events)
+}
+
+func customerIdOf(e *event.Event) string {
+	switch data := e.Data.(type) {
+	case event.FavoriteData:
+		return data.CustomerId
+	case event.PriceDropData:
+		return data.CustomerId
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,132 @@
+package sse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	customerA = "01986709-c873-7525-bd98-20457930777c"
+	customerB = "01986709-c873-7525-bd98-20457930777d"
+)
+
+func TestBroker_Subscribe_ReceivesOnlyCustomerEvents(t *testing.T) {
+	broker := NewBroker(DefaultHistorySize)
+	events, unsubscribe := broker.Subscribe(customerA, "")
+	defer unsubscribe()
+
+	added := event.NewFavoriteAddedEvent(customerA, 1)
+	require.NoError(t, broker.Publish(context.Background(), event.NewFavoriteAddedEvent(customerB, 2)))
+	require.NoError(t, broker.Publish(context.Background(), added))
+
+	received := <-events
+	assert.Equal(t, added.Id, received.Id)
+	assert.Len(t, events, 0)
+}
+
+func TestBroker_Subscribe_ReceivesPriceDrops(t *testing.T) {
+	broker := NewBroker(DefaultHistorySize)
+	events, unsubscribe := broker.Subscribe(customerA, "")
+	defer unsubscribe()
+
+	dropped := event.NewFavoritePriceDroppedEvent(customerA, 1, "Backpack", 109.95, 89.9)
+	require.NoError(t, broker.Publish(context.Background(), event.NewFavoritePriceDroppedEvent(customerB, 1, "Backpack", 109.95, 89.9)))
+	require.NoError(t, broker.Publish(context.Background(), dropped))
+
+	received := <-events
+	assert.Equal(t, dropped.Id, received.Id)
+	assert.Len(t, events, 0)
+}
+
+func TestBroker_Subscribe_ReplaysAfterLastEventId(t *testing.T) {
+	broker := NewBroker(DefaultHistorySize)
+
+	first := event.NewFavoriteAddedEvent(customerA, 1)
+	second := event.NewFavoriteAddedEvent(customerA, 2)
+	third := event.NewFavoriteRemovedEvent(customerA, 1)
+	for _, e := range []*event.Event{first, event.NewFavoriteAddedEvent(customerB, 3), second, third} {
+		require.NoError(t, broker.Publish(context.Background(), e))
+	}
+
+	events, unsubscribe := broker.Subscribe(customerA, first.Id)
+	defer unsubscribe()
+
+	require.Len(t, events, 2)
+	assert.Equal(t, second.Id, (<-events).Id)
+	assert.Equal(t, third.Id, (<-events).Id)
+}
+
+func TestBroker_Publish_TrimsHistory(t *testing.T) {
+	broker := NewBroker(2)
+
+	first := event.NewFavoriteAddedEvent(customerA, 1)
+	second := event.NewFavoriteAddedEvent(customerA, 2)
+	require.NoError(t, broker.Publish(context.Background(), first))
+	require.NoError(t, broker.Publish(context.Background(), second))
+	require.NoError(t, broker.Publish(context.Background(), event.NewFavoriteAddedEvent(customerA, 3)))
+
+	events, unsubscribe := broker.Subscribe(customerA, second.Id)
+	defer unsubscribe()
+
+	assert.Len(t, events, 1)
+}
+
+func TestBroker_Subscribe_ResetsWhenLastEventIdIsGone(t *testing.T) {
+	broker := NewBroker(2)
+
+	first := event.NewFavoriteAddedEvent(customerA, 1)
+	third := event.NewFavoriteAddedEvent(customerB, 3)
+	for _, e := range []*event.Event{first, event.NewFavoriteAddedEvent(customerA, 2), third} {
+		require.NoError(t, broker.Publish(context.Background(), e))
+	}
+
+	events, unsubscribe := broker.Subscribe(customerA, first.Id)
+	defer unsubscribe()
+
+	require.Len(t, events, 1)
+	reset := <-events
+	assert.Equal(t, event.StreamReset, reset.Type)
+	assert.Equal(t, third.Id, reset.Id)
+	assert.Equal(t, event.StreamResetData{CustomerId: customerA}, reset.Data)
+
+	// Reconnecting with the reset id resumes from the history.
+	removed := event.NewFavoriteRemovedEvent(customerA, 2)
+	require.NoError(t, broker.Publish(context.Background(), removed))
+	resumed, unsubscribeResumed := broker.Subscribe(customerA, reset.Id)
+	defer unsubscribeResumed()
+
+	require.Len(t, resumed, 1)
+	assert.Equal(t, removed.Id, (<-resumed).Id)
+}
+
+func TestBroker_Subscribe_ResetsWithEmptyHistory(t *testing.T) {
+	broker := NewBroker(DefaultHistorySize)
+
+	events, unsubscribe := broker.Subscribe(customerA, event.NewFavoriteAddedEvent(customerA, 1).Id)
+	defer unsubscribe()
+
+	require.Len(t, events, 1)
+	reset := <-events
+	assert.Equal(t, event.StreamReset, reset.Type)
+	assert.Empty(t, reset.Id)
+}
+
+func TestBroker_Publish_DropsSlowSubscriber(t *testing.T) {
+	broker := NewBroker(DefaultHistorySize)
+	events, unsubscribe := broker.Subscribe(customerA, "")
+	defer unsubscribe()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		require.NoError(t, broker.Publish(context.Background(), event.NewFavoriteAddedEvent(customerA, int64(i+1))))
+	}
+
+	count := 0
+	for range events {
+		count++
+	}
+	assert.Equal(t, subscriberBuffer, count)
+}
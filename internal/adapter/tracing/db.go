@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var queryNamePattern = regexp.MustCompile(`^-- name: (\w+)`)
+
+// DB wraps the connection given to sqlc, starting a span per query named
+// after the sqlc query (e.g. FindCustomerById).
+type DB struct {
+	db     database.DBTX
+	system string
+	tracer trace.Tracer
+}
+
+func NewDB(db database.DBTX, system string) *DB {
+	return &DB{
+		db:     db,
+		system: system,
+		tracer: otel.Tracer("github.com/juliocsrf/aiqfome-challenge/internal/adapter/tracing"),
+	}
+}
+
+func (d *DB) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := d.start(ctx, query)
+	defer span.End()
+
+	tag, err := d.db.Exec(ctx, query, args...)
+	recordError(span, err)
+	return tag, err
+}
+
+// Query ends the span when the rows are closed, so it covers reading them and
+// records the errors met while scanning.
+func (d *DB) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := d.start(ctx, query)
+
+	result, err := d.db.Query(ctx, query, args...)
+	if err != nil {
+		recordError(span, err)
+		span.End()
+		return result, err
+	}
+
+	return &rows{Rows: result, span: span}, nil
+}
+
+type rows struct {
+	pgx.Rows
+	span   trace.Span
+	closed bool
+}
+
+func (r *rows) Close() {
+	r.Rows.Close()
+	if r.closed {
+		return
+	}
+
+	r.closed = true
+	recordError(r.span, r.Rows.Err())
+	r.span.End()
+}
+
+// QueryRow ends the span when the row is scanned, as pgx only runs the query
+// then.
+func (d *DB) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	ctx, span := d.start(ctx, query)
+
+	return &row{Row: d.db.QueryRow(ctx, query, args...), span: span}
+}
+
+type row struct {
+	pgx.Row
+	span trace.Span
+}
+
+func (r *row) Scan(dest ...any) error {
+	defer r.span.End()
+
+	err := r.Row.Scan(dest...)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		recordError(r.span, err)
+	}
+	return err
+}
+
+func (d *DB) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := QueryName(query)
+
+	return d.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", d.system),
+			attribute.String("db.operation.name", name),
+			attribute.String("db.query.text", query),
+		),
+	)
+}
+
+// QueryName extracts the sqlc query name from the "-- name:" comment that
+// prefixes every generated query, falling back to "db.query".
+func QueryName(query string) string {
+	if match := queryNamePattern.FindStringSubmatch(query); match != nil {
+		return match[1]
+	}
+
+	return "db.query"
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
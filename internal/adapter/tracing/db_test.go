@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "FindCustomerById", QueryName("-- name: FindCustomerById :one\nSELECT id FROM customers WHERE id = $1\n"))
+	assert.Equal(t, "InsertWebhook", QueryName("-- name: InsertWebhook :exec\nINSERT INTO webhooks VALUES ($1)\n"))
+	assert.Equal(t, "db.query", QueryName("SELECT 1"))
+}
+
+type stubRows struct {
+	pgx.Rows
+	err error
+}
+
+func (r *stubRows) Close()     {}
+func (r *stubRows) Err() error { return r.err }
+
+type stubDBTX struct {
+	database.DBTX
+	rows *stubRows
+}
+
+func (s *stubDBTX) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	return s.rows, nil
+}
+
+func TestDB_QueryEndsSpanWhenRowsClose(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	db := NewDB(&stubDBTX{rows: &stubRows{err: errors.New("scan failed")}}, "postgresql")
+
+	rows, err := db.Query(context.Background(), "-- name: FindAllProducts :many\nSELECT * FROM products")
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Ended(), "the span stays open while the rows are read")
+
+	rows.Close()
+	rows.Close()
+
+	require.Len(t, recorder.Ended(), 1)
+	span := recorder.Ended()[0]
+	assert.Equal(t, "FindAllProducts", span.Name())
+	assert.Equal(t, codes.Error, span.Status().Code)
+}
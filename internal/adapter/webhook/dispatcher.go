@@ -0,0 +1,277 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+// deliveryBatchSize bounds how many due deliveries a poll claims at once.
+const deliveryBatchSize = 50
+
+// Dispatcher sends webhook deliveries. The pending rows of webhook_deliveries
+// are its queue: a delivery is sent right away when published, and retries,
+// as well as deliveries interrupted by a restart, are picked up by DeliverDue.
+type Dispatcher struct {
+	WebhookRepository  repository.WebhookRepository
+	DeliveryRepository repository.WebhookDeliveryRepository
+	Client             *http.Client
+	MaxAttempts        int
+	InitialBackoff     time.Duration
+	MaxBackoff         time.Duration
+	// Lease is how long a delivery being sent stays out of DeliverDue's reach.
+	// It outlasts the request timeout, so a delivery is never sent twice at once.
+	Lease     time.Duration
+	BatchSize int
+
+	wg       sync.WaitGroup
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewDispatcher(
+	webhookRepository repository.WebhookRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+	maxAttempts int,
+	initialBackoff time.Duration,
+	timeout time.Duration,
+	allowPrivateNetworks bool,
+) *Dispatcher {
+	return &Dispatcher{
+		WebhookRepository:  webhookRepository,
+		DeliveryRepository: deliveryRepository,
+		Client:             &http.Client{Timeout: timeout, Transport: newTransport(allowPrivateNetworks)},
+		MaxAttempts:        maxAttempts,
+		InitialBackoff:     initialBackoff,
+		MaxBackoff:         time.Hour,
+		Lease:              timeout + time.Minute,
+		BatchSize:          deliveryBatchSize,
+		stop:               make(chan struct{}),
+	}
+}
+
+// Publish records a delivery for every active webhook subscribed to the event
+// and sends them in the background.
+func (d *Dispatcher) Publish(ctx context.Context, e *event.Event) error {
+	webhooks, err := d.WebhookRepository.FindActiveByEvent(ctx, string(e.Type))
+	if err != nil {
+		logger.FromContext(ctx).Error("webhook: finding subscriptions", slog.String("event_type", string(e.Type)), slog.Any("error", err))
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("error while encoding event payload: %s", err)
+	}
+
+	for _, webhook := range webhooks {
+		delivery, err := entity.NewWebhookDelivery(webhook.Id, e.Id, string(e.Type), payload, d.MaxAttempts)
+		if err != nil {
+			return err
+		}
+
+		// Recorded already claimed by this process, which sends it below.
+		delivery.ScheduleAt(time.Now().Add(d.Lease))
+		if err := d.DeliveryRepository.Create(ctx, delivery); err != nil {
+			logger.FromContext(ctx).Error("webhook: recording delivery", slog.String("webhook_id", webhook.Id), slog.Any("error", err))
+			return err
+		}
+
+		d.attemptInBackground(ctx, webhook, delivery)
+	}
+
+	return nil
+}
+
+// Redeliver queues a finished delivery again with a fresh budget of
+// MaxAttempts and sends it in the background. A delivery still pending is
+// left to its own retries and reported with ErrWebhookDeliveryAlreadyPending.
+func (d *Dispatcher) Redeliver(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) error {
+	if delivery.IsPending() {
+		return entity.ErrWebhookDeliveryAlreadyPending
+	}
+
+	delivery.Requeue(d.MaxAttempts)
+	delivery.ScheduleAt(time.Now().Add(d.Lease))
+
+	// The row may have turned pending since it was read, e.g. by a concurrent
+	// redelivery; only one of them gets to send it.
+	requeued, err := d.DeliveryRepository.Requeue(ctx, delivery)
+	if err != nil {
+		return err
+	}
+
+	if !requeued {
+		return entity.ErrWebhookDeliveryAlreadyPending
+	}
+
+	sending := *delivery
+	d.attemptInBackground(ctx, webhook, &sending)
+
+	return nil
+}
+
+// DeliverDue claims the pending deliveries that are due, BatchSize at a time,
+// and attempts each of them once. It is meant to run periodically. Attempts
+// already started finish even if ctx is cancelled.
+func (d *Dispatcher) DeliverDue(ctx context.Context) error {
+	for ctx.Err() == nil {
+		now := time.Now()
+		deliveries, err := d.DeliveryRepository.ClaimDue(ctx, now, now.Add(d.Lease), d.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		webhooks := map[string]*entity.Webhook{}
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			webhook, ok := webhooks[delivery.WebhookId]
+			if !ok {
+				// A failed lookup leaves the delivery claimed until its lease ends.
+				if webhook, err = d.WebhookRepository.FindById(ctx, delivery.WebhookId); err != nil {
+					logger.FromContext(ctx).Error("webhook: finding webhook", slog.String("webhook_id", delivery.WebhookId), slog.Any("error", err))
+					continue
+				}
+				webhooks[delivery.WebhookId] = webhook
+			}
+
+			if webhook == nil {
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.attempt(context.WithoutCancel(ctx), webhook, delivery)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < d.BatchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Wait blocks until every delivery sent in the background has finished.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Shutdown stops sending in the background and waits for the requests in
+// flight. Deliveries not sent stay pending and are picked up by DeliverDue.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.stopOnce.Do(func() { close(d.stop) })
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// attemptInBackground makes the first attempt of a delivery claimed by the
+// caller. The attempt keeps the trace of ctx but is not cancelled with it.
+func (d *Dispatcher) attemptInBackground(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) {
+	select {
+	case <-d.stop:
+		return
+	default:
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.attempt(ctx, webhook, delivery)
+	}()
+}
+
+// attempt sends a claimed delivery once and saves the outcome: delivered,
+// scheduled for a retry with exponential backoff, or failed once no attempts
+// are left.
+func (d *Dispatcher) attempt(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) {
+	statusCode, err := d.send(ctx, webhook, delivery)
+	delivery.RecordAttempt(statusCode, err)
+
+	if err != nil {
+		if delivery.AttemptsLeft == 0 {
+			delivery.MarkFailed()
+		} else {
+			delivery.ScheduleAt(time.Now().Add(d.backoff(d.MaxAttempts - delivery.AttemptsLeft)))
+		}
+
+		logger.FromContext(ctx).Warn("webhook: delivery attempt failed",
+			slog.String("delivery_id", delivery.Id),
+			slog.Int("attempt", delivery.Attempts),
+			slog.Any("error", err),
+		)
+	}
+
+	if updateErr := d.DeliveryRepository.Update(ctx, delivery); updateErr != nil {
+		logger.FromContext(ctx).Error("webhook: updating delivery", slog.String("delivery_id", delivery.Id), slog.Any("error", updateErr))
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("error while creating request: %s", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "aiqfome-challenge-webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.Id)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error while sending request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) backoff(retry int) time.Duration {
+	// A lower MaxAttempts since the delivery was queued leaves retry below one.
+	retry = max(retry, 1)
+	backoff := d.InitialBackoff << (retry - 1)
+	if backoff <= 0 || backoff > d.MaxBackoff {
+		return d.MaxBackoff
+	}
+
+	return backoff
+}
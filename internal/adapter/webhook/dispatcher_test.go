@@ -0,0 +1,404 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inMemoryWebhookRepository struct {
+	webhooks []*entity.Webhook
+}
+
+func (r *inMemoryWebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	return r.webhooks, nil
+}
+
+func (r *inMemoryWebhookRepository) FindById(ctx context.Context, id string) (*entity.Webhook, error) {
+	for _, webhook := range r.webhooks {
+		if webhook.Id == id {
+			return webhook, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *inMemoryWebhookRepository) FindActiveByEvent(ctx context.Context, eventType string) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.Active && webhook.Subscribes(eventType) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (r *inMemoryWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	r.webhooks = append(r.webhooks, webhook)
+	return webhook, nil
+}
+
+func (r *inMemoryWebhookRepository) Update(context.Context, *entity.Webhook) error { return nil }
+
+func (r *inMemoryWebhookRepository) Delete(context.Context, *entity.Webhook) error { return nil }
+
+type inMemoryDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries map[string]entity.WebhookDelivery
+}
+
+func (r *inMemoryDeliveryRepository) FindById(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return nil, nil
+	}
+	return &delivery, nil
+}
+
+func (r *inMemoryDeliveryRepository) FindAllByWebhook(ctx context.Context, webhook *entity.Webhook) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []*entity.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookId == webhook.Id {
+			delivery := delivery
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *inMemoryDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	return r.Update(ctx, delivery)
+}
+
+func (r *inMemoryDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.deliveries == nil {
+		r.deliveries = map[string]entity.WebhookDelivery{}
+	}
+	r.deliveries[delivery.Id] = *delivery
+	return nil
+}
+
+func (r *inMemoryDeliveryRepository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var claimed []*entity.WebhookDelivery
+	for id, delivery := range r.deliveries {
+		if len(claimed) == limit || !delivery.IsPending() || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery.ScheduleAt(leaseUntil)
+		r.deliveries[id] = delivery
+		claimed = append(claimed, &delivery)
+	}
+	return claimed, nil
+}
+
+func (r *inMemoryDeliveryRepository) Requeue(ctx context.Context, delivery *entity.WebhookDelivery) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored := r.deliveries[delivery.Id]; stored.IsPending() {
+		return false, nil
+	}
+	r.deliveries[delivery.Id] = *delivery
+	return true, nil
+}
+
+func (r *inMemoryDeliveryRepository) hasPending() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range r.deliveries {
+		if delivery.IsPending() {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestDispatcher(t *testing.T, url string, maxAttempts int) (*Dispatcher, *entity.Webhook, *inMemoryDeliveryRepository) {
+	webhook, err := newTestWebhook(url)
+	require.NoError(t, err)
+
+	webhooks := &inMemoryWebhookRepository{webhooks: []*entity.Webhook{webhook}}
+	deliveries := &inMemoryDeliveryRepository{}
+	dispatcher := NewDispatcher(webhooks, deliveries, maxAttempts, time.Millisecond, time.Second, true)
+
+	return dispatcher, webhook, deliveries
+}
+
+func newTestWebhook(url string) (*entity.Webhook, error) {
+	return entity.NewWebhook(url, []string{string(event.FavoriteAdded)}, "test-secret-0123456789")
+}
+
+// deliverUntilDone runs the retry poller until no delivery is pending.
+func deliverUntilDone(t *testing.T, dispatcher *Dispatcher, deliveries *inMemoryDeliveryRepository) {
+	dispatcher.Wait()
+	require.Eventually(t, func() bool {
+		require.NoError(t, dispatcher.DeliverDue(context.Background()))
+		return !deliveries.hasPending()
+	}, time.Second, time.Millisecond)
+}
+
+func TestDispatcher_Publish_SignsPayload(t *testing.T) {
+	var received []byte
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		headers = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dispatcher, webhook, deliveries := newTestDispatcher(t, server.URL, 3)
+
+	e := event.NewFavoriteAddedEvent("01986709-c873-7525-bd98-20457930777c", 1)
+	require.NoError(t, dispatcher.Publish(context.Background(), e))
+	dispatcher.Wait()
+
+	assert.Equal(t, string(event.FavoriteAdded), headers.Get(EventHeader))
+	assert.True(t, Verify(webhook.Secret, headers.Get(TimestampHeader), received, headers.Get(SignatureHeader)))
+	assert.False(t, Verify("another-secret-0123456789", headers.Get(TimestampHeader), received, headers.Get(SignatureHeader)))
+
+	var payload event.Event
+	require.NoError(t, json.Unmarshal(received, &payload))
+	assert.Equal(t, e.Id, payload.Id)
+
+	delivery, err := deliveries.FindById(context.Background(), headers.Get(DeliveryHeader))
+	require.NoError(t, err)
+	require.NotNil(t, delivery)
+	assert.Equal(t, entity.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+}
+
+func TestDispatcher_Publish_RetriesUntilSuccess(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dispatcher, webhook, deliveries := newTestDispatcher(t, server.URL, 5)
+
+	require.NoError(t, dispatcher.Publish(context.Background(), event.NewFavoriteAddedEvent("01986709-c873-7525-bd98-20457930777c", 1)))
+	deliverUntilDone(t, dispatcher, deliveries)
+
+	logged, err := deliveries.FindAllByWebhook(context.Background(), webhook)
+	require.NoError(t, err)
+	require.Len(t, logged, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, entity.WebhookDeliverySucceeded, logged[0].Status)
+	assert.Equal(t, 3, logged[0].Attempts)
+}
+
+func TestDispatcher_Publish_MarksFailedAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dispatcher, webhook, deliveries := newTestDispatcher(t, server.URL, 3)
+
+	require.NoError(t, dispatcher.Publish(context.Background(), event.NewFavoriteAddedEvent("01986709-c873-7525-bd98-20457930777c", 1)))
+	deliverUntilDone(t, dispatcher, deliveries)
+
+	logged, err := deliveries.FindAllByWebhook(context.Background(), webhook)
+	require.NoError(t, err)
+	require.Len(t, logged, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, entity.WebhookDeliveryFailed, logged[0].Status)
+	assert.Equal(t, 3, logged[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, logged[0].ResponseStatus)
+	assert.Equal(t, "unexpected response status 500", logged[0].LastError)
+}
+
+func TestDispatcher_Publish_IgnoresUnsubscribedEvents(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	dispatcher, _, _ := newTestDispatcher(t, server.URL, 3)
+
+	require.NoError(t, dispatcher.Publish(context.Background(), event.NewFavoriteRemovedEvent("01986709-c873-7525-bd98-20457930777c", 1)))
+	dispatcher.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	dispatcher := &Dispatcher{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	assert.Equal(t, time.Second, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Second, dispatcher.backoff(2))
+	assert.Equal(t, 4*time.Second, dispatcher.backoff(3))
+	assert.Equal(t, 5*time.Second, dispatcher.backoff(4))
+	assert.Equal(t, time.Second, dispatcher.backoff(0))
+}
+
+func TestDispatcher_Shutdown_LeavesRetriesQueued(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	dispatcher, webhook, deliveries := newTestDispatcher(t, server.URL, 5)
+	dispatcher.InitialBackoff = time.Hour
+
+	require.NoError(t, dispatcher.Publish(context.Background(), event.NewFavoriteAddedEvent("01986709-c873-7525-bd98-20457930777c", 1)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, dispatcher.Shutdown(ctx))
+	require.NoError(t, dispatcher.DeliverDue(context.Background()))
+
+	logged, err := deliveries.FindAllByWebhook(context.Background(), webhook)
+	require.NoError(t, err)
+	require.Len(t, logged, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, entity.WebhookDeliveryPending, logged[0].Status)
+	assert.Equal(t, 1, logged[0].Attempts)
+	assert.Equal(t, 4, logged[0].AttemptsLeft)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *logged[0].NextAttemptAt, time.Minute)
+}
+
+func TestDispatcher_DeliverDue_SendsDeliveriesLeftPending(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	dispatcher, webhook, deliveries := newTestDispatcher(t, server.URL, 3)
+
+	// Left behind by a process that stopped before sending it.
+	delivery, err := entity.NewWebhookDelivery(webhook.Id, "01986709-c873-7525-bd98-20457930777c", string(event.FavoriteAdded), []byte(`{}`), 3)
+	require.NoError(t, err)
+	require.NoError(t, deliveries.Create(context.Background(), delivery))
+
+	require.NoError(t, dispatcher.DeliverDue(context.Background()))
+
+	logged, err := deliveries.FindById(context.Background(), delivery.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, entity.WebhookDeliverySucceeded, logged.Status)
+	assert.Nil(t, logged.NextAttemptAt)
+}
+
+func TestDispatcher_DeliverDue_SkipsDeliveriesBeingSent(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+	}))
+	defer server.Close()
+
+	dispatcher, _, deliveries := newTestDispatcher(t, server.URL, 3)
+
+	require.NoError(t, dispatcher.Publish(context.Background(), event.NewFavoriteAddedEvent("01986709-c873-7525-bd98-20457930777c", 1)))
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, dispatcher.DeliverDue(context.Background()))
+	close(release)
+	dispatcher.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.False(t, deliveries.hasPending())
+}
+
+func TestDispatcher_Redeliver(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	dispatcher, webhook, deliveries := newTestDispatcher(t, server.URL, 3)
+
+	delivery, err := entity.NewWebhookDelivery(webhook.Id, "01986709-c873-7525-bd98-20457930777c", string(event.FavoriteAdded), []byte(`{}`), 3)
+	require.NoError(t, err)
+	require.NoError(t, deliveries.Create(context.Background(), delivery))
+
+	assert.ErrorIs(t, dispatcher.Redeliver(context.Background(), webhook, delivery), entity.ErrWebhookDeliveryAlreadyPending)
+
+	delivery.MarkFailed()
+	require.NoError(t, deliveries.Update(context.Background(), delivery))
+	require.NoError(t, dispatcher.Redeliver(context.Background(), webhook, delivery))
+	dispatcher.Wait()
+
+	logged, err := deliveries.FindById(context.Background(), delivery.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, entity.WebhookDeliverySucceeded, logged.Status)
+	assert.Equal(t, 1, logged.Attempts)
+}
+
+func TestDispatcher_RefusesPrivateAddresses(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	webhook, err := newTestWebhook(server.URL)
+	require.NoError(t, err)
+	deliveries := &inMemoryDeliveryRepository{}
+	dispatcher := NewDispatcher(&inMemoryWebhookRepository{webhooks: []*entity.Webhook{webhook}}, deliveries, 1, time.Millisecond, time.Second, false)
+
+	require.NoError(t, dispatcher.Publish(context.Background(), event.NewFavoriteAddedEvent("01986709-c873-7525-bd98-20457930777c", 1)))
+	dispatcher.Wait()
+
+	logged, err := deliveries.FindAllByWebhook(context.Background(), webhook)
+	require.NoError(t, err)
+	require.Len(t, logged, 1)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+	assert.Equal(t, entity.WebhookDeliveryFailed, logged[0].Status)
+	assert.Contains(t, logged[0].LastError, ErrAddressNotAllowed.Error())
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"8.8.8.8":          true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"0.0.0.0":          false,
+		"100.64.0.1":       false,
+		"::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, public, isPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+var ErrAddressNotAllowed = errors.New("webhook address is not publicly routable")
+
+// nonPublicPrefixes are the ranges not covered by the netip.Addr predicates
+// that still never belong to a partner's endpoint.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// newTransport returns the transport deliveries are sent through. Unless
+// private networks are allowed, it refuses to connect to loopback, link-local
+// and private addresses, so a webhook cannot reach the API's own network.
+func newTransport(allowPrivateNetworks bool) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if !allowPrivateNetworks {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   publicOnly,
+		}
+		transport.DialContext = dialer.DialContext
+		// A proxy would connect on our behalf, out of reach of the check.
+		transport.Proxy = nil
+	}
+
+	return otelhttp.NewTransport(transport)
+}
+
+// publicOnly runs on the resolved address right before connecting, so neither
+// a hostname nor a redirect can point a delivery at an internal service.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("error while parsing address %q: %s", address, err)
+	}
+
+	if !isPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, addrPort.Addr())
+	}
+
+	return nil
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
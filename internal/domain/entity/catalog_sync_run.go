@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	CatalogSyncRunning   = "running"
+	CatalogSyncSucceeded = "succeeded"
+	CatalogSyncFailed    = "failed"
+)
+
+// CatalogSyncRun records one import of the upstream catalog into the local
+// products table.
+type CatalogSyncRun struct {
+	Id           int64
+	Status       string
+	Fetched      int
+	Inserted     int
+	Updated      int
+	Unchanged    int
+	Discontinued int
+	// Conflicts lists the upstream ids already taken by products created
+	// through the API. Those upstream products are not imported.
+	Conflicts  []int64
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+func NewCatalogSyncRun() *CatalogSyncRun {
+	return &CatalogSyncRun{
+		Status:    CatalogSyncRunning,
+		StartedAt: time.Now(),
+	}
+}
+
+func (r *CatalogSyncRun) Succeed() {
+	r.Status = CatalogSyncSucceeded
+	r.FinishedAt = time.Now()
+}
+
+func (r *CatalogSyncRun) Fail(err error) {
+	r.Status = CatalogSyncFailed
+	r.Error = err.Error()
+	r.FinishedAt = time.Now()
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogSyncRun_Succeed(t *testing.T) {
+	run := NewCatalogSyncRun()
+	assert.Equal(t, CatalogSyncRunning, run.Status)
+	assert.True(t, run.FinishedAt.IsZero())
+
+	run.Succeed()
+
+	assert.Equal(t, CatalogSyncSucceeded, run.Status)
+	assert.False(t, run.FinishedAt.IsZero())
+	assert.Empty(t, run.Error)
+}
+
+func TestCatalogSyncRun_Fail(t *testing.T) {
+	run := NewCatalogSyncRun()
+
+	run.Fail(errors.New("upstream down"))
+
+	assert.Equal(t, CatalogSyncFailed, run.Status)
+	assert.Equal(t, "upstream down", run.Error)
+	assert.False(t, run.FinishedAt.IsZero())
+}
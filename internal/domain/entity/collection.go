@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCollectionName names the collection behind the customer's flat
+// favorites list.
+const DefaultCollectionName = "Favoritos"
+
+const collectionNameMaxLength = 100
+
+var (
+	ErrCollectionIdEmpty         = errors.New("id cannot be empty")
+	ErrCollectionCustomerIdEmpty = errors.New("customer id cannot be empty")
+	ErrCollectionNameEmpty       = errors.New("name cannot be empty")
+	ErrCollectionNameTooLong     = errors.New("name must have at most 100 characters")
+)
+
+// Collection is a named list of favorites. Every customer has one default
+// collection, created on demand, that the legacy favorites routes work on.
+type Collection struct {
+	Id         string
+	CustomerId string
+	Name       string
+	IsDefault  bool
+
+	// FavoritesCount is filled when listing collections.
+	FavoritesCount int64
+	// Favorites is filled when loading a single collection.
+	Favorites []*Favorite
+}
+
+func NewCollection(customerId, name string) (*Collection, error) {
+	id := uuid.Must(uuid.NewV7()).String()
+	return NewCollectionWithId(id, customerId, name, false)
+}
+
+func NewCollectionWithId(id, customerId, name string, isDefault bool) (*Collection, error) {
+	var collection = &Collection{
+		Id:         id,
+		CustomerId: customerId,
+		Name:       strings.Join(strings.Fields(name), " "),
+		IsDefault:  isDefault,
+		Favorites:  []*Favorite{},
+	}
+
+	if err := collection.Validate(); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (c *Collection) Validate() error {
+	if c.Id == "" {
+		return ErrCollectionIdEmpty
+	}
+
+	if c.CustomerId == "" {
+		return ErrCollectionCustomerIdEmpty
+	}
+
+	if c.Name == "" {
+		return ErrCollectionNameEmpty
+	}
+
+	if utf8.RuneCountInString(c.Name) > collectionNameMaxLength {
+		return ErrCollectionNameTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCollection_Success(t *testing.T) {
+	collection, err := NewCollection("customer-1", "  Mercado   do mês ")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, collection.Id)
+	assert.Equal(t, "customer-1", collection.CustomerId)
+	assert.Equal(t, "Mercado do mês", collection.Name)
+	assert.False(t, collection.IsDefault)
+	assert.Empty(t, collection.Favorites)
+}
+
+func TestNewCollection_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		customerId string
+		name       string
+		err        error
+	}{
+		"empty customer": {customerId: "", name: "Presentes", err: ErrCollectionCustomerIdEmpty},
+		"empty name":     {customerId: "customer-1", name: "   ", err: ErrCollectionNameEmpty},
+		"long name":      {customerId: "customer-1", name: strings.Repeat("á", 101), err: ErrCollectionNameTooLong},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			collection, err := NewCollection(tt.customerId, tt.name)
+
+			assert.Equal(t, tt.err, err)
+			assert.Nil(t, collection)
+		})
+	}
+}
+
+func TestNewCollectionWithId_EmptyId(t *testing.T) {
+	collection, err := NewCollectionWithId("", "customer-1", DefaultCollectionName, true)
+
+	assert.Equal(t, ErrCollectionIdEmpty, err)
+	assert.Nil(t, collection)
+}
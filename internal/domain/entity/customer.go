@@ -4,23 +4,37 @@ import (
 	"errors"
 	"net/mail"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
 
+// The customers table stores both as VARCHAR(255).
+const (
+	customerNameMaxLength  = 255
+	customerEmailMaxLength = 255
+)
+
 var (
 	ErrCustomerIdEmpty      = errors.New("id cannot be empty")
 	ErrCustomerNameEmtpy    = errors.New("name cannot be empty")
+	ErrCustomerNameTooLong  = errors.New("name must have at most 255 characters")
 	ErrCustomerEmailEmpty   = errors.New("email cannot be empty")
+	ErrCustomerEmailTooLong = errors.New("email must have at most 255 characters")
 	ErrCustomerEmailInvalid = errors.New("email is invalid")
+
+	ErrCustomerFavoritesQuotaInvalid = errors.New("favorites quota must be greater than zero")
 )
 
 type Customer struct {
 	Id    string
 	Name  string
 	Email string
+	// FavoritesQuota overrides the default maximum of favorites for this
+	// customer; nil uses the default.
+	FavoritesQuota *int
 
-	Favorites []*Product
+	Favorites []*Favorite
 }
 
 func NewCustomer(name, email string) (*Customer, error) {
@@ -33,7 +47,7 @@ func NewCustomerWithId(id, name, email string) (*Customer, error) {
 		Id:        id,
 		Name:      strings.Join(strings.Fields(name), " "),
 		Email:     email,
-		Favorites: []*Product{},
+		Favorites: []*Favorite{},
 	}
 
 	if err := customer.Validate(); err != nil {
@@ -52,14 +66,43 @@ func (c *Customer) Validate() error {
 		return ErrCustomerNameEmtpy
 	}
 
+	if utf8.RuneCountInString(c.Name) > customerNameMaxLength {
+		return ErrCustomerNameTooLong
+	}
+
 	if c.Email == "" {
 		return ErrCustomerEmailEmpty
 	}
 
+	if utf8.RuneCountInString(c.Email) > customerEmailMaxLength {
+		return ErrCustomerEmailTooLong
+	}
+
 	_, err := mail.ParseAddress(c.Email)
 	if err != nil {
 		return ErrCustomerEmailInvalid
 	}
 
+	if c.FavoritesQuota != nil && *c.FavoritesQuota <= 0 {
+		return ErrCustomerFavoritesQuotaInvalid
+	}
+
 	return nil
 }
+
+// FavoritesLimit is the most products the customer can favorite: the
+// customer's quota when set and defaultLimit otherwise. Zero means no limit.
+func (c *Customer) FavoritesLimit(defaultLimit int) int {
+	if c.FavoritesQuota != nil {
+		return *c.FavoritesQuota
+	}
+
+	return defaultLimit
+}
+
+// CustomerExport is a customer as exported in bulk: the ids of the products
+// they favorited instead of the favorites themselves.
+type CustomerExport struct {
+	Customer    *Customer
+	FavoriteIds []int64
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,3 +61,31 @@ func TestNewCustomer_InvalidEmail(t *testing.T) {
 	assert.Equal(t, ErrCustomerEmailInvalid, err)
 	assert.Nil(t, customer)
 }
+
+func TestNewCustomer_TooLong(t *testing.T) {
+	customer, err := NewCustomer(strings.Repeat("á", 256), "julio.fonseca@gmail.com")
+	assert.Equal(t, ErrCustomerNameTooLong, err)
+	assert.Nil(t, customer)
+
+	customer, err = NewCustomer("Júlio Fonseca", strings.Repeat("a", 244)+"@example.com")
+	assert.Equal(t, ErrCustomerEmailTooLong, err)
+	assert.Nil(t, customer)
+
+	customer, err = NewCustomer(strings.Repeat("á", 255), strings.Repeat("a", 243)+"@example.com")
+	assert.NoError(t, err)
+	assert.NotNil(t, customer)
+}
+
+func TestCustomer_FavoritesLimit(t *testing.T) {
+	customer, err := NewCustomer("Júlio Fonseca", "julio.fonseca@gmail.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 100, customer.FavoritesLimit(100))
+
+	quota := 500
+	customer.FavoritesQuota = &quota
+	assert.NoError(t, customer.Validate())
+	assert.Equal(t, 500, customer.FavoritesLimit(100))
+
+	quota = 0
+	assert.Equal(t, ErrCustomerFavoritesQuotaInvalid, customer.Validate())
+}
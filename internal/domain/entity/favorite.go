@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	favoriteNoteMaxLength   = 500
+	favoriteQuantityMaximum = 999
+)
+
+var (
+	ErrFavoriteNoteTooLong     = errors.New("note must have at most 500 characters")
+	ErrFavoriteQuantityInvalid = errors.New("quantity must be between 1 and 999")
+	ErrFavoritePriorityInvalid = errors.New("priority must be low, normal or high")
+	ErrFavoriteSortInvalid     = errors.New("sort must be one of created_at, title, price, quantity, priority, optionally prefixed with -")
+)
+
+// FavoritePriority orders favorites in a shopping list, from low to high.
+type FavoritePriority int
+
+const (
+	FavoritePriorityLow FavoritePriority = iota
+	FavoritePriorityNormal
+	FavoritePriorityHigh
+)
+
+var favoritePriorityNames = []string{"low", "normal", "high"}
+
+func ParseFavoritePriority(name string) (FavoritePriority, error) {
+	index := slices.Index(favoritePriorityNames, name)
+	if index < 0 {
+		return 0, ErrFavoritePriorityInvalid
+	}
+
+	return FavoritePriority(index), nil
+}
+
+func (p FavoritePriority) String() string {
+	if p < FavoritePriorityLow || p > FavoritePriorityHigh {
+		return ""
+	}
+
+	return favoritePriorityNames[p]
+}
+
+// Favorite is a product in a customer's favorites list. Title, Image and Price
+// are a snapshot taken when the product was favorited, so the favorite can
+// still be shown after the product leaves the catalog.
+type Favorite struct {
+	ProductId int64
+	Title     string
+	Image     string
+	Price     float64
+	CreatedAt time.Time
+
+	// Note, Quantity and Priority are set by the customer for shopping lists.
+	Note     string
+	Quantity int
+	Priority FavoritePriority
+
+	// Available is false once the catalog reports the product as gone.
+	Available bool
+	// Product is the current catalog entry, nil when it could not be loaded.
+	Product *Product
+}
+
+func NewFavorite(product *Product) *Favorite {
+	return &Favorite{
+		ProductId: product.Id,
+		Title:     product.Title,
+		Image:     product.Image,
+		Price:     product.Price,
+		CreatedAt: time.Now(),
+		Quantity:  1,
+		Priority:  FavoritePriorityNormal,
+		Available: true,
+		Product:   product,
+	}
+}
+
+// SetProduct attaches the current catalog entry. Favorites recorded before
+// snapshots were taken have an empty one, which is filled from the product.
+func (f *Favorite) SetProduct(product *Product) {
+	f.Product = product
+	if f.Title == "" {
+		f.Title = product.Title
+		f.Image = product.Image
+		f.Price = product.Price
+	}
+}
+
+func (f *Favorite) Validate() error {
+	if utf8.RuneCountInString(f.Note) > favoriteNoteMaxLength {
+		return ErrFavoriteNoteTooLong
+	}
+
+	if f.Quantity < 1 || f.Quantity > favoriteQuantityMaximum {
+		return ErrFavoriteQuantityInvalid
+	}
+
+	if f.Priority.String() == "" {
+		return ErrFavoritePriorityInvalid
+	}
+
+	return nil
+}
+
+// currentTitle and currentPrice prefer the catalog entry over the snapshot,
+// matching what is shown to the customer.
+func (f *Favorite) currentTitle() string {
+	if f.Product != nil {
+		return f.Product.Title
+	}
+
+	return f.Title
+}
+
+func (f *Favorite) currentPrice() float64 {
+	if f.Product != nil {
+		return f.Product.Price
+	}
+
+	return f.Price
+}
+
+var favoriteSortKeys = map[string]func(a, b *Favorite) int{
+	"created_at": func(a, b *Favorite) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"title": func(a, b *Favorite) int {
+		return strings.Compare(strings.ToLower(a.currentTitle()), strings.ToLower(b.currentTitle()))
+	},
+	"price":    func(a, b *Favorite) int { return cmp.Compare(a.currentPrice(), b.currentPrice()) },
+	"quantity": func(a, b *Favorite) int { return cmp.Compare(a.Quantity, b.Quantity) },
+	"priority": func(a, b *Favorite) int { return cmp.Compare(a.Priority, b.Priority) },
+}
+
+// SortFavorites sorts favorites in place by a key from favoriteSortKeys, in
+// descending order when prefixed with "-". The sort is stable, so ties keep
+// the order the repositories return, oldest favorite first. An empty key
+// leaves the favorites untouched.
+func SortFavorites(favorites []*Favorite, sort string) error {
+	if err := ValidateFavoriteSort(sort); err != nil || sort == "" {
+		return err
+	}
+
+	key, descending := strings.CutPrefix(sort, "-")
+	compare := favoriteSortKeys[key]
+
+	slices.SortStableFunc(favorites, func(a, b *Favorite) int {
+		if descending {
+			return compare(b, a)
+		}
+
+		return compare(a, b)
+	})
+
+	return nil
+}
+
+func ValidateFavoriteSort(sort string) error {
+	if sort == "" {
+		return nil
+	}
+
+	if _, ok := favoriteSortKeys[strings.TrimPrefix(sort, "-")]; !ok {
+		return ErrFavoriteSortInvalid
+	}
+
+	return nil
+}
+
+// FavoritedProduct counts how many customers favorited a product.
+type FavoritedProduct struct {
+	ProductId int64
+	Favorites int64
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFavorite(t *testing.T) {
+	product, err := NewProduct(1, "Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 4.5, 150)
+	require.NoError(t, err)
+
+	favorite := NewFavorite(product)
+
+	assert.Equal(t, int64(1), favorite.ProductId)
+	assert.Equal(t, "Produto Teste", favorite.Title)
+	assert.Equal(t, "https://placehold.co/600x400", favorite.Image)
+	assert.Equal(t, 999.99, favorite.Price)
+	assert.False(t, favorite.CreatedAt.IsZero())
+	assert.True(t, favorite.Available)
+	assert.Same(t, product, favorite.Product)
+
+	product.Price = 10
+	assert.Equal(t, 999.99, favorite.Price)
+}
+
+func TestNewFavorite_Defaults(t *testing.T) {
+	favorite := NewFavorite(&Product{Id: 1})
+
+	assert.Equal(t, 1, favorite.Quantity)
+	assert.Equal(t, FavoritePriorityNormal, favorite.Priority)
+	assert.Empty(t, favorite.Note)
+	assert.NoError(t, favorite.Validate())
+}
+
+func TestFavorite_SetProduct(t *testing.T) {
+	product := &Product{Id: 1, Title: "Backpack", Image: "backpack.jpg", Price: 109.95}
+
+	legacy := &Favorite{ProductId: 1}
+	legacy.SetProduct(product)
+	assert.Same(t, product, legacy.Product)
+	assert.Equal(t, "Backpack", legacy.Title)
+	assert.Equal(t, 109.95, legacy.Price)
+
+	snapshot := &Favorite{ProductId: 1, Title: "Old backpack", Price: 99.9}
+	snapshot.SetProduct(product)
+	assert.Equal(t, "Old backpack", snapshot.Title)
+	assert.Equal(t, 99.9, snapshot.Price)
+}
+
+func TestFavorite_Validate(t *testing.T) {
+	tests := map[string]struct {
+		favorite Favorite
+		err      error
+	}{
+		"long note":        {favorite: Favorite{Note: strings.Repeat("a", 501), Quantity: 1}, err: ErrFavoriteNoteTooLong},
+		"zero quantity":    {favorite: Favorite{Quantity: 0}, err: ErrFavoriteQuantityInvalid},
+		"huge quantity":    {favorite: Favorite{Quantity: 1000}, err: ErrFavoriteQuantityInvalid},
+		"unknown priority": {favorite: Favorite{Quantity: 1, Priority: 3}, err: ErrFavoritePriorityInvalid},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.favorite.Validate())
+		})
+	}
+}
+
+func TestParseFavoritePriority(t *testing.T) {
+	priority, err := ParseFavoritePriority("high")
+	require.NoError(t, err)
+	assert.Equal(t, FavoritePriorityHigh, priority)
+	assert.Equal(t, "high", priority.String())
+
+	_, err = ParseFavoritePriority("urgent")
+	assert.Equal(t, ErrFavoritePriorityInvalid, err)
+}
+
+func TestSortFavorites(t *testing.T) {
+	now := time.Now()
+	favorites := []*Favorite{
+		{ProductId: 1, Title: "banana", Quantity: 2, Priority: FavoritePriorityNormal, CreatedAt: now},
+		{ProductId: 2, Title: "Abacate", Quantity: 5, Priority: FavoritePriorityHigh, CreatedAt: now.Add(time.Minute)},
+		{ProductId: 3, Title: "cenoura", Quantity: 1, Priority: FavoritePriorityHigh, CreatedAt: now.Add(2 * time.Minute)},
+	}
+
+	ids := func() []int64 {
+		var ids []int64
+		for _, favorite := range favorites {
+			ids = append(ids, favorite.ProductId)
+		}
+		return ids
+	}
+
+	require.NoError(t, SortFavorites(favorites, "-priority"))
+	assert.Equal(t, []int64{2, 3, 1}, ids())
+
+	require.NoError(t, SortFavorites(favorites, "title"))
+	assert.Equal(t, []int64{2, 1, 3}, ids())
+
+	require.NoError(t, SortFavorites(favorites, "quantity"))
+	assert.Equal(t, []int64{3, 1, 2}, ids())
+
+	require.NoError(t, SortFavorites(favorites, "-created_at"))
+	assert.Equal(t, []int64{3, 2, 1}, ids())
+
+	assert.Equal(t, ErrFavoriteSortInvalid, SortFavorites(favorites, "note"))
+}
@@ -1,7 +1,10 @@
 package entity
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"strconv"
 )
 
 var (
@@ -13,22 +16,26 @@ var (
 )
 
 type Product struct {
-	Id        int64
-	Title     string
-	Image     string
-	Price     float64
-	Rate      float64
-	RateCount int64
+	Id          int64
+	Title       string
+	Description string
+	Category    string
+	Image       string
+	Price       float64
+	Rate        float64
+	RateCount   int64
 }
 
-func NewProduct(id int64, title, image string, price, rate float64, rateCount int64) (*Product, error) {
+func NewProduct(id int64, title, description, category, image string, price, rate float64, rateCount int64) (*Product, error) {
 	var product = &Product{
-		Id:        id,
-		Title:     title,
-		Image:     image,
-		Price:     price,
-		Rate:      rate,
-		RateCount: rateCount,
+		Id:          id,
+		Title:       title,
+		Description: description,
+		Category:    category,
+		Image:       image,
+		Price:       price,
+		Rate:        rate,
+		RateCount:   rateCount,
 	}
 
 	if err := product.Validate(); err != nil {
@@ -38,11 +45,35 @@ func NewProduct(id int64, title, image string, price, rate float64, rateCount in
 	return product, nil
 }
 
+// NewProductWithoutId builds a product that has not been stored yet, for
+// catalogs that assign the id on insert.
+func NewProductWithoutId(title, description, category, image string, price, rate float64, rateCount int64) (*Product, error) {
+	var product = &Product{
+		Title:       title,
+		Description: description,
+		Category:    category,
+		Image:       image,
+		Price:       price,
+		Rate:        rate,
+		RateCount:   rateCount,
+	}
+
+	if err := product.validateDetails(); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
 func (p *Product) Validate() error {
 	if p.Id <= 0 {
 		return ErrProductIdInvalid
 	}
 
+	return p.validateDetails()
+}
+
+func (p *Product) validateDetails() error {
 	if p.Title == "" {
 		return ErrProductTitleEmpty
 	}
@@ -61,3 +92,24 @@ func (p *Product) Validate() error {
 
 	return nil
 }
+
+// ContentHash fingerprints the fields copied from an upstream catalog, so a
+// sync can skip products that did not change since the last run.
+func (p *Product) ContentHash() string {
+	hash := sha256.New()
+	for _, field := range []string{
+		strconv.FormatInt(p.Id, 10),
+		p.Title,
+		p.Description,
+		p.Category,
+		p.Image,
+		strconv.FormatFloat(p.Price, 'f', -1, 64),
+		strconv.FormatFloat(p.Rate, 'f', -1, 64),
+		strconv.FormatInt(p.RateCount, 10),
+	} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
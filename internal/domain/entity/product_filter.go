@@ -0,0 +1,18 @@
+package entity
+
+// ProductFilter is a catalog search run by the product provider itself.
+// Empty strings and nil ranges mean no filter.
+type ProductFilter struct {
+	Category  string
+	Search    string
+	MinPrice  *float64
+	MaxPrice  *float64
+	MinRating *float64
+	MaxRating *float64
+	// SortField is price, rating or rate_count, or empty to order by id. Ties
+	// are always broken by id.
+	SortField string
+	SortDesc  bool
+	Limit     int
+	Offset    int
+}
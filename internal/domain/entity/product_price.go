@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// PricePoint is the price a product had from RecordedAt until the next point.
+type PricePoint struct {
+	ProductId  int64
+	Price      float64
+	RecordedAt time.Time
+}
+
+func NewPricePoint(product *Product) *PricePoint {
+	return &PricePoint{
+		ProductId:  product.Id,
+		Price:      product.Price,
+		RecordedAt: time.Now(),
+	}
+}
+
+// PriceChange is a recorded price point and the price the product had right
+// before it. Previous is nil for the first price recorded for a product.
+type PriceChange struct {
+	Point    *PricePoint
+	Previous *float64
+}
+
+// IsDrop reports whether the product got cheaper. The first price recorded is
+// only a baseline.
+func (c *PriceChange) IsDrop() bool {
+	return c.Previous != nil && c.Point.Price < *c.Previous
+}
+
+// SamePrice reports whether a and b are the same amount in cents, so float
+// noise in the catalog is not taken for a price change.
+func SamePrice(a, b float64) bool {
+	return math.Round(a*100) == math.Round(b*100)
+}
+
+// PriceDrop tells a customer that a favorited product got cheaper.
+type PriceDrop struct {
+	CustomerId string
+	ProductId  int64
+	Title      string
+	OldPrice   float64
+	NewPrice   float64
+}
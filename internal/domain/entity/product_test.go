@@ -8,13 +8,15 @@ import (
 )
 
 func TestNewProduct_Success(t *testing.T) {
-	product, err := NewProduct(1, "Produto Teste", "https://placehold.co/600x400", 999.99, 4.5, 150)
+	product, err := NewProduct(1, "Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 4.5, 150)
 
 	require.NoError(t, err)
 	require.NotNil(t, product)
 
 	assert.Equal(t, int64(1), product.Id)
 	assert.Equal(t, "Produto Teste", product.Title)
+	assert.Equal(t, "Descrição do produto", product.Description)
+	assert.Equal(t, "electronics", product.Category)
 	assert.Equal(t, "https://placehold.co/600x400", product.Image)
 	assert.Equal(t, 999.99, product.Price)
 	assert.Equal(t, 4.5, product.Rate)
@@ -22,7 +24,7 @@ func TestNewProduct_Success(t *testing.T) {
 }
 
 func TestNewProduct_InvalidId(t *testing.T) {
-	product, err := NewProduct(0, "Produto Teste", "https://placehold.co/600x400", 999.99, 4.5, 150)
+	product, err := NewProduct(0, "Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 4.5, 150)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrProductIdInvalid, err)
@@ -30,7 +32,7 @@ func TestNewProduct_InvalidId(t *testing.T) {
 }
 
 func TestNewProduct_EmptyTitle(t *testing.T) {
-	product, err := NewProduct(1, "", "https://placehold.co/600x400", 999.99, 4.5, 150)
+	product, err := NewProduct(1, "", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 4.5, 150)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrProductTitleEmpty, err)
@@ -38,7 +40,7 @@ func TestNewProduct_EmptyTitle(t *testing.T) {
 }
 
 func TestNewProduct_EmptyImage(t *testing.T) {
-	product, err := NewProduct(1, "Produto Teste", "", 999.99, 4.5, 150)
+	product, err := NewProduct(1, "Produto Teste", "Descrição do produto", "electronics", "", 999.99, 4.5, 150)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrProductImageEmpty, err)
@@ -46,7 +48,7 @@ func TestNewProduct_EmptyImage(t *testing.T) {
 }
 
 func TestNewProduct_InvalidPrice(t *testing.T) {
-	product, err := NewProduct(1, "Produto Teste", "https://placehold.co/600x400", 0, 4.5, 150)
+	product, err := NewProduct(1, "Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 0, 4.5, 150)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrProductPriceInvalid, err)
@@ -54,7 +56,7 @@ func TestNewProduct_InvalidPrice(t *testing.T) {
 }
 
 func TestNewProduct_InvalidRate(t *testing.T) {
-	product, err := NewProduct(1, "Produto Teste", "https://placehold.co/600x400", 999.99, 6, 150)
+	product, err := NewProduct(1, "Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 6, 150)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrProductRateInvalid, err)
@@ -116,3 +118,40 @@ func TestProduct_Validate_RateBoundaries(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, ErrProductRateInvalid, err)
 }
+
+func TestNewProductWithoutId_Success(t *testing.T) {
+	product, err := NewProductWithoutId("Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 4.5, 150)
+
+	require.NoError(t, err)
+	require.NotNil(t, product)
+
+	assert.Equal(t, int64(0), product.Id)
+	assert.Equal(t, "Produto Teste", product.Title)
+	assert.Equal(t, "electronics", product.Category)
+	assert.Equal(t, 999.99, product.Price)
+}
+
+func TestNewProductWithoutId_InvalidPrice(t *testing.T) {
+	product, err := NewProductWithoutId("Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 0, 4.5, 150)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrProductPriceInvalid, err)
+	assert.Nil(t, product)
+}
+
+func TestProduct_ContentHash(t *testing.T) {
+	product, err := NewProduct(1, "Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 4.5, 150)
+	require.NoError(t, err)
+
+	same, err := NewProduct(1, "Produto Teste", "Descrição do produto", "electronics", "https://placehold.co/600x400", 999.99, 4.5, 150)
+	require.NoError(t, err)
+	assert.Equal(t, product.ContentHash(), same.ContentHash())
+	assert.Len(t, product.ContentHash(), 64)
+
+	same.Price = 899.99
+	assert.NotEqual(t, product.ContentHash(), same.ContentHash())
+
+	same.Price = product.Price
+	same.Category = "jewelery"
+	assert.NotEqual(t, product.ContentHash(), same.ContentHash())
+}
@@ -0,0 +1,10 @@
+package entity
+
+// Recommendation is a catalog product suggested to a customer. CoFavorites
+// counts the customers who favorited it alongside the customer's favorites and
+// CategoryAffinity how many of the customer's favorites share its category.
+type Recommendation struct {
+	Product          *Product
+	CoFavorites      int64
+	CategoryAffinity int
+}
@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+)
+
+var (
+	ErrWebhookIdEmpty       = errors.New("id cannot be empty")
+	ErrWebhookUrlEmpty      = errors.New("url cannot be empty")
+	ErrWebhookUrlInvalid    = errors.New("url must be an absolute http or https url")
+	ErrWebhookEventsEmpty   = errors.New("events cannot be empty")
+	ErrWebhookEventInvalid  = errors.New("events contains an unknown event type")
+	ErrWebhookSecretEmpty   = errors.New("secret cannot be empty")
+	ErrWebhookSecretTooWeak = errors.New("secret must have at least 16 characters")
+)
+
+type Webhook struct {
+	Id     string
+	Url    string
+	Events []string
+	Secret string
+	Active bool
+}
+
+// NewWebhook creates an active webhook, generating a random secret when none is given.
+func NewWebhook(url string, events []string, secret string) (*Webhook, error) {
+	id := uuid.Must(uuid.NewV7()).String()
+	if secret == "" {
+		secret = generateWebhookSecret()
+	}
+
+	return NewWebhookWithId(id, url, events, secret, true)
+}
+
+func NewWebhookWithId(id, url string, events []string, secret string, active bool) (*Webhook, error) {
+	var webhook = &Webhook{
+		Id:     id,
+		Url:    url,
+		Events: events,
+		Secret: secret,
+		Active: active,
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (w *Webhook) Validate() error {
+	if w.Id == "" {
+		return ErrWebhookIdEmpty
+	}
+
+	if w.Url == "" {
+		return ErrWebhookUrlEmpty
+	}
+
+	parsedUrl, err := url.Parse(w.Url)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return ErrWebhookUrlInvalid
+	}
+
+	if len(w.Events) == 0 {
+		return ErrWebhookEventsEmpty
+	}
+
+	for _, eventType := range w.Events {
+		if !event.IsValidType(eventType) {
+			return ErrWebhookEventInvalid
+		}
+	}
+
+	if w.Secret == "" {
+		return ErrWebhookSecretEmpty
+	}
+
+	if len(w.Secret) < 16 {
+		return ErrWebhookSecretTooWeak
+	}
+
+	return nil
+}
+
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func generateWebhookSecret() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return hex.EncodeToString(secret)
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+var (
+	ErrWebhookDeliveryIdEmpty        = errors.New("id cannot be empty")
+	ErrWebhookDeliveryWebhookIdEmpty = errors.New("webhook id cannot be empty")
+	ErrWebhookDeliveryEventIdEmpty   = errors.New("event id cannot be empty")
+	ErrWebhookDeliveryEventTypeEmpty = errors.New("event type cannot be empty")
+	ErrWebhookDeliveryPayloadEmpty   = errors.New("payload cannot be empty")
+	ErrWebhookDeliveryAttemptsEmpty  = errors.New("a pending delivery needs at least one attempt left")
+
+	ErrWebhookDeliveryAlreadyPending = errors.New("delivery is already pending")
+)
+
+// WebhookDelivery is both the log of an event sent to a webhook and, while
+// pending, its place in the retry queue: it is due at NextAttemptAt and tried
+// AttemptsLeft more times before being marked failed.
+type WebhookDelivery struct {
+	Id             string
+	WebhookId      string
+	EventId        string
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int
+	AttemptsLeft   int
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+	NextAttemptAt  *time.Time
+}
+
+// NewWebhookDelivery creates a delivery due right away, to be tried up to
+// maxAttempts times.
+func NewWebhookDelivery(webhookId, eventId, eventType string, payload []byte, maxAttempts int) (*WebhookDelivery, error) {
+	now := time.Now().UTC()
+	var delivery = &WebhookDelivery{
+		Id:            uuid.Must(uuid.NewV7()).String(),
+		WebhookId:     webhookId,
+		EventId:       eventId,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		AttemptsLeft:  maxAttempts,
+		CreatedAt:     now,
+		NextAttemptAt: &now,
+	}
+
+	if err := delivery.Validate(); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (d *WebhookDelivery) Validate() error {
+	if d.Id == "" {
+		return ErrWebhookDeliveryIdEmpty
+	}
+
+	if d.WebhookId == "" {
+		return ErrWebhookDeliveryWebhookIdEmpty
+	}
+
+	if d.EventId == "" {
+		return ErrWebhookDeliveryEventIdEmpty
+	}
+
+	if d.EventType == "" {
+		return ErrWebhookDeliveryEventTypeEmpty
+	}
+
+	if len(d.Payload) == 0 {
+		return ErrWebhookDeliveryPayloadEmpty
+	}
+
+	if d.Status == WebhookDeliveryPending && d.AttemptsLeft <= 0 {
+		return ErrWebhookDeliveryAttemptsEmpty
+	}
+
+	return nil
+}
+
+func (d *WebhookDelivery) IsPending() bool {
+	return d.Status == WebhookDeliveryPending
+}
+
+// RecordAttempt registers the outcome of a single delivery attempt.
+func (d *WebhookDelivery) RecordAttempt(responseStatus int, err error) {
+	d.Attempts++
+	d.AttemptsLeft = max(d.AttemptsLeft-1, 0)
+	d.ResponseStatus = responseStatus
+
+	if err != nil {
+		d.LastError = err.Error()
+		return
+	}
+
+	now := time.Now().UTC()
+	d.LastError = ""
+	d.Status = WebhookDeliverySucceeded
+	d.DeliveredAt = &now
+	d.NextAttemptAt = nil
+}
+
+// ScheduleAt sets when a pending delivery is due next.
+func (d *WebhookDelivery) ScheduleAt(at time.Time) {
+	at = at.UTC()
+	d.NextAttemptAt = &at
+}
+
+func (d *WebhookDelivery) MarkFailed() {
+	d.Status = WebhookDeliveryFailed
+	d.AttemptsLeft = 0
+	d.NextAttemptAt = nil
+}
+
+// Requeue makes a finished delivery due again with a fresh budget of attempts,
+// keeping its attempt history.
+func (d *WebhookDelivery) Requeue(maxAttempts int) {
+	now := time.Now().UTC()
+	d.Status = WebhookDeliveryPending
+	d.AttemptsLeft = maxAttempts
+	d.DeliveredAt = nil
+	d.NextAttemptAt = &now
+}
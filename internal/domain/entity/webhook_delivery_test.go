@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhookDelivery_Success(t *testing.T) {
+	delivery, err := NewWebhookDelivery("webhook-id", "event-id", "favorite.added", []byte(`{}`), 3)
+
+	require.NoError(t, err)
+	require.NotNil(t, delivery)
+
+	assert.NotEmpty(t, delivery.Id)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 0, delivery.Attempts)
+	assert.Nil(t, delivery.DeliveredAt)
+}
+
+func TestNewWebhookDelivery_EmptyPayload(t *testing.T) {
+	delivery, err := NewWebhookDelivery("webhook-id", "event-id", "favorite.added", nil, 3)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrWebhookDeliveryPayloadEmpty, err)
+	assert.Nil(t, delivery)
+}
+
+func TestWebhookDelivery_RecordAttempt(t *testing.T) {
+	delivery, err := NewWebhookDelivery("webhook-id", "event-id", "favorite.added", []byte(`{}`), 3)
+	require.NoError(t, err)
+
+	delivery.RecordAttempt(500, errors.New("unexpected status 500"))
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, 2, delivery.AttemptsLeft)
+	assert.Equal(t, 500, delivery.ResponseStatus)
+	assert.Equal(t, "unexpected status 500", delivery.LastError)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+
+	delivery.RecordAttempt(200, nil)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Empty(t, delivery.LastError)
+	assert.Equal(t, WebhookDeliverySucceeded, delivery.Status)
+	assert.NotNil(t, delivery.DeliveredAt)
+	assert.Nil(t, delivery.NextAttemptAt)
+
+	delivery.Requeue(3)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+	assert.Nil(t, delivery.DeliveredAt)
+	assert.NotNil(t, delivery.NextAttemptAt)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, 3, delivery.AttemptsLeft)
+}
+
+func TestNewWebhookDelivery_NoAttempts(t *testing.T) {
+	delivery, err := NewWebhookDelivery("webhook-id", "event-id", "favorite.added", []byte(`{}`), 0)
+
+	assert.Equal(t, ErrWebhookDeliveryAttemptsEmpty, err)
+	assert.Nil(t, delivery)
+}
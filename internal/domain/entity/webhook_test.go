@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhook_Success(t *testing.T) {
+	webhook, err := NewWebhook("https://example.com/hooks", []string{"favorite.added"}, "super-secret-value-123")
+
+	require.NoError(t, err)
+	require.NotNil(t, webhook)
+
+	assert.NotEmpty(t, webhook.Id)
+	assert.Equal(t, "https://example.com/hooks", webhook.Url)
+	assert.Equal(t, []string{"favorite.added"}, webhook.Events)
+	assert.Equal(t, "super-secret-value-123", webhook.Secret)
+	assert.True(t, webhook.Active)
+}
+
+func TestNewWebhook_GeneratesSecret(t *testing.T) {
+	webhook, err := NewWebhook("https://example.com/hooks", []string{"favorite.added"}, "")
+
+	require.NoError(t, err)
+	assert.Len(t, webhook.Secret, 64)
+}
+
+func TestNewWebhookWithId_EmptyId(t *testing.T) {
+	webhook, err := NewWebhookWithId("", "https://example.com/hooks", []string{"favorite.added"}, "super-secret-value-123", true)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrWebhookIdEmpty, err)
+	assert.Nil(t, webhook)
+}
+
+func TestNewWebhook_EmptyUrl(t *testing.T) {
+	webhook, err := NewWebhook("", []string{"favorite.added"}, "super-secret-value-123")
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrWebhookUrlEmpty, err)
+	assert.Nil(t, webhook)
+}
+
+func TestNewWebhook_InvalidUrl(t *testing.T) {
+	webhook, err := NewWebhook("ftp://example.com/hooks", []string{"favorite.added"}, "super-secret-value-123")
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrWebhookUrlInvalid, err)
+	assert.Nil(t, webhook)
+}
+
+func TestNewWebhook_EmptyEvents(t *testing.T) {
+	webhook, err := NewWebhook("https://example.com/hooks", []string{}, "super-secret-value-123")
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrWebhookEventsEmpty, err)
+	assert.Nil(t, webhook)
+}
+
+func TestNewWebhook_InvalidEvent(t *testing.T) {
+	webhook, err := NewWebhook("https://example.com/hooks", []string{"customer.deleted"}, "super-secret-value-123")
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrWebhookEventInvalid, err)
+	assert.Nil(t, webhook)
+}
+
+func TestNewWebhook_WeakSecret(t *testing.T) {
+	webhook, err := NewWebhook("https://example.com/hooks", []string{"favorite.added"}, "short")
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrWebhookSecretTooWeak, err)
+	assert.Nil(t, webhook)
+}
+
+func TestWebhook_Subscribes(t *testing.T) {
+	webhook, err := NewWebhook("https://example.com/hooks", []string{"favorite.added"}, "")
+	require.NoError(t, err)
+
+	assert.True(t, webhook.Subscribes("favorite.added"))
+	assert.False(t, webhook.Subscribes("favorite.removed"))
+}
@@ -0,0 +1,95 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	FavoriteAdded        Type = "favorite.added"
+	FavoriteRemoved      Type = "favorite.removed"
+	FavoritePriceDropped Type = "favorite.price_dropped"
+
+	// StreamReset tells a stream consumer that the events it missed are no
+	// longer available and it must reload the favorites. It is not delivered
+	// to webhooks.
+	StreamReset Type = "stream.reset"
+)
+
+var Types = []Type{
+	FavoriteAdded,
+	FavoriteRemoved,
+	FavoritePriceDropped,
+}
+
+type Event struct {
+	Id         string    `json:"id"`
+	Type       Type      `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+type FavoriteData struct {
+	CustomerId string `json:"customer_id"`
+	ProductId  int64  `json:"product_id"`
+}
+
+type PriceDropData struct {
+	CustomerId string  `json:"customer_id"`
+	ProductId  int64   `json:"product_id"`
+	Title      string  `json:"title"`
+	OldPrice   float64 `json:"old_price"`
+	NewPrice   float64 `json:"new_price"`
+}
+
+type StreamResetData struct {
+	CustomerId string `json:"customer_id"`
+}
+
+func NewEvent(eventType Type, data any) *Event {
+	return &Event{
+		Id:         uuid.Must(uuid.NewV7()).String(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+func NewFavoriteAddedEvent(customerId string, productId int64) *Event {
+	return NewEvent(FavoriteAdded, FavoriteData{CustomerId: customerId, ProductId: productId})
+}
+
+func NewFavoriteRemovedEvent(customerId string, productId int64) *Event {
+	return NewEvent(FavoriteRemoved, FavoriteData{CustomerId: customerId, ProductId: productId})
+}
+
+func NewFavoritePriceDroppedEvent(customerId string, productId int64, title string, oldPrice, newPrice float64) *Event {
+	return NewEvent(FavoritePriceDropped, PriceDropData{
+		CustomerId: customerId,
+		ProductId:  productId,
+		Title:      title,
+		OldPrice:   oldPrice,
+		NewPrice:   newPrice,
+	})
+}
+
+// NewStreamResetEvent takes the id of the newest event the stream has seen,
+// so a consumer that reconnects after the reset resumes from there.
+func NewStreamResetEvent(id, customerId string) *Event {
+	e := NewEvent(StreamReset, StreamResetData{CustomerId: customerId})
+	e.Id = id
+	return e
+}
+
+func IsValidType(eventType string) bool {
+	for _, t := range Types {
+		if string(t) == eventType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+package event
+
+import "context"
+
+type Publisher interface {
+	Publish(context.Context, *Event) error
+}
+
+// MultiPublisher fans an event out to every publisher, returning the first error found.
+type MultiPublisher []Publisher
+
+func (m MultiPublisher) Publish(ctx context.Context, e *Event) error {
+	var firstErr error
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, e); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+type Subscriber interface {
+	// Subscribe streams the events of a customer. Events published after
+	// lastEventId are replayed first when it is not empty; when they can no
+	// longer be replayed, a StreamReset event is sent instead. The returned
+	// function must be called to release the subscription.
+	Subscribe(customerId, lastEventId string) (<-chan *Event, func())
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+// ErrCatalogSyncInProgress is returned when another process is already syncing the catalog.
+var ErrCatalogSyncInProgress = errors.New("catalog sync already in progress")
+
+type CatalogRepository interface {
+	// Sync upserts the fetched products and discontinues the synced products
+	// missing from them in a single transaction, filling the run counters.
+	Sync(context.Context, []*entity.Product, *entity.CatalogSyncRun) error
+	CreateSyncRun(context.Context, *entity.CatalogSyncRun) error
+	FinishSyncRun(context.Context, *entity.CatalogSyncRun) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+var (
+	// ErrCollectionNameTaken is returned when the customer already has a collection with that name.
+	ErrCollectionNameTaken = errors.New("collection name already in use")
+	// ErrFavoriteAlreadyInCollection is returned when adding, moving or copying
+	// a product into a collection that already has it.
+	ErrFavoriteAlreadyInCollection = errors.New("product already in collection")
+)
+
+type CollectionRepository interface {
+	FindAllByCustomer(context.Context, *entity.Customer) ([]*entity.Collection, error)
+	FindById(ctx context.Context, customer *entity.Customer, id string) (*entity.Collection, error)
+	// FindOrCreateDefault returns the customer's default collection, creating it on first use.
+	FindOrCreateDefault(context.Context, *entity.Customer) (*entity.Collection, error)
+	Create(context.Context, *entity.Collection) error
+	Update(context.Context, *entity.Collection) error
+	Delete(context.Context, *entity.Collection) error
+
+	FindFavorites(context.Context, *entity.Collection) ([]*entity.Favorite, error)
+	// AddFavorite enforces limit like FavoritesRepository.AddToCustomer.
+	AddFavorite(ctx context.Context, collection *entity.Collection, favorite *entity.Favorite, limit int) error
+	// RemoveFavorite, MoveFavorite and CopyFavorite report whether the product
+	// was in the (source) collection.
+	RemoveFavorite(ctx context.Context, collection *entity.Collection, productId int64) (bool, error)
+	MoveFavorite(ctx context.Context, source, target *entity.Collection, productId int64) (bool, error)
+	CopyFavorite(ctx context.Context, source, target *entity.Collection, productId int64) (bool, error)
+}
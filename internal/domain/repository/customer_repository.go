@@ -1,10 +1,23 @@
 package repository
 
-import "github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
 
 type CustomerRepository interface {
-	FindById(id string) (*entity.Customer, error)
-	Create(*entity.Customer) (*entity.Customer, error)
-	Update(*entity.Customer) error
-	Delete(*entity.Customer) error
+	FindById(ctx context.Context, id string) (*entity.Customer, error)
+	FindByEmail(ctx context.Context, email string) (*entity.Customer, error)
+	Create(context.Context, *entity.Customer) (*entity.Customer, error)
+	// CreateMany saves the customers with their own ids, all or none, skipping
+	// those whose email is taken. It returns the emails skipped.
+	CreateMany(context.Context, []*entity.Customer) ([]string, error)
+	// FindPageWithFavoriteIds returns up to limit customers ordered by id,
+	// starting after afterId ("" starts from the first).
+	FindPageWithFavoriteIds(ctx context.Context, afterId string, limit int) ([]*entity.CustomerExport, error)
+	Update(context.Context, *entity.Customer) error
+	Delete(context.Context, *entity.Customer) error
+	// UpdateFavoritesQuota saves the customer's FavoritesQuota.
+	UpdateFavoritesQuota(context.Context, *entity.Customer) error
 }
@@ -1,9 +1,38 @@
 package repository
 
-import "github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+// ErrFavoritesLimitReached is returned when adding a favorite would take the
+// customer past their favorites limit.
+var ErrFavoritesLimitReached = errors.New("favorites limit reached")
 
 type FavoritesRepository interface {
-	FindAllByCustomer(*entity.Customer) ([]*int64, error)
-	AddToCustomer(*entity.Customer, *int64) error
-	RemoveFromCustomer(*entity.Customer, *int64) error
+	FindAllByCustomer(context.Context, *entity.Customer) ([]*entity.Favorite, error)
+	// AddToCustomer fails with ErrFavoritesLimitReached when the customer
+	// already favorited limit distinct products, counted across collections
+	// while holding a lock on the customer. A limit of zero means no limit.
+	AddToCustomer(ctx context.Context, customer *entity.Customer, favorite *entity.Favorite, limit int) error
+	// UpdateForCustomer saves the note, quantity and priority of a favorite and
+	// reports whether the product was in the customer's favorites.
+	UpdateForCustomer(context.Context, *entity.Customer, *entity.Favorite) (bool, error)
+	// RemoveFromCustomer reports whether the product was in the customer's favorites.
+	RemoveFromCustomer(context.Context, *entity.Customer, *int64) (bool, error)
+	// CountByCustomer counts the distinct products the customer favorited, across collections.
+	CountByCustomer(context.Context, *entity.Customer) (int64, error)
+	// CountByProduct, CountByProductIds and CountBySince count distinct
+	// customers, so a product in several collections of a customer counts once.
+	CountByProduct(context.Context) ([]*entity.FavoritedProduct, error)
+	// CountByProductIds omits products nobody favorited.
+	CountByProductIds(ctx context.Context, productIds []int64) (map[int64]int64, error)
+	// CountBySince counts favorites created from since on, most favorited
+	// first, returning at most limit products after skipping offset.
+	CountBySince(ctx context.Context, since time.Time, limit, offset int) ([]*entity.FavoritedProduct, error)
+	FindCustomerIdsByProduct(ctx context.Context, productId int64) ([]string, error)
+	RemoveByProducts(ctx context.Context, productIds []int64) (int64, error)
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+// ErrPriceTrackingInProgress is returned when another process is already recording prices.
+var ErrPriceTrackingInProgress = errors.New("price tracking already in progress")
+
+type PriceHistoryRepository interface {
+	// FindLatest returns the last recorded price of every tracked product, by product id.
+	FindLatest(context.Context) (map[int64]float64, error)
+	// Record stores the points whose price differs from the last recorded one
+	// in a single transaction. It returns the points it stored, each with the
+	// price it replaced as read inside that transaction.
+	Record(context.Context, []*entity.PricePoint) ([]*entity.PriceChange, error)
+	FindByProduct(ctx context.Context, productId int64) ([]*entity.PricePoint, error)
+}
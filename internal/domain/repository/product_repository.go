@@ -1,8 +1,43 @@
 package repository
 
-import "github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+var (
+	// ErrProductNotFound is returned by FindById when the product does not exist.
+	ErrProductNotFound = errors.New("product not found")
+	// ErrProductServiceUnavailable is returned when the product catalog cannot be
+	// reached, so callers can tell an outage apart from a missing product.
+	ErrProductServiceUnavailable = errors.New("product service unavailable")
+	// ErrProductCatalogReadOnly is returned when the configured provider cannot be edited.
+	ErrProductCatalogReadOnly = errors.New("product catalog is read-only")
+)
 
 type ProductRepository interface {
-	FindAll() ([]*entity.Product, error)
-	FindById(id int64) (*entity.Product, error)
+	FindAll(ctx context.Context) ([]*entity.Product, error)
+	FindById(ctx context.Context, id int64) (*entity.Product, error)
+	// FindByIds returns the products found among ids in one lookup, keyed by
+	// id; missing ids are left out.
+	FindByIds(ctx context.Context, ids []int64) (map[int64]*entity.Product, error)
+	FindByCategory(ctx context.Context, category string) ([]*entity.Product, error)
+	FindCategories(ctx context.Context) ([]string, error)
+}
+
+// ProductSearcher is implemented by the providers that can filter, sort and
+// page the catalog themselves.
+type ProductSearcher interface {
+	// Search returns one page of the matching products and how many matched.
+	Search(context.Context, *entity.ProductFilter) ([]*entity.Product, int, error)
+}
+
+// ProductWriter is implemented by the providers whose catalog can be edited
+// through the API.
+type ProductWriter interface {
+	Create(context.Context, *entity.Product) (*entity.Product, error)
+	Update(context.Context, *entity.Product) error
+	Delete(ctx context.Context, id int64) error
 }
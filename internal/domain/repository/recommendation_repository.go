@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+// ErrRecommendationsRefreshInProgress is returned when another process is already rebuilding the recommendations.
+var ErrRecommendationsRefreshInProgress = errors.New("recommendations refresh already in progress")
+
+type RecommendationRepository interface {
+	// Refresh rebuilds the co-favorite pairs from the favorites table and
+	// returns how many pairs were stored.
+	Refresh(context.Context) (int64, error)
+	// FindCoFavorited returns the products favorited by customers who share a
+	// favorite with the customer, as of the last refresh, most shared first.
+	// Favorites counts those customers; the customer's own favorites are left out.
+	FindCoFavorited(context.Context, *entity.Customer) ([]*entity.FavoritedProduct, error)
+	// FindFavoritedProductIds returns the customer's favorites across every collection.
+	FindFavoritedProductIds(context.Context, *entity.Customer) ([]int64, error)
+}
@@ -1,8 +1,14 @@
 package repository
 
-import "github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
 
 type UserRepository interface {
-	FindByEmail(email string) (*entity.User, error)
-	FindByID(id string) (*entity.User, error)
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	FindByID(ctx context.Context, id string) (*entity.User, error)
+	// Create saves the user as is, so Password must already be hashed.
+	Create(context.Context, *entity.User) error
 }
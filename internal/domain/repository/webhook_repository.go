@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type WebhookRepository interface {
+	FindAll(ctx context.Context) ([]*entity.Webhook, error)
+	FindById(ctx context.Context, id string) (*entity.Webhook, error)
+	FindActiveByEvent(ctx context.Context, eventType string) ([]*entity.Webhook, error)
+	Create(context.Context, *entity.Webhook) (*entity.Webhook, error)
+	Update(context.Context, *entity.Webhook) error
+	Delete(context.Context, *entity.Webhook) error
+}
+
+type WebhookDeliveryRepository interface {
+	FindById(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	FindAllByWebhook(context.Context, *entity.Webhook) ([]*entity.WebhookDelivery, error)
+	Create(context.Context, *entity.WebhookDelivery) error
+	Update(context.Context, *entity.WebhookDelivery) error
+	// ClaimDue takes up to limit pending deliveries due at now, skipping those
+	// claimed elsewhere, and holds them until leaseUntil.
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	// Requeue saves a delivery made pending again, unless it already was
+	// pending, in which case it reports false.
+	Requeue(context.Context, *entity.WebhookDelivery) (bool, error)
+}
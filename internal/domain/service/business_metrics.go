@@ -0,0 +1,8 @@
+package service
+
+type BusinessMetrics interface {
+	CustomerCreated()
+	FavoriteAdded()
+	FavoriteRemoved()
+	LoginFailed()
+}
@@ -0,0 +1,8 @@
+package service
+
+import "context"
+
+type HealthChecker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
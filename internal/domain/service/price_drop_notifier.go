@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type PriceDropNotifier interface {
+	NotifyPriceDrop(context.Context, *entity.PriceDrop) error
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+)
+
+type WebhookSender interface {
+	// Redeliver queues a finished delivery to be sent again. It fails with
+	// entity.ErrWebhookDeliveryAlreadyPending while the delivery is pending.
+	Redeliver(context.Context, *entity.Webhook, *entity.WebhookDelivery) error
+}
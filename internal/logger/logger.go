@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type contextKey struct{}
+
+// New builds the application logger. Level is one of debug, info, warn or
+// error and format is json or text.
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	var slogLevel slog.Level
+	if err := slogLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	options := &slog.HandlerOptions{Level: slogLevel}
+
+	switch strings.ToLower(format) {
+	case FormatJSON, "":
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+}
+
+// WithContext stores the logger in the context so the layers below can
+// retrieve it with FromContext.
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the request scoped logger, or the default one, with the
+// trace and span ids of the current span when there is one.
+func FromContext(ctx context.Context) *slog.Logger {
+	logger, ok := ctx.Value(contextKey{}).(*slog.Logger)
+	if !ok {
+		logger = slog.Default()
+	}
+
+	spanContext := trace.SpanContextFromContext(ctx)
+	if spanContext.IsValid() {
+		logger = logger.With(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+
+	return logger
+}
+
+// With returns a copy of ctx whose logger carries the given attributes.
+func With(ctx context.Context, args ...any) context.Context {
+	logger, ok := ctx.Value(contextKey{}).(*slog.Logger)
+	if !ok {
+		logger = slog.Default()
+	}
+
+	return WithContext(ctx, logger.With(args...))
+}
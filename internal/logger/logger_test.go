@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew_InvalidOptions(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "verbose", FormatJSON)
+	assert.Error(t, err)
+
+	_, err = New(&bytes.Buffer{}, "info", "xml")
+	assert.Error(t, err)
+}
+
+func TestNew_FiltersByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "warn", FormatJSON)
+	require.NoError(t, err)
+
+	logger.Info("ignored")
+	logger.Warn("kept")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "kept", entry["msg"])
+}
+
+func TestFromContext_DefaultsAndTraceIds(t *testing.T) {
+	assert.Equal(t, slog.Default(), FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", FormatJSON)
+	require.NoError(t, err)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(WithContext(context.Background(), logger), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	FromContext(ctx).Info("hello", slog.String("request_id", "abc"))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", entry["span_id"])
+	assert.Equal(t, "abc", entry["request_id"])
+}
@@ -1,17 +1,22 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type LoginUseCase struct {
-	UserRepository repository.UserRepository
-	JWTSecret      string
+	UserRepository  repository.UserRepository
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	Metrics         service.BusinessMetrics
 }
 
 type LoginResponse struct {
@@ -26,25 +31,35 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func NewLoginUseCase(userRepo repository.UserRepository, jwtSecret string) *LoginUseCase {
+func NewLoginUseCase(
+	userRepo repository.UserRepository,
+	jwtSecret string,
+	accessTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+	metrics service.BusinessMetrics,
+) *LoginUseCase {
 	return &LoginUseCase{
-		UserRepository: userRepo,
-		JWTSecret:      jwtSecret,
+		UserRepository:  userRepo,
+		JWTSecret:       jwtSecret,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+		Metrics:         metrics,
 	}
 }
 
-func (u *LoginUseCase) Execute(email, password string) (*LoginResponse, error) {
-	user, err := u.UserRepository.FindByEmail(email)
-	if err != nil {
-		return nil, errors.New("invalid credentials")
-	}
+func (u *LoginUseCase) Execute(ctx context.Context, email, password string) (*LoginResponse, error) {
+	ctx, span := tracer.Start(ctx, "LoginUseCase.Execute")
+	defer span.End()
 
-	if user == nil {
+	user, err := u.UserRepository.FindByEmail(ctx, email)
+	if err != nil || user == nil {
+		u.Metrics.LoginFailed()
 		return nil, errors.New("invalid credentials")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
+		u.Metrics.LoginFailed()
 		return nil, errors.New("invalid credentials")
 	}
 
@@ -61,7 +76,7 @@ func (u *LoginUseCase) Execute(email, password string) (*LoginResponse, error) {
 	return &LoginResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		ExpiresIn:    900,
+		ExpiresIn:    int(u.AccessTokenTTL.Seconds()),
 	}, nil
 }
 
@@ -70,7 +85,7 @@ func (u *LoginUseCase) generateAccessToken(userID, email string) (string, error)
 		UserID: userID,
 		Email:  email,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(u.AccessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "aiqfome-challenge",
 		},
@@ -83,7 +98,7 @@ func (u *LoginUseCase) generateAccessToken(userID, email string) (string, error)
 func (u *LoginUseCase) generateRefreshToken(userID string) (string, error) {
 	claims := jwt.RegisteredClaims{
 		Subject:   userID,
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(u.RefreshTokenTTL)),
 		IssuedAt:  jwt.NewNumericDate(time.Now()),
 		Issuer:    "aiqfome-challenge",
 	}
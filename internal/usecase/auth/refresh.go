@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
@@ -10,6 +12,7 @@ import (
 type RefreshTokenUseCase struct {
 	UserRepository repository.UserRepository
 	JWTSecret      string
+	AccessTokenTTL time.Duration
 }
 
 type RefreshTokenResponse struct {
@@ -17,14 +20,18 @@ type RefreshTokenResponse struct {
 	ExpiresIn   int
 }
 
-func NewRefreshTokenUseCase(userRepo repository.UserRepository, jwtSecret string) *RefreshTokenUseCase {
+func NewRefreshTokenUseCase(userRepo repository.UserRepository, jwtSecret string, accessTokenTTL time.Duration) *RefreshTokenUseCase {
 	return &RefreshTokenUseCase{
 		UserRepository: userRepo,
 		JWTSecret:      jwtSecret,
+		AccessTokenTTL: accessTokenTTL,
 	}
 }
 
-func (u *RefreshTokenUseCase) Execute(refreshToken string) (*RefreshTokenResponse, error) {
+func (u *RefreshTokenUseCase) Execute(ctx context.Context, refreshToken string) (*RefreshTokenResponse, error) {
+	ctx, span := tracer.Start(ctx, "RefreshTokenUseCase.Execute")
+	defer span.End()
+
 	claims := &jwt.RegisteredClaims{}
 	token, err := jwt.ParseWithClaims(refreshToken, claims, func(token *jwt.Token) (interface{}, error) {
 		return []byte(u.JWTSecret), nil
@@ -39,7 +46,7 @@ func (u *RefreshTokenUseCase) Execute(refreshToken string) (*RefreshTokenRespons
 		return nil, errors.New("invalid refresh token")
 	}
 
-	user, err := u.UserRepository.FindByID(userID)
+	user, err := u.UserRepository.FindByID(ctx, userID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
@@ -51,6 +58,7 @@ func (u *RefreshTokenUseCase) Execute(refreshToken string) (*RefreshTokenRespons
 	loginUseCase := &LoginUseCase{
 		UserRepository: u.UserRepository,
 		JWTSecret:      u.JWTSecret,
+		AccessTokenTTL: u.AccessTokenTTL,
 	}
 
 	accessToken, err := loginUseCase.generateAccessToken(user.Id, user.Email)
@@ -60,6 +68,6 @@ func (u *RefreshTokenUseCase) Execute(refreshToken string) (*RefreshTokenRespons
 
 	return &RefreshTokenResponse{
 		AccessToken: accessToken,
-		ExpiresIn:   900,
+		ExpiresIn:   int(u.AccessTokenTTL.Seconds()),
 	}, nil
 }
@@ -0,0 +1,86 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+// ErrEmptyCatalog keeps a broken upstream response from discontinuing every product.
+var ErrEmptyCatalog = errors.New("upstream catalog returned no products")
+
+type SyncCatalogUseCase struct {
+	Source  repository.ProductRepository
+	Catalog repository.CatalogRepository
+}
+
+func NewSyncCatalogUseCase(source repository.ProductRepository, catalog repository.CatalogRepository) *SyncCatalogUseCase {
+	return &SyncCatalogUseCase{
+		Source:  source,
+		Catalog: catalog,
+	}
+}
+
+func (s *SyncCatalogUseCase) Execute(ctx context.Context) (*entity.CatalogSyncRun, error) {
+	ctx, span := tracer.Start(ctx, "SyncCatalogUseCase.Execute")
+	defer span.End()
+
+	run := entity.NewCatalogSyncRun()
+	if err := s.Catalog.CreateSyncRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	err := s.sync(ctx, run)
+	if err != nil {
+		run.Fail(err)
+	} else {
+		run.Succeed()
+	}
+
+	// The run is recorded even when the sync was cancelled halfway.
+	if finishErr := s.Catalog.FinishSyncRun(context.WithoutCancel(ctx), run); finishErr != nil {
+		logger.FromContext(ctx).Error("failed to record catalog sync run", slog.Int64("run_id", run.Id), slog.Any("error", finishErr))
+	}
+
+	if err != nil {
+		return run, err
+	}
+
+	logger.FromContext(ctx).Info("catalog synced",
+		slog.Int64("run_id", run.Id),
+		slog.Int("fetched", run.Fetched),
+		slog.Int("inserted", run.Inserted),
+		slog.Int("updated", run.Updated),
+		slog.Int("unchanged", run.Unchanged),
+		slog.Int("discontinued", run.Discontinued),
+		slog.Int("conflicts", len(run.Conflicts)),
+	)
+
+	if len(run.Conflicts) > 0 {
+		logger.FromContext(ctx).Warn("upstream products skipped: their ids belong to products created through the API",
+			slog.Int64("run_id", run.Id),
+			slog.Any("product_ids", run.Conflicts),
+		)
+	}
+
+	return run, nil
+}
+
+func (s *SyncCatalogUseCase) sync(ctx context.Context, run *entity.CatalogSyncRun) error {
+	products, err := s.Source.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("error while fetching upstream catalog: %w", err)
+	}
+
+	run.Fetched = len(products)
+	if len(products) == 0 {
+		return ErrEmptyCatalog
+	}
+
+	return s.Catalog.Sync(ctx, products, run)
+}
@@ -0,0 +1,5 @@
+package catalog
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("github.com/juliocsrf/aiqfome-challenge/internal/usecase/catalog")
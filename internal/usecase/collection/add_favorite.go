@@ -0,0 +1,79 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+)
+
+type AddFavoriteCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+	ProductRepository    repository.ProductRepository
+	EventPublisher       event.Publisher
+	Metrics              service.BusinessMetrics
+	// FavoritesLimit is the default maximum of favorites per customer.
+	FavoritesLimit int
+}
+
+func NewAddFavoriteCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository, productRepository repository.ProductRepository, eventPublisher event.Publisher, metrics service.BusinessMetrics, favoritesLimit int) *AddFavoriteCollectionUseCase {
+	return &AddFavoriteCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+		ProductRepository:    productRepository,
+		EventPublisher:       eventPublisher,
+		Metrics:              metrics,
+		FavoritesLimit:       favoritesLimit,
+	}
+}
+
+func (u *AddFavoriteCollectionUseCase) Execute(ctx context.Context, customerId, collectionId string, productId int64) error {
+	ctx, span := tracer.Start(ctx, "AddFavoriteCollectionUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return err
+	}
+
+	if customer == nil {
+		return errors.New("customer not found")
+	}
+
+	collection, err := u.CollectionRepository.FindById(ctx, customer, collectionId)
+	if err != nil {
+		return err
+	}
+
+	if collection == nil {
+		return errors.New("collection not found")
+	}
+
+	product, err := u.ProductRepository.FindById(ctx, productId)
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return errors.New("product not found")
+	}
+
+	limit := customer.FavoritesLimit(u.FavoritesLimit)
+	err = u.CollectionRepository.AddFavorite(ctx, collection, entity.NewFavorite(product), limit)
+	if errors.Is(err, repository.ErrFavoritesLimitReached) {
+		return fmt.Errorf("%w: customer can have at most %d favorites", err, limit)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	favoriteAdded(ctx, u.EventPublisher, u.Metrics, collection, product.Id)
+
+	return nil
+}
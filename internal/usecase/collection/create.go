@@ -0,0 +1,57 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type CreateCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+}
+
+func NewCreateCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository) *CreateCollectionUseCase {
+	return &CreateCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+	}
+}
+
+func (u *CreateCollectionUseCase) Execute(ctx context.Context, customerId, name string) (*entity.Collection, error) {
+	ctx, span := tracer.Start(ctx, "CreateCollectionUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, errors.New("customer not found")
+	}
+
+	collection, err := entity.NewCollection(customer.Id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDefaultName(collection.Name) {
+		return nil, repository.ErrCollectionNameTaken
+	}
+
+	if err := u.CollectionRepository.Create(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// isDefaultName reports whether name is reserved for the default collection,
+// which may not have been created yet.
+func isDefaultName(name string) bool {
+	return strings.EqualFold(name, entity.DefaultCollectionName)
+}
@@ -0,0 +1,55 @@
+package collection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCollectionUseCase_Success(t *testing.T) {
+	customers, collections := newStubs()
+	useCase := NewCreateCollectionUseCase(customers, collections)
+
+	collection, err := useCase.Execute(context.Background(), "customer-1", "Mercado do mês")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Mercado do mês", collection.Name)
+	assert.False(t, collection.IsDefault)
+	assert.Len(t, collections.collections, 3)
+}
+
+func TestCreateCollectionUseCase_NameTaken(t *testing.T) {
+	customers, collections := newStubs()
+	useCase := NewCreateCollectionUseCase(customers, collections)
+
+	for _, name := range []string{"Presentes", "favoritos"} {
+		collection, err := useCase.Execute(context.Background(), "customer-1", name)
+
+		assert.ErrorIs(t, err, repository.ErrCollectionNameTaken, name)
+		assert.Nil(t, collection)
+	}
+}
+
+func TestCreateCollectionUseCase_Invalid(t *testing.T) {
+	customers, collections := newStubs()
+	useCase := NewCreateCollectionUseCase(customers, collections)
+
+	_, err := useCase.Execute(context.Background(), "customer-1", " ")
+	assert.ErrorIs(t, err, entity.ErrCollectionNameEmpty)
+
+	_, err = useCase.Execute(context.Background(), "unknown", "Presentes")
+	assert.EqualError(t, err, "customer not found")
+}
+
+func TestDeleteCollectionUseCase_DefaultCollection(t *testing.T) {
+	customers, collections := newStubs()
+	useCase := NewDeleteCollectionUseCase(customers, collections)
+
+	assert.ErrorIs(t, useCase.Execute(context.Background(), "customer-1", "default"), ErrDefaultCollection)
+	require.NoError(t, useCase.Execute(context.Background(), "customer-1", "gifts"))
+	assert.Len(t, collections.collections, 1)
+}
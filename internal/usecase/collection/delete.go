@@ -0,0 +1,36 @@
+package collection
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type DeleteCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+}
+
+func NewDeleteCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository) *DeleteCollectionUseCase {
+	return &DeleteCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+	}
+}
+
+// Execute deletes the collection together with its favorites.
+func (u *DeleteCollectionUseCase) Execute(ctx context.Context, customerId, collectionId string) error {
+	ctx, span := tracer.Start(ctx, "DeleteCollectionUseCase.Execute")
+	defer span.End()
+
+	collection, err := findCollection(ctx, u.CustomerRepository, u.CollectionRepository, customerId, collectionId)
+	if err != nil {
+		return err
+	}
+
+	if collection.IsDefault {
+		return ErrDefaultCollection
+	}
+
+	return u.CollectionRepository.Delete(ctx, collection)
+}
@@ -0,0 +1,53 @@
+package collection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// ErrDefaultCollection is returned when renaming or deleting the default collection.
+var ErrDefaultCollection = errors.New("default collection cannot be renamed or deleted")
+
+type EditCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+}
+
+func NewEditCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository) *EditCollectionUseCase {
+	return &EditCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+	}
+}
+
+func (u *EditCollectionUseCase) Execute(ctx context.Context, customerId, collectionId, name string) (*entity.Collection, error) {
+	ctx, span := tracer.Start(ctx, "EditCollectionUseCase.Execute")
+	defer span.End()
+
+	collection, err := findCollection(ctx, u.CustomerRepository, u.CollectionRepository, customerId, collectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.IsDefault {
+		return nil, ErrDefaultCollection
+	}
+
+	collection, err = entity.NewCollectionWithId(collection.Id, collection.CustomerId, name, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if isDefaultName(collection.Name) {
+		return nil, repository.ErrCollectionNameTaken
+	}
+
+	if err := u.CollectionRepository.Update(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,40 @@
+package collection
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+// The default collection is the customer's favorites list, so adding to or
+// removing from it is reported like the favorite use cases do. The other
+// collections only organize favorites and stay quiet.
+
+func favoriteAdded(ctx context.Context, publisher event.Publisher, metrics service.BusinessMetrics, collection *entity.Collection, productId int64) {
+	if !collection.IsDefault {
+		return
+	}
+
+	metrics.FavoriteAdded()
+
+	// The favorite is already persisted; a failed notification must not fail the request.
+	if err := publisher.Publish(ctx, event.NewFavoriteAddedEvent(collection.CustomerId, productId)); err != nil {
+		logger.FromContext(ctx).Warn("publishing favorite event", slog.String("customer_id", collection.CustomerId), slog.Any("error", err))
+	}
+}
+
+func favoriteRemoved(ctx context.Context, publisher event.Publisher, metrics service.BusinessMetrics, collection *entity.Collection, productId int64) {
+	if !collection.IsDefault {
+		return
+	}
+
+	metrics.FavoriteRemoved()
+
+	if err := publisher.Publish(ctx, event.NewFavoriteRemovedEvent(collection.CustomerId, productId)); err != nil {
+		logger.FromContext(ctx).Warn("publishing favorite event", slog.String("customer_id", collection.CustomerId), slog.Any("error", err))
+	}
+}
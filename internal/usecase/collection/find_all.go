@@ -0,0 +1,42 @@
+package collection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type FindAllCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+}
+
+func NewFindAllCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository) *FindAllCollectionUseCase {
+	return &FindAllCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+	}
+}
+
+func (u *FindAllCollectionUseCase) Execute(ctx context.Context, customerId string) ([]*entity.Collection, error) {
+	ctx, span := tracer.Start(ctx, "FindAllCollectionUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, errors.New("customer not found")
+	}
+
+	// The default collection is listed even before the first favorite is added.
+	if _, err := u.CollectionRepository.FindOrCreateDefault(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	return u.CollectionRepository.FindAllByCustomer(ctx, customer)
+}
@@ -0,0 +1,96 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+type FindByIdCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+	ProductRepository    repository.ProductRepository
+}
+
+func NewFindByIdCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository, productRepository repository.ProductRepository) *FindByIdCollectionUseCase {
+	return &FindByIdCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+		ProductRepository:    productRepository,
+	}
+}
+
+// Execute returns the collection with its favorites, each one enriched with
+// the current catalog entry like the customer's favorites list and sorted by
+// favoritesSort (see entity.SortFavorites).
+func (u *FindByIdCollectionUseCase) Execute(ctx context.Context, customerId, collectionId, favoritesSort string) (*entity.Collection, error) {
+	ctx, span := tracer.Start(ctx, "FindByIdCollectionUseCase.Execute")
+	defer span.End()
+
+	if err := entity.ValidateFavoriteSort(favoritesSort); err != nil {
+		return nil, err
+	}
+
+	collection, err := findCollection(ctx, u.CustomerRepository, u.CollectionRepository, customerId, collectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites, err := u.CollectionRepository.FindFavorites(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, favorite := range favorites {
+		product, err := u.ProductRepository.FindById(ctx, favorite.ProductId)
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			favorite.Available = false
+		case err != nil:
+			logger.FromContext(ctx).Warn("loading favorite product", slog.Int64("product_id", favorite.ProductId), slog.Any("error", err))
+			favorite.Available = true
+		default:
+			favorite.Available = true
+			favorite.SetProduct(product)
+		}
+	}
+
+	if err := entity.SortFavorites(favorites, favoritesSort); err != nil {
+		return nil, err
+	}
+
+	if favorites != nil {
+		collection.Favorites = favorites
+	}
+	collection.FavoritesCount = int64(len(collection.Favorites))
+
+	return collection, nil
+}
+
+// findCollection loads one of the customer's collections, failing when the
+// customer or the collection does not exist.
+func findCollection(ctx context.Context, customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository, customerId, collectionId string) (*entity.Collection, error) {
+	customer, err := customerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, errors.New("customer not found")
+	}
+
+	collection, err := collectionRepository.FindById(ctx, customer, collectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection == nil {
+		return nil, errors.New("collection not found")
+	}
+
+	return collection, nil
+}
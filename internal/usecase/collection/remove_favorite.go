@@ -0,0 +1,54 @@
+package collection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+)
+
+// ErrFavoriteNotInCollection is returned when the product is not in the (source) collection.
+var ErrFavoriteNotInCollection = errors.New("product not in collection")
+
+type RemoveFavoriteCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+	EventPublisher       event.Publisher
+	Metrics              service.BusinessMetrics
+}
+
+func NewRemoveFavoriteCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository, eventPublisher event.Publisher, metrics service.BusinessMetrics) *RemoveFavoriteCollectionUseCase {
+	return &RemoveFavoriteCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+		EventPublisher:       eventPublisher,
+		Metrics:              metrics,
+	}
+}
+
+// Execute removes the product from the collection without a catalog lookup,
+// so favorites of discontinued products can still be removed.
+func (u *RemoveFavoriteCollectionUseCase) Execute(ctx context.Context, customerId, collectionId string, productId int64) error {
+	ctx, span := tracer.Start(ctx, "RemoveFavoriteCollectionUseCase.Execute")
+	defer span.End()
+
+	collection, err := findCollection(ctx, u.CustomerRepository, u.CollectionRepository, customerId, collectionId)
+	if err != nil {
+		return err
+	}
+
+	removed, err := u.CollectionRepository.RemoveFavorite(ctx, collection, productId)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return ErrFavoriteNotInCollection
+	}
+
+	favoriteRemoved(ctx, u.EventPublisher, u.Metrics, collection, productId)
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package collection
+
+import (
+	"context"
+	"slices"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+)
+
+type stubCustomerRepository struct {
+	repository.CustomerRepository
+	customers map[string]*entity.Customer
+}
+
+func (s *stubCustomerRepository) FindById(ctx context.Context, id string) (*entity.Customer, error) {
+	return s.customers[id], nil
+}
+
+// stubCollectionRepository keeps collections and their favorites in memory.
+type stubCollectionRepository struct {
+	collections []*entity.Collection
+}
+
+func (s *stubCollectionRepository) FindAllByCustomer(ctx context.Context, customer *entity.Customer) ([]*entity.Collection, error) {
+	var collections []*entity.Collection
+	for _, collection := range s.collections {
+		if collection.CustomerId == customer.Id {
+			collections = append(collections, collection)
+		}
+	}
+
+	return collections, nil
+}
+
+func (s *stubCollectionRepository) FindById(ctx context.Context, customer *entity.Customer, id string) (*entity.Collection, error) {
+	for _, collection := range s.collections {
+		if collection.Id == id && collection.CustomerId == customer.Id {
+			return collection, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *stubCollectionRepository) FindOrCreateDefault(ctx context.Context, customer *entity.Customer) (*entity.Collection, error) {
+	for _, collection := range s.collections {
+		if collection.IsDefault && collection.CustomerId == customer.Id {
+			return collection, nil
+		}
+	}
+
+	collection, _ := entity.NewCollectionWithId("default-"+customer.Id, customer.Id, entity.DefaultCollectionName, true)
+	s.collections = append(s.collections, collection)
+	return collection, nil
+}
+
+func (s *stubCollectionRepository) Create(ctx context.Context, collection *entity.Collection) error {
+	for _, existing := range s.collections {
+		if existing.CustomerId == collection.CustomerId && existing.Name == collection.Name {
+			return repository.ErrCollectionNameTaken
+		}
+	}
+
+	s.collections = append(s.collections, collection)
+	return nil
+}
+
+func (s *stubCollectionRepository) Update(ctx context.Context, collection *entity.Collection) error {
+	return nil
+}
+
+func (s *stubCollectionRepository) Delete(ctx context.Context, collection *entity.Collection) error {
+	s.collections = slices.DeleteFunc(s.collections, func(existing *entity.Collection) bool {
+		return existing.Id == collection.Id
+	})
+	return nil
+}
+
+func (s *stubCollectionRepository) FindFavorites(ctx context.Context, collection *entity.Collection) ([]*entity.Favorite, error) {
+	return collection.Favorites, nil
+}
+
+func (s *stubCollectionRepository) AddFavorite(ctx context.Context, collection *entity.Collection, favorite *entity.Favorite, limit int) error {
+	if s.contains(collection, favorite.ProductId) {
+		return repository.ErrFavoriteAlreadyInCollection
+	}
+
+	collection.Favorites = append(collection.Favorites, favorite)
+	return nil
+}
+
+func (s *stubCollectionRepository) RemoveFavorite(ctx context.Context, collection *entity.Collection, productId int64) (bool, error) {
+	before := len(collection.Favorites)
+	collection.Favorites = slices.DeleteFunc(collection.Favorites, func(favorite *entity.Favorite) bool {
+		return favorite.ProductId == productId
+	})
+
+	return len(collection.Favorites) < before, nil
+}
+
+func (s *stubCollectionRepository) MoveFavorite(ctx context.Context, source, target *entity.Collection, productId int64) (bool, error) {
+	copied, err := s.CopyFavorite(ctx, source, target, productId)
+	if err != nil || !copied {
+		return copied, err
+	}
+
+	return s.RemoveFavorite(ctx, source, productId)
+}
+
+func (s *stubCollectionRepository) CopyFavorite(ctx context.Context, source, target *entity.Collection, productId int64) (bool, error) {
+	index := slices.IndexFunc(source.Favorites, func(favorite *entity.Favorite) bool {
+		return favorite.ProductId == productId
+	})
+	if index < 0 {
+		return false, nil
+	}
+
+	if s.contains(target, productId) {
+		return false, repository.ErrFavoriteAlreadyInCollection
+	}
+
+	favorite := *source.Favorites[index]
+	target.Favorites = append(target.Favorites, &favorite)
+	return true, nil
+}
+
+func (s *stubCollectionRepository) contains(collection *entity.Collection, productId int64) bool {
+	return slices.ContainsFunc(collection.Favorites, func(favorite *entity.Favorite) bool {
+		return favorite.ProductId == productId
+	})
+}
+
+type stubPublisher struct {
+	events []*event.Event
+}
+
+func (s *stubPublisher) Publish(ctx context.Context, e *event.Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+type stubMetrics struct {
+	service.BusinessMetrics
+	added   int
+	removed int
+}
+
+func (s *stubMetrics) FavoriteAdded()   { s.added++ }
+func (s *stubMetrics) FavoriteRemoved() { s.removed++ }
+
+// newStubs returns a customer with the default collection holding product 1
+// and an empty "Presentes" collection.
+func newStubs() (*stubCustomerRepository, *stubCollectionRepository) {
+	customer := &entity.Customer{Id: "customer-1"}
+	defaultCollection, _ := entity.NewCollectionWithId("default", customer.Id, entity.DefaultCollectionName, true)
+	defaultCollection.Favorites = []*entity.Favorite{{ProductId: 1, Title: "Backpack", Price: 109.95}}
+	gifts, _ := entity.NewCollectionWithId("gifts", customer.Id, "Presentes", false)
+
+	customers := &stubCustomerRepository{customers: map[string]*entity.Customer{customer.Id: customer}}
+	collections := &stubCollectionRepository{collections: []*entity.Collection{defaultCollection, gifts}}
+
+	return customers, collections
+}
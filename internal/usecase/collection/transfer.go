@@ -0,0 +1,82 @@
+package collection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+)
+
+// ErrSameCollection is returned when moving or copying a favorite into the collection it is in.
+var ErrSameCollection = errors.New("target collection must differ from the source collection")
+
+// TransferMode says whether a transferred favorite leaves the source collection.
+type TransferMode string
+
+const (
+	TransferMove TransferMode = "move"
+	TransferCopy TransferMode = "copy"
+)
+
+type TransferFavoriteCollectionUseCase struct {
+	CustomerRepository   repository.CustomerRepository
+	CollectionRepository repository.CollectionRepository
+	EventPublisher       event.Publisher
+	Metrics              service.BusinessMetrics
+}
+
+func NewTransferFavoriteCollectionUseCase(customerRepository repository.CustomerRepository, collectionRepository repository.CollectionRepository, eventPublisher event.Publisher, metrics service.BusinessMetrics) *TransferFavoriteCollectionUseCase {
+	return &TransferFavoriteCollectionUseCase{
+		CustomerRepository:   customerRepository,
+		CollectionRepository: collectionRepository,
+		EventPublisher:       eventPublisher,
+		Metrics:              metrics,
+	}
+}
+
+// Execute moves or copies a favorite between two collections of the same
+// customer. The favorite keeps the snapshot taken when it was first added.
+func (u *TransferFavoriteCollectionUseCase) Execute(ctx context.Context, mode TransferMode, customerId, sourceId, targetId string, productId int64) error {
+	ctx, span := tracer.Start(ctx, "TransferFavoriteCollectionUseCase.Execute")
+	defer span.End()
+
+	source, err := findCollection(ctx, u.CustomerRepository, u.CollectionRepository, customerId, sourceId)
+	if err != nil {
+		return err
+	}
+
+	target, err := findCollection(ctx, u.CustomerRepository, u.CollectionRepository, customerId, targetId)
+	if err != nil {
+		return err
+	}
+
+	if source.Id == target.Id {
+		return ErrSameCollection
+	}
+
+	var transferred bool
+	switch mode {
+	case TransferMove:
+		transferred, err = u.CollectionRepository.MoveFavorite(ctx, source, target, productId)
+	case TransferCopy:
+		transferred, err = u.CollectionRepository.CopyFavorite(ctx, source, target, productId)
+	default:
+		return errors.New("unknown transfer mode")
+	}
+	if err != nil {
+		return err
+	}
+
+	if !transferred {
+		return ErrFavoriteNotInCollection
+	}
+
+	if mode == TransferMove {
+		favoriteRemoved(ctx, u.EventPublisher, u.Metrics, source, productId)
+	}
+	favoriteAdded(ctx, u.EventPublisher, u.Metrics, target, productId)
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package collection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferFavoriteCollectionUseCase_Move(t *testing.T) {
+	customers, collections := newStubs()
+	useCase := NewTransferFavoriteCollectionUseCase(customers, collections, &stubPublisher{}, &stubMetrics{})
+
+	err := useCase.Execute(context.Background(), TransferMove, "customer-1", "default", "gifts", 1)
+
+	require.NoError(t, err)
+	assert.Empty(t, collections.collections[0].Favorites)
+	require.Len(t, collections.collections[1].Favorites, 1)
+	assert.Equal(t, "Backpack", collections.collections[1].Favorites[0].Title)
+}
+
+func TestTransferFavoriteCollectionUseCase_Copy(t *testing.T) {
+	customers, collections := newStubs()
+	useCase := NewTransferFavoriteCollectionUseCase(customers, collections, &stubPublisher{}, &stubMetrics{})
+
+	require.NoError(t, useCase.Execute(context.Background(), TransferCopy, "customer-1", "default", "gifts", 1))
+	assert.Len(t, collections.collections[0].Favorites, 1)
+	assert.Len(t, collections.collections[1].Favorites, 1)
+
+	err := useCase.Execute(context.Background(), TransferCopy, "customer-1", "default", "gifts", 1)
+	assert.ErrorIs(t, err, repository.ErrFavoriteAlreadyInCollection)
+}
+
+func TestTransferFavoriteCollectionUseCase_ReportsDefaultCollectionChanges(t *testing.T) {
+	customers, collections := newStubs()
+	publisher := &stubPublisher{}
+	metrics := &stubMetrics{}
+	useCase := NewTransferFavoriteCollectionUseCase(customers, collections, publisher, metrics)
+
+	require.NoError(t, useCase.Execute(context.Background(), TransferMove, "customer-1", "default", "gifts", 1))
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, event.FavoriteRemoved, publisher.events[0].Type)
+
+	require.NoError(t, useCase.Execute(context.Background(), TransferCopy, "customer-1", "gifts", "default", 1))
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, event.FavoriteAdded, publisher.events[1].Type)
+	assert.Equal(t, 1, metrics.added)
+	assert.Equal(t, 1, metrics.removed)
+}
+
+func TestTransferFavoriteCollectionUseCase_Errors(t *testing.T) {
+	tests := map[string]struct {
+		sourceId  string
+		targetId  string
+		productId int64
+		err       string
+	}{
+		"same collection":      {sourceId: "default", targetId: "default", productId: 1, err: ErrSameCollection.Error()},
+		"not in source":        {sourceId: "gifts", targetId: "default", productId: 1, err: ErrFavoriteNotInCollection.Error()},
+		"unknown target":       {sourceId: "default", targetId: "unknown", productId: 1, err: "collection not found"},
+		"other customer's one": {sourceId: "default", targetId: "other", productId: 1, err: "collection not found"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			customers, collections := newStubs()
+			collections.collections = append(collections.collections, &entity.Collection{Id: "other", CustomerId: "customer-2", Name: "Presentes"})
+			useCase := NewTransferFavoriteCollectionUseCase(customers, collections, &stubPublisher{}, &stubMetrics{})
+
+			err := useCase.Execute(context.Background(), TransferMove, "customer-1", tt.sourceId, tt.targetId, tt.productId)
+
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -1,20 +1,34 @@
 package customer
 
 import (
+	"context"
+
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
 )
 
 type CreateCustomerUseCase struct {
 	Repository repository.CustomerRepository
+	Metrics    service.BusinessMetrics
 }
 
-func NewCreateCustomerUseCase(repository repository.CustomerRepository) *CreateCustomerUseCase {
+func NewCreateCustomerUseCase(repository repository.CustomerRepository, metrics service.BusinessMetrics) *CreateCustomerUseCase {
 	return &CreateCustomerUseCase{
 		Repository: repository,
+		Metrics:    metrics,
 	}
 }
 
-func (c *CreateCustomerUseCase) Execute(customer *entity.Customer) (*entity.Customer, error) {
-	return c.Repository.Create(customer)
+func (c *CreateCustomerUseCase) Execute(ctx context.Context, customer *entity.Customer) (*entity.Customer, error) {
+	ctx, span := tracer.Start(ctx, "CreateCustomerUseCase.Execute")
+	defer span.End()
+
+	createdCustomer, err := c.Repository.Create(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Metrics.CustomerCreated()
+	return createdCustomer, nil
 }
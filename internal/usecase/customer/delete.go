@@ -1,6 +1,7 @@
 package customer
 
 import (
+	"context"
 	"errors"
 
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
@@ -16,8 +17,11 @@ func NewDeleteCustomerUseCase(repository repository.CustomerRepository) *DeleteC
 	}
 }
 
-func (u *DeleteCustomerUseCase) Execute(customerId string) error {
-	customer, err := u.Repository.FindById(customerId)
+func (u *DeleteCustomerUseCase) Execute(ctx context.Context, customerId string) error {
+	ctx, span := tracer.Start(ctx, "DeleteCustomerUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.Repository.FindById(ctx, customerId)
 	if err != nil {
 		return err
 	}
@@ -26,7 +30,7 @@ func (u *DeleteCustomerUseCase) Execute(customerId string) error {
 		return errors.New("customer not found")
 	}
 
-	err = u.Repository.Delete(customer)
+	err = u.Repository.Delete(ctx, customer)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package customer
 
 import (
+	"context"
 	"errors"
 
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
@@ -17,8 +18,11 @@ func NewEditCustomerUseCase(repository repository.CustomerRepository) *EditCusto
 	}
 }
 
-func (u *EditCustomerUseCase) Execute(customer *entity.Customer) error {
-	customerEntity, err := u.Repository.FindById(customer.Id)
+func (u *EditCustomerUseCase) Execute(ctx context.Context, customer *entity.Customer) error {
+	ctx, span := tracer.Start(ctx, "EditCustomerUseCase.Execute")
+	defer span.End()
+
+	customerEntity, err := u.Repository.FindById(ctx, customer.Id)
 	if err != nil {
 		return err
 	}
@@ -30,7 +34,7 @@ func (u *EditCustomerUseCase) Execute(customer *entity.Customer) error {
 	customerEntity.Name = customer.Name
 	customerEntity.Email = customer.Email
 
-	err = u.Repository.Update(customerEntity)
+	err = u.Repository.Update(ctx, customerEntity)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,48 @@
+package customer
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+const exportPageSize = 500
+
+// ExportCustomersUseCase walks every customer a page at a time, so exporting
+// never loads the whole table.
+type ExportCustomersUseCase struct {
+	Repository repository.CustomerRepository
+}
+
+func NewExportCustomersUseCase(repository repository.CustomerRepository) *ExportCustomersUseCase {
+	return &ExportCustomersUseCase{
+		Repository: repository,
+	}
+}
+
+// Execute calls write for every customer, ordered by id, and stops at the
+// first error it returns.
+func (u *ExportCustomersUseCase) Execute(ctx context.Context, write func(*entity.CustomerExport) error) error {
+	ctx, span := tracer.Start(ctx, "ExportCustomersUseCase.Execute")
+	defer span.End()
+
+	afterId := ""
+	for {
+		page, err := u.Repository.FindPageWithFavoriteIds(ctx, afterId, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, customer := range page {
+			if err := write(customer); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < exportPageSize {
+			return nil
+		}
+		afterId = page[len(page)-1].Customer.Id
+	}
+}
@@ -1,8 +1,13 @@
 package customer
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
 )
 
 type FindByIdCustomerUseCase struct {
@@ -23,8 +28,17 @@ func NewFindByIdCustomerUseCase(
 	}
 }
 
-func (f *FindByIdCustomerUseCase) Execute(customerId string) (*entity.Customer, error) {
-	customer, err := f.CustomerRepository.FindById(customerId)
+// Execute loads the customer with their favorites, sorted by favoritesSort
+// (see entity.SortFavorites).
+func (f *FindByIdCustomerUseCase) Execute(ctx context.Context, customerId, favoritesSort string) (*entity.Customer, error) {
+	ctx, span := tracer.Start(ctx, "FindByIdCustomerUseCase.Execute")
+	defer span.End()
+
+	if err := entity.ValidateFavoriteSort(favoritesSort); err != nil {
+		return nil, err
+	}
+
+	customer, err := f.CustomerRepository.FindById(ctx, customerId)
 	if err != nil {
 		return nil, err
 	}
@@ -33,21 +47,32 @@ func (f *FindByIdCustomerUseCase) Execute(customerId string) (*entity.Customer,
 		return nil, nil
 	}
 
-	favoriteProductIds, err := f.FavoritesRepository.FindAllByCustomer(customer)
+	favorites, err := f.FavoritesRepository.FindAllByCustomer(ctx, customer)
 	if err != nil {
+		logger.FromContext(ctx).Warn("loading customer favorites", slog.String("customer_id", customer.Id), slog.Any("error", err))
 		return customer, nil
 	}
 
-	var favoriteProducts []*entity.Product
-	for _, productId := range favoriteProductIds {
-		if productId != nil {
-			product, err := f.ProductRepository.FindById(*productId)
-			if err == nil && product != nil {
-				favoriteProducts = append(favoriteProducts, product)
-			}
+	for _, favorite := range favorites {
+		product, err := f.ProductRepository.FindById(ctx, favorite.ProductId)
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			favorite.Available = false
+		case err != nil:
+			// The catalog could not answer, which says nothing about the product
+			// itself: fall back to the snapshot without flagging it.
+			logger.FromContext(ctx).Warn("loading favorite product", slog.Int64("product_id", favorite.ProductId), slog.Any("error", err))
+			favorite.Available = true
+		default:
+			favorite.Available = true
+			favorite.SetProduct(product)
 		}
 	}
 
-	customer.Favorites = favoriteProducts
+	if err := entity.SortFavorites(favorites, favoritesSort); err != nil {
+		return nil, err
+	}
+
+	customer.Favorites = favorites
 	return customer, nil
 }
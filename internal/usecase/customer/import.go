@@ -0,0 +1,174 @@
+package customer
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"slices"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+const defaultImportBatchSize = 500
+
+var (
+	// errImportRowNotSaved is reported for a row the database refused. The
+	// cause is logged, not returned, so the report carries no SQL detail.
+	errImportRowNotSaved = errors.New("customer could not be saved")
+	// errImportInterrupted is reported for the rows read but not yet saved
+	// when the import was cancelled.
+	errImportInterrupted = errors.New("import interrupted before the customer was saved")
+)
+
+// ImportRow is a customer read from an import file. Err is set when the row
+// itself could not be decoded.
+type ImportRow struct {
+	Line  int
+	Name  string
+	Email string
+	Err   error
+}
+
+// ImportRowError explains why a row was not created. Skipped rows are
+// duplicates, of an earlier row or of an existing customer; the others failed.
+type ImportRowError struct {
+	Line    int
+	Email   string
+	Error   string
+	Skipped bool
+}
+
+type ImportReport struct {
+	Total   int
+	Created int
+	Skipped int
+	Failed  int
+	Errors  []ImportRowError
+}
+
+func (r *ImportReport) skip(row ImportRow, reason string) {
+	r.Skipped++
+	r.Errors = append(r.Errors, ImportRowError{Line: row.Line, Email: row.Email, Error: reason, Skipped: true})
+}
+
+func (r *ImportReport) fail(row ImportRow, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportRowError{Line: row.Line, Email: row.Email, Error: err.Error()})
+}
+
+// ImportCustomersUseCase creates customers in bulk, BatchSize at a time. Each
+// batch is saved all or none, so a failed batch is retried one row at a time
+// to pin the failure on the rows that caused it.
+type ImportCustomersUseCase struct {
+	Repository repository.CustomerRepository
+	Metrics    service.BusinessMetrics
+	BatchSize  int
+}
+
+func NewImportCustomersUseCase(repository repository.CustomerRepository, metrics service.BusinessMetrics) *ImportCustomersUseCase {
+	return &ImportCustomersUseCase{
+		Repository: repository,
+		Metrics:    metrics,
+		BatchSize:  defaultImportBatchSize,
+	}
+}
+
+func (u *ImportCustomersUseCase) Execute(ctx context.Context, rows iter.Seq[ImportRow]) (*ImportReport, error) {
+	ctx, span := tracer.Start(ctx, "ImportCustomersUseCase.Execute")
+	defer span.End()
+
+	report := &ImportReport{}
+	seen := map[string]int{}
+
+	var batch []*entity.Customer
+	var batchRows []ImportRow
+
+	for row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+
+		report.Total++
+		if row.Err != nil {
+			report.fail(row, row.Err)
+			continue
+		}
+
+		customer, err := entity.NewCustomer(row.Name, row.Email)
+		if err != nil {
+			report.fail(row, err)
+			continue
+		}
+
+		if line, ok := seen[customer.Email]; ok {
+			report.skip(row, fmt.Sprintf("duplicate of line %d", line))
+			continue
+		}
+		seen[customer.Email] = row.Line
+
+		batch = append(batch, customer)
+		batchRows = append(batchRows, row)
+		if len(batch) == u.BatchSize {
+			u.createBatch(ctx, report, batch, batchRows)
+			batch, batchRows = nil, nil
+		}
+	}
+
+	if len(batch) > 0 {
+		u.createBatch(ctx, report, batch, batchRows)
+	}
+
+	// Batch errors are only known after the rows read past them.
+	slices.SortStableFunc(report.Errors, func(a, b ImportRowError) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+
+	// A cancelled import still reports the rows it got through.
+	return report, ctx.Err()
+}
+
+func (u *ImportCustomersUseCase) createBatch(ctx context.Context, report *ImportReport, batch []*entity.Customer, rows []ImportRow) {
+	if ctx.Err() != nil {
+		for _, row := range rows {
+			report.fail(row, errImportInterrupted)
+		}
+		return
+	}
+
+	skipped, err := u.Repository.CreateMany(ctx, batch)
+	if err != nil {
+		if len(batch) == 1 {
+			logger.FromContext(ctx).Warn("importing customers", slog.Int("line", rows[0].Line), slog.Int("rows", len(rows)), slog.Any("error", err))
+			for _, row := range rows {
+				report.fail(row, errImportRowNotSaved)
+			}
+			return
+		}
+
+		for i := range batch {
+			u.createBatch(ctx, report, batch[i:i+1], rows[i:i+1])
+		}
+		return
+	}
+
+	existing := make(map[string]bool, len(skipped))
+	for _, email := range skipped {
+		existing[email] = true
+	}
+
+	for i, customer := range batch {
+		if existing[customer.Email] {
+			report.skip(rows[i], fmt.Sprintf("customer with email %s already exists", customer.Email))
+			continue
+		}
+
+		report.Created++
+		u.Metrics.CustomerCreated()
+	}
+}
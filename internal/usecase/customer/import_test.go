@@ -0,0 +1,117 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCustomersUseCase_ReportsEveryRow(t *testing.T) {
+	existing, _ := entity.NewCustomer("Existing", "existing@example.com")
+	repository := &stubCustomerRepository{customers: []*entity.Customer{existing}}
+	metrics := &stubBusinessMetrics{}
+	useCase := NewImportCustomersUseCase(repository, metrics)
+	useCase.BatchSize = 2
+
+	report, err := useCase.Execute(context.Background(), slices.Values([]ImportRow{
+		{Line: 2, Name: "Ana", Email: "ana@example.com"},
+		{Line: 3, Name: "", Email: "nameless@example.com"},
+		{Line: 4, Name: "Ana again", Email: "ana@example.com"},
+		{Line: 5, Err: errors.New("bare quote in field")},
+		{Line: 6, Name: "Existing", Email: "existing@example.com"},
+		{Line: 7, Name: "Bruno", Email: "not-an-email"},
+		{Line: 8, Name: "Carla", Email: "carla@example.com"},
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Total)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, []ImportRowError{
+		{Line: 3, Email: "nameless@example.com", Error: entity.ErrCustomerNameEmtpy.Error()},
+		{Line: 4, Email: "ana@example.com", Error: "duplicate of line 2", Skipped: true},
+		{Line: 5, Error: "bare quote in field"},
+		{Line: 6, Email: "existing@example.com", Error: "customer with email existing@example.com already exists", Skipped: true},
+		{Line: 7, Email: "not-an-email", Error: entity.ErrCustomerEmailInvalid.Error()},
+	}, report.Errors)
+	assert.Equal(t, 2, repository.batches)
+	assert.Equal(t, 2, metrics.customersCreated)
+}
+
+func TestImportCustomersUseCase_FailedBatchIsRetriedRowByRow(t *testing.T) {
+	repository := &stubCustomerRepository{failEmail: "b@example.com"}
+	useCase := NewImportCustomersUseCase(repository, &stubBusinessMetrics{})
+	useCase.BatchSize = 2
+
+	var rows []ImportRow
+	for i, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		rows = append(rows, ImportRow{Line: i + 2, Name: fmt.Sprintf("Customer %d", i), Email: email})
+	}
+
+	report, err := useCase.Execute(context.Background(), slices.Values(rows))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, []ImportRowError{
+		{Line: 3, Email: "b@example.com", Error: "customer could not be saved"},
+	}, report.Errors)
+	assert.Len(t, repository.customers, 2)
+	// The failed batch, then each of its rows, then the last batch.
+	assert.Equal(t, 4, repository.batches)
+}
+
+func TestImportCustomersUseCase_CancelledImportKeepsTheReport(t *testing.T) {
+	repository := &stubCustomerRepository{}
+	useCase := NewImportCustomersUseCase(repository, &stubBusinessMetrics{})
+	useCase.BatchSize = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rows := func(yield func(ImportRow) bool) {
+		for i, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"} {
+			if i == 3 {
+				cancel()
+			}
+			if !yield(ImportRow{Line: i + 2, Name: "Customer", Email: email}) {
+				return
+			}
+		}
+	}
+
+	report, err := useCase.Execute(ctx, rows)
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, report)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, []ImportRowError{
+		{Line: 4, Email: "c@example.com", Error: errImportInterrupted.Error()},
+	}, report.Errors)
+}
+
+func TestExportCustomersUseCase_WalksEveryPage(t *testing.T) {
+	repository := &stubCustomerRepository{}
+	for i := range exportPageSize + 3 {
+		customer, _ := entity.NewCustomer("Customer", fmt.Sprintf("customer%d@example.com", i))
+		repository.customers = append(repository.customers, customer)
+	}
+
+	var emails []string
+	err := NewExportCustomersUseCase(repository).Execute(context.Background(), func(export *entity.CustomerExport) error {
+		emails = append(emails, export.Customer.Email)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, emails, exportPageSize+3)
+	assert.Equal(t, "customer0@example.com", emails[0])
+	assert.Equal(t, fmt.Sprintf("customer%d@example.com", exportPageSize+2), emails[len(emails)-1])
+}
@@ -0,0 +1,63 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+)
+
+// stubCustomerRepository keeps customers in id order and fails the batches
+// containing failEmail.
+type stubCustomerRepository struct {
+	repository.CustomerRepository
+	customers []*entity.Customer
+	batches   int
+	failEmail string
+}
+
+func (s *stubCustomerRepository) CreateMany(ctx context.Context, customers []*entity.Customer) ([]string, error) {
+	s.batches++
+
+	for _, customer := range customers {
+		if customer.Email == s.failEmail {
+			return nil, errors.New("connection reset")
+		}
+	}
+
+	var skipped []string
+	for _, customer := range customers {
+		if slices.ContainsFunc(s.customers, func(existing *entity.Customer) bool { return existing.Email == customer.Email }) {
+			skipped = append(skipped, customer.Email)
+			continue
+		}
+		s.customers = append(s.customers, customer)
+	}
+
+	slices.SortFunc(s.customers, func(a, b *entity.Customer) int { return strings.Compare(a.Id, b.Id) })
+	return skipped, nil
+}
+
+func (s *stubCustomerRepository) FindPageWithFavoriteIds(ctx context.Context, afterId string, limit int) ([]*entity.CustomerExport, error) {
+	var page []*entity.CustomerExport
+	for _, customer := range s.customers {
+		if customer.Id > afterId && len(page) < limit {
+			page = append(page, &entity.CustomerExport{Customer: customer})
+		}
+	}
+
+	return page, nil
+}
+
+type stubBusinessMetrics struct {
+	service.BusinessMetrics
+	customersCreated int
+}
+
+func (s *stubBusinessMetrics) CustomerCreated() {
+	s.customersCreated++
+}
@@ -1,27 +1,45 @@
 package favorite
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
 )
 
 type CreateFavoriteUseCase struct {
 	FavoritesRepository repository.FavoritesRepository
 	CustomerRepository  repository.CustomerRepository
 	ProductRepository   repository.ProductRepository
+	EventPublisher      event.Publisher
+	Metrics             service.BusinessMetrics
+	// FavoritesLimit is the default maximum of favorites per customer,
+	// overridden by the customer's quota.
+	FavoritesLimit int
 }
 
-func NewCreateFavoriteUseCase(favoritesRepository repository.FavoritesRepository, customerRepository repository.CustomerRepository, productRepository repository.ProductRepository) *CreateFavoriteUseCase {
+func NewCreateFavoriteUseCase(favoritesRepository repository.FavoritesRepository, customerRepository repository.CustomerRepository, productRepository repository.ProductRepository, eventPublisher event.Publisher, metrics service.BusinessMetrics, favoritesLimit int) *CreateFavoriteUseCase {
 	return &CreateFavoriteUseCase{
 		FavoritesRepository: favoritesRepository,
 		CustomerRepository:  customerRepository,
 		ProductRepository:   productRepository,
+		EventPublisher:      eventPublisher,
+		Metrics:             metrics,
+		FavoritesLimit:      favoritesLimit,
 	}
 }
 
-func (u *CreateFavoriteUseCase) Execute(customerId string, productId int64) error {
-	customer, err := u.CustomerRepository.FindById(customerId)
+func (u *CreateFavoriteUseCase) Execute(ctx context.Context, customerId string, productId int64) error {
+	ctx, span := tracer.Start(ctx, "CreateFavoriteUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
 	if err != nil {
 		return err
 	}
@@ -30,14 +48,31 @@ func (u *CreateFavoriteUseCase) Execute(customerId string, productId int64) erro
 		return errors.New("customer not found")
 	}
 
-	product, err := u.ProductRepository.FindById(productId)
+	product, err := u.ProductRepository.FindById(ctx, productId)
 	if err != nil {
 		return err
 	}
 
 	if product == nil {
-		return errors.New("product not found")
+		return repository.ErrProductNotFound
+	}
+
+	limit := customer.FavoritesLimit(u.FavoritesLimit)
+	err = u.FavoritesRepository.AddToCustomer(ctx, customer, entity.NewFavorite(product), limit)
+	if errors.Is(err, repository.ErrFavoritesLimitReached) {
+		return fmt.Errorf("%w: customer can have at most %d favorites", err, limit)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	u.Metrics.FavoriteAdded()
+
+	// The favorite is already persisted; a failed notification must not fail the request.
+	if err := u.EventPublisher.Publish(ctx, event.NewFavoriteAddedEvent(customer.Id, product.Id)); err != nil {
+		logger.FromContext(ctx).Warn("publishing favorite event", slog.String("customer_id", customer.Id), slog.Any("error", err))
 	}
 
-	return u.FavoritesRepository.AddToCustomer(customer, &product.Id)
+	return nil
 }
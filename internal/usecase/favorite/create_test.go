@@ -0,0 +1,44 @@
+package favorite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCreateUseCase(customer *entity.Customer, limit int) (*CreateFavoriteUseCase, *stubFavoritesRepository, *stubPublisher) {
+	favorites := &stubFavoritesRepository{favorites: map[string][]*entity.Favorite{
+		customer.Id: {{ProductId: 1}, {ProductId: 2}},
+	}}
+	customers := &stubCustomerRepository{customers: map[string]*entity.Customer{customer.Id: customer}}
+	products := &stubProductRepository{products: []*entity.Product{{Id: 1}, {Id: 2}, {Id: 3}}}
+	publisher := &stubPublisher{}
+
+	return NewCreateFavoriteUseCase(favorites, customers, products, publisher, stubMetrics{}, limit), favorites, publisher
+}
+
+func TestCreateFavoriteUseCase_LimitReached(t *testing.T) {
+	useCase, favorites, publisher := newCreateUseCase(&entity.Customer{Id: "customer-1"}, 2)
+
+	err := useCase.Execute(context.Background(), "customer-1", 3)
+
+	assert.ErrorIs(t, err, repository.ErrFavoritesLimitReached)
+	assert.EqualError(t, err, "favorites limit reached: customer can have at most 2 favorites")
+	assert.Len(t, favorites.favorites["customer-1"], 2)
+	assert.Empty(t, publisher.events)
+}
+
+func TestCreateFavoriteUseCase_QuotaOverridesLimit(t *testing.T) {
+	quota := 3
+	useCase, favorites, publisher := newCreateUseCase(&entity.Customer{Id: "customer-1", FavoritesQuota: &quota}, 2)
+
+	err := useCase.Execute(context.Background(), "customer-1", 3)
+
+	require.NoError(t, err)
+	assert.Len(t, favorites.favorites["customer-1"], 3)
+	assert.Len(t, publisher.events, 1)
+}
@@ -1,27 +1,39 @@
 package favorite
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
 )
 
 type DeleteFavoriteUseCase struct {
 	FavoritesRepository repository.FavoritesRepository
 	CustomerRepository  repository.CustomerRepository
 	ProductRepository   repository.ProductRepository
+	EventPublisher      event.Publisher
+	Metrics             service.BusinessMetrics
 }
 
-func NewDeleteFavoriteUseCase(favoritesRepository repository.FavoritesRepository, customerRepository repository.CustomerRepository, productRepository repository.ProductRepository) *DeleteFavoriteUseCase {
+func NewDeleteFavoriteUseCase(favoritesRepository repository.FavoritesRepository, customerRepository repository.CustomerRepository, productRepository repository.ProductRepository, eventPublisher event.Publisher, metrics service.BusinessMetrics) *DeleteFavoriteUseCase {
 	return &DeleteFavoriteUseCase{
 		FavoritesRepository: favoritesRepository,
 		CustomerRepository:  customerRepository,
 		ProductRepository:   productRepository,
+		EventPublisher:      eventPublisher,
+		Metrics:             metrics,
 	}
 }
 
-func (u *DeleteFavoriteUseCase) Execute(customerId string, productId int64) error {
-	customer, err := u.CustomerRepository.FindById(customerId)
+func (u *DeleteFavoriteUseCase) Execute(ctx context.Context, customerId string, productId int64) error {
+	ctx, span := tracer.Start(ctx, "DeleteFavoriteUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
 	if err != nil {
 		return err
 	}
@@ -30,14 +42,32 @@ func (u *DeleteFavoriteUseCase) Execute(customerId string, productId int64) erro
 		return errors.New("customer not found")
 	}
 
-	product, err := u.ProductRepository.FindById(productId)
+	// Removing does not go through the catalog first, so favorites of
+	// discontinued products can still be deleted.
+	removed, err := u.FavoritesRepository.RemoveFromCustomer(ctx, customer, &productId)
 	if err != nil {
 		return err
 	}
 
-	if product == nil {
-		return errors.New("product not found")
+	if !removed {
+		product, err := u.ProductRepository.FindById(ctx, productId)
+		if err != nil {
+			return err
+		}
+
+		if product == nil {
+			return repository.ErrProductNotFound
+		}
+
+		return nil
+	}
+
+	u.Metrics.FavoriteRemoved()
+
+	// The favorite is already removed; a failed notification must not fail the request.
+	if err := u.EventPublisher.Publish(ctx, event.NewFavoriteRemovedEvent(customer.Id, productId)); err != nil {
+		logger.FromContext(ctx).Warn("publishing favorite event", slog.String("customer_id", customer.Id), slog.Any("error", err))
 	}
 
-	return u.FavoritesRepository.RemoveFromCustomer(customer, &product.Id)
+	return nil
 }
@@ -0,0 +1,50 @@
+package favorite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeleteUseCase(products *stubProductRepository) (*DeleteFavoriteUseCase, *stubFavoritesRepository, *stubPublisher) {
+	customer := &entity.Customer{Id: "customer-1"}
+	favorites := &stubFavoritesRepository{favorites: map[string][]*entity.Favorite{
+		customer.Id: {{ProductId: 1}, {ProductId: 99}},
+	}}
+	publisher := &stubPublisher{}
+	customers := &stubCustomerRepository{customers: map[string]*entity.Customer{customer.Id: customer}}
+
+	return NewDeleteFavoriteUseCase(favorites, customers, products, publisher, stubMetrics{}), favorites, publisher
+}
+
+func TestDeleteFavoriteUseCase_DiscontinuedProduct(t *testing.T) {
+	useCase, favorites, publisher := newDeleteUseCase(&stubProductRepository{products: []*entity.Product{{Id: 1}}})
+
+	err := useCase.Execute(context.Background(), "customer-1", 99)
+
+	require.NoError(t, err)
+	assert.Len(t, favorites.favorites["customer-1"], 1)
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestDeleteFavoriteUseCase_NotFavorited(t *testing.T) {
+	useCase, _, publisher := newDeleteUseCase(&stubProductRepository{products: []*entity.Product{{Id: 1}, {Id: 2}}})
+
+	require.NoError(t, useCase.Execute(context.Background(), "customer-1", 2))
+	assert.Empty(t, publisher.events)
+
+	err := useCase.Execute(context.Background(), "customer-1", 3)
+	assert.ErrorIs(t, err, repository.ErrProductNotFound)
+}
+
+func TestDeleteFavoriteUseCase_CustomerNotFound(t *testing.T) {
+	useCase, _, _ := newDeleteUseCase(&stubProductRepository{})
+
+	err := useCase.Execute(context.Background(), "unknown", 1)
+
+	assert.EqualError(t, err, "customer not found")
+}
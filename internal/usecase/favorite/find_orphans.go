@@ -0,0 +1,56 @@
+package favorite
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// FindOrphanFavoritesUseCase lists the favorited products the catalog no
+// longer has, with how many favorites point to each of them.
+type FindOrphanFavoritesUseCase struct {
+	FavoritesRepository repository.FavoritesRepository
+	ProductRepository   repository.ProductRepository
+}
+
+func NewFindOrphanFavoritesUseCase(favoritesRepository repository.FavoritesRepository, productRepository repository.ProductRepository) *FindOrphanFavoritesUseCase {
+	return &FindOrphanFavoritesUseCase{
+		FavoritesRepository: favoritesRepository,
+		ProductRepository:   productRepository,
+	}
+}
+
+func (u *FindOrphanFavoritesUseCase) Execute(ctx context.Context) ([]*entity.FavoritedProduct, error) {
+	ctx, span := tracer.Start(ctx, "FindOrphanFavoritesUseCase.Execute")
+	defer span.End()
+
+	favorited, err := u.FavoritesRepository.CountByProduct(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(favorited) == 0 {
+		return []*entity.FavoritedProduct{}, nil
+	}
+
+	// A catalog error aborts instead of reporting every favorite as orphaned.
+	products, err := u.ProductRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inCatalog := make(map[int64]bool, len(products))
+	for _, product := range products {
+		inCatalog[product.Id] = true
+	}
+
+	orphans := []*entity.FavoritedProduct{}
+	for _, product := range favorited {
+		if !inCatalog[product.ProductId] {
+			orphans = append(orphans, product)
+		}
+	}
+
+	return orphans, nil
+}
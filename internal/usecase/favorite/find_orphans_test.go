@@ -0,0 +1,58 @@
+package favorite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrphanFixture() (*stubFavoritesRepository, *stubProductRepository) {
+	favorites := &stubFavoritesRepository{favorites: map[string][]*entity.Favorite{
+		"customer-1": {{ProductId: 1}, {ProductId: 2}, {ProductId: 3}},
+		"customer-2": {{ProductId: 2}, {ProductId: 3}},
+	}}
+	products := &stubProductRepository{products: []*entity.Product{{Id: 1}, {Id: 3}}}
+
+	return favorites, products
+}
+
+func TestFindOrphanFavoritesUseCase_Execute(t *testing.T) {
+	favorites, products := newOrphanFixture()
+
+	orphans, err := NewFindOrphanFavoritesUseCase(favorites, products).Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.FavoritedProduct{{ProductId: 2, Favorites: 2}}, orphans)
+}
+
+func TestFindOrphanFavoritesUseCase_CatalogUnavailable(t *testing.T) {
+	favorites, products := newOrphanFixture()
+	products.err = repository.ErrProductServiceUnavailable
+
+	orphans, err := NewFindOrphanFavoritesUseCase(favorites, products).Execute(context.Background())
+
+	assert.ErrorIs(t, err, repository.ErrProductServiceUnavailable)
+	assert.Nil(t, orphans)
+}
+
+func TestPruneOrphanFavoritesUseCase_Execute(t *testing.T) {
+	favorites, products := newOrphanFixture()
+	useCase := NewPruneOrphanFavoritesUseCase(NewFindOrphanFavoritesUseCase(favorites, products), favorites)
+
+	orphans, removed, err := useCase.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, orphans, 1)
+	assert.Equal(t, int64(2), removed)
+	assert.Len(t, favorites.favorites["customer-1"], 2)
+	assert.Len(t, favorites.favorites["customer-2"], 1)
+
+	orphans, removed, err = useCase.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+	assert.Zero(t, removed)
+}
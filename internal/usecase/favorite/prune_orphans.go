@@ -0,0 +1,52 @@
+package favorite
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+type PruneOrphanFavoritesUseCase struct {
+	FindOrphansUseCase  *FindOrphanFavoritesUseCase
+	FavoritesRepository repository.FavoritesRepository
+}
+
+func NewPruneOrphanFavoritesUseCase(findOrphansUseCase *FindOrphanFavoritesUseCase, favoritesRepository repository.FavoritesRepository) *PruneOrphanFavoritesUseCase {
+	return &PruneOrphanFavoritesUseCase{
+		FindOrphansUseCase:  findOrphansUseCase,
+		FavoritesRepository: favoritesRepository,
+	}
+}
+
+// Execute deletes every favorite of a product missing from the catalog and
+// returns the pruned products along with the number of favorites removed.
+func (u *PruneOrphanFavoritesUseCase) Execute(ctx context.Context) ([]*entity.FavoritedProduct, int64, error) {
+	ctx, span := tracer.Start(ctx, "PruneOrphanFavoritesUseCase.Execute")
+	defer span.End()
+
+	orphans, err := u.FindOrphansUseCase.Execute(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(orphans) == 0 {
+		return orphans, 0, nil
+	}
+
+	productIds := make([]int64, len(orphans))
+	for i, orphan := range orphans {
+		productIds[i] = orphan.ProductId
+	}
+
+	removed, err := u.FavoritesRepository.RemoveByProducts(ctx, productIds)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logger.FromContext(ctx).Info("pruned orphan favorites", slog.Any("product_ids", productIds), slog.Int64("removed", removed))
+
+	return orphans, removed, nil
+}
@@ -0,0 +1,104 @@
+package favorite
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// Quota is how many favorites a customer can have and how many are used.
+// Override is the customer's own quota, nil when the default applies.
+type Quota struct {
+	CustomerId string
+	Limit      int
+	Used       int64
+	Override   *int
+}
+
+type FindQuotaFavoriteUseCase struct {
+	FavoritesRepository repository.FavoritesRepository
+	CustomerRepository  repository.CustomerRepository
+	FavoritesLimit      int
+}
+
+func NewFindQuotaFavoriteUseCase(favoritesRepository repository.FavoritesRepository, customerRepository repository.CustomerRepository, favoritesLimit int) *FindQuotaFavoriteUseCase {
+	return &FindQuotaFavoriteUseCase{
+		FavoritesRepository: favoritesRepository,
+		CustomerRepository:  customerRepository,
+		FavoritesLimit:      favoritesLimit,
+	}
+}
+
+func (u *FindQuotaFavoriteUseCase) Execute(ctx context.Context, customerId string) (*Quota, error) {
+	ctx, span := tracer.Start(ctx, "FindQuotaFavoriteUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, errors.New("customer not found")
+	}
+
+	return quotaOf(ctx, u.FavoritesRepository, customer, u.FavoritesLimit)
+}
+
+type SetQuotaFavoriteUseCase struct {
+	FavoritesRepository repository.FavoritesRepository
+	CustomerRepository  repository.CustomerRepository
+	FavoritesLimit      int
+}
+
+func NewSetQuotaFavoriteUseCase(favoritesRepository repository.FavoritesRepository, customerRepository repository.CustomerRepository, favoritesLimit int) *SetQuotaFavoriteUseCase {
+	return &SetQuotaFavoriteUseCase{
+		FavoritesRepository: favoritesRepository,
+		CustomerRepository:  customerRepository,
+		FavoritesLimit:      favoritesLimit,
+	}
+}
+
+// Execute overrides the customer's favorites limit, or restores the default
+// when quota is nil. Lowering it below the favorites already saved keeps them,
+// but no new product can be favorited until the customer is under the limit.
+func (u *SetQuotaFavoriteUseCase) Execute(ctx context.Context, customerId string, quota *int) (*Quota, error) {
+	ctx, span := tracer.Start(ctx, "SetQuotaFavoriteUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, errors.New("customer not found")
+	}
+
+	customer.FavoritesQuota = quota
+	if err := customer.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.CustomerRepository.UpdateFavoritesQuota(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	return quotaOf(ctx, u.FavoritesRepository, customer, u.FavoritesLimit)
+}
+
+func quotaOf(ctx context.Context, favoritesRepository repository.FavoritesRepository, customer *entity.Customer, defaultLimit int) (*Quota, error) {
+	used, err := favoritesRepository.CountByCustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Quota{
+		CustomerId: customer.Id,
+		Limit:      customer.FavoritesLimit(defaultLimit),
+		Used:       used,
+		Override:   customer.FavoritesQuota,
+	}, nil
+}
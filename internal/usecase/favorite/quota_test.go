@@ -0,0 +1,36 @@
+package favorite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetQuotaFavoriteUseCase(t *testing.T) {
+	customers := &stubCustomerRepository{customers: map[string]*entity.Customer{"customer-1": {Id: "customer-1", Name: "Julio", Email: "julio@example.com"}}}
+	favorites := &stubFavoritesRepository{favorites: map[string][]*entity.Favorite{"customer-1": {{ProductId: 1}}}}
+	useCase := NewSetQuotaFavoriteUseCase(favorites, customers, 100)
+
+	quota := 500
+	result, err := useCase.Execute(context.Background(), "customer-1", &quota)
+
+	require.NoError(t, err)
+	assert.Equal(t, &Quota{CustomerId: "customer-1", Limit: 500, Used: 1, Override: &quota}, result)
+	assert.Equal(t, &quota, customers.customers["customer-1"].FavoritesQuota)
+
+	result, err = useCase.Execute(context.Background(), "customer-1", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 100, result.Limit)
+	assert.Nil(t, result.Override)
+
+	invalid := 0
+	_, err = useCase.Execute(context.Background(), "customer-1", &invalid)
+	assert.ErrorIs(t, err, entity.ErrCustomerFavoritesQuotaInvalid)
+
+	_, err = useCase.Execute(context.Background(), "unknown", &quota)
+	assert.EqualError(t, err, "customer not found")
+}
@@ -0,0 +1,38 @@
+package favorite
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type StreamFavoriteUseCase struct {
+	CustomerRepository repository.CustomerRepository
+	EventSubscriber    event.Subscriber
+}
+
+func NewStreamFavoriteUseCase(customerRepository repository.CustomerRepository, eventSubscriber event.Subscriber) *StreamFavoriteUseCase {
+	return &StreamFavoriteUseCase{
+		CustomerRepository: customerRepository,
+		EventSubscriber:    eventSubscriber,
+	}
+}
+
+func (u *StreamFavoriteUseCase) Execute(ctx context.Context, customerId, lastEventId string) (<-chan *event.Event, func(), error) {
+	ctx, span := tracer.Start(ctx, "StreamFavoriteUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if customer == nil {
+		return nil, nil, errors.New("customer not found")
+	}
+
+	events, unsubscribe := u.EventSubscriber.Subscribe(customer.Id, lastEventId)
+	return events, unsubscribe, nil
+}
@@ -0,0 +1,207 @@
+package favorite
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type stubCustomerRepository struct {
+	customers map[string]*entity.Customer
+}
+
+func (s *stubCustomerRepository) FindById(ctx context.Context, id string) (*entity.Customer, error) {
+	return s.customers[id], nil
+}
+
+func (s *stubCustomerRepository) FindByEmail(ctx context.Context, email string) (*entity.Customer, error) {
+	for _, customer := range s.customers {
+		if customer.Email == email {
+			return customer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubCustomerRepository) Create(ctx context.Context, customer *entity.Customer) (*entity.Customer, error) {
+	return customer, nil
+}
+
+func (s *stubCustomerRepository) CreateMany(ctx context.Context, customers []*entity.Customer) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubCustomerRepository) FindPageWithFavoriteIds(ctx context.Context, afterId string, limit int) ([]*entity.CustomerExport, error) {
+	return nil, nil
+}
+
+func (s *stubCustomerRepository) Update(ctx context.Context, customer *entity.Customer) error {
+	return nil
+}
+
+func (s *stubCustomerRepository) Delete(ctx context.Context, customer *entity.Customer) error {
+	return nil
+}
+
+func (s *stubCustomerRepository) UpdateFavoritesQuota(ctx context.Context, customer *entity.Customer) error {
+	s.customers[customer.Id] = customer
+	return nil
+}
+
+type stubProductRepository struct {
+	products []*entity.Product
+	err      error
+}
+
+func (s *stubProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	return s.products, s.err
+}
+
+func (s *stubProductRepository) FindById(ctx context.Context, id int64) (*entity.Product, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	for _, product := range s.products {
+		if product.Id == id {
+			return product, nil
+		}
+	}
+
+	return nil, repository.ErrProductNotFound
+}
+
+func (s *stubProductRepository) FindByIds(ctx context.Context, ids []int64) (map[int64]*entity.Product, error) {
+	found := map[int64]*entity.Product{}
+	for _, product := range s.products {
+		if slices.Contains(ids, product.Id) {
+			found[product.Id] = product
+		}
+	}
+
+	return found, s.err
+}
+
+func (s *stubProductRepository) FindByCategory(ctx context.Context, category string) ([]*entity.Product, error) {
+	return nil, s.err
+}
+
+func (s *stubProductRepository) FindCategories(ctx context.Context) ([]string, error) {
+	return nil, s.err
+}
+
+// stubFavoritesRepository keeps favorites per customer id.
+type stubFavoritesRepository struct {
+	favorites map[string][]*entity.Favorite
+}
+
+func (s *stubFavoritesRepository) FindAllByCustomer(ctx context.Context, customer *entity.Customer) ([]*entity.Favorite, error) {
+	return s.favorites[customer.Id], nil
+}
+
+func (s *stubFavoritesRepository) AddToCustomer(ctx context.Context, customer *entity.Customer, favorite *entity.Favorite, limit int) error {
+	if limit > 0 && len(s.favorites[customer.Id]) >= limit {
+		return repository.ErrFavoritesLimitReached
+	}
+
+	s.favorites[customer.Id] = append(s.favorites[customer.Id], favorite)
+	return nil
+}
+
+func (s *stubFavoritesRepository) CountByCustomer(ctx context.Context, customer *entity.Customer) (int64, error) {
+	return int64(len(s.favorites[customer.Id])), nil
+}
+
+func (s *stubFavoritesRepository) UpdateForCustomer(ctx context.Context, customer *entity.Customer, favorite *entity.Favorite) (bool, error) {
+	for i, existing := range s.favorites[customer.Id] {
+		if existing.ProductId == favorite.ProductId {
+			s.favorites[customer.Id][i] = favorite
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *stubFavoritesRepository) RemoveFromCustomer(ctx context.Context, customer *entity.Customer, productId *int64) (bool, error) {
+	before := len(s.favorites[customer.Id])
+	s.favorites[customer.Id] = slices.DeleteFunc(s.favorites[customer.Id], func(favorite *entity.Favorite) bool {
+		return favorite.ProductId == *productId
+	})
+
+	return len(s.favorites[customer.Id]) < before, nil
+}
+
+func (s *stubFavoritesRepository) CountByProductIds(ctx context.Context, productIds []int64) (map[int64]int64, error) {
+	return nil, nil
+}
+
+func (s *stubFavoritesRepository) CountBySince(ctx context.Context, since time.Time, limit, offset int) ([]*entity.FavoritedProduct, error) {
+	return nil, nil
+}
+
+func (s *stubFavoritesRepository) CountByProduct(ctx context.Context) ([]*entity.FavoritedProduct, error) {
+	counts := map[int64]int64{}
+	for _, favorites := range s.favorites {
+		for _, favorite := range favorites {
+			counts[favorite.ProductId]++
+		}
+	}
+
+	var favorited []*entity.FavoritedProduct
+	for productId, count := range counts {
+		favorited = append(favorited, &entity.FavoritedProduct{ProductId: productId, Favorites: count})
+	}
+	slices.SortFunc(favorited, func(a, b *entity.FavoritedProduct) int {
+		return int(a.ProductId - b.ProductId)
+	})
+
+	return favorited, nil
+}
+
+func (s *stubFavoritesRepository) FindCustomerIdsByProduct(ctx context.Context, productId int64) ([]string, error) {
+	var customerIds []string
+	for customerId, favorites := range s.favorites {
+		for _, favorite := range favorites {
+			if favorite.ProductId == productId {
+				customerIds = append(customerIds, customerId)
+			}
+		}
+	}
+	slices.Sort(customerIds)
+
+	return customerIds, nil
+}
+
+func (s *stubFavoritesRepository) RemoveByProducts(ctx context.Context, productIds []int64) (int64, error) {
+	var removed int64
+	for customerId, favorites := range s.favorites {
+		kept := slices.DeleteFunc(favorites, func(favorite *entity.Favorite) bool {
+			return slices.Contains(productIds, favorite.ProductId)
+		})
+		removed += int64(len(favorites) - len(kept))
+		s.favorites[customerId] = kept
+	}
+
+	return removed, nil
+}
+
+type stubPublisher struct {
+	events []*event.Event
+}
+
+func (s *stubPublisher) Publish(ctx context.Context, e *event.Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+type stubMetrics struct{}
+
+func (stubMetrics) CustomerCreated() {}
+func (stubMetrics) FavoriteAdded()   {}
+func (stubMetrics) FavoriteRemoved() {}
+func (stubMetrics) LoginFailed()     {}
@@ -0,0 +1,90 @@
+package favorite
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+// ErrFavoriteNotFound is returned when the product is not in the customer's favorites.
+var ErrFavoriteNotFound = errors.New("product not in favorites")
+
+// FavoriteChanges holds the fields to update; nil fields keep their value.
+type FavoriteChanges struct {
+	Note     *string
+	Quantity *int
+	Priority *string
+}
+
+type UpdateFavoriteUseCase struct {
+	FavoritesRepository repository.FavoritesRepository
+	CustomerRepository  repository.CustomerRepository
+}
+
+func NewUpdateFavoriteUseCase(favoritesRepository repository.FavoritesRepository, customerRepository repository.CustomerRepository) *UpdateFavoriteUseCase {
+	return &UpdateFavoriteUseCase{
+		FavoritesRepository: favoritesRepository,
+		CustomerRepository:  customerRepository,
+	}
+}
+
+func (u *UpdateFavoriteUseCase) Execute(ctx context.Context, customerId string, productId int64, changes FavoriteChanges) (*entity.Favorite, error) {
+	ctx, span := tracer.Start(ctx, "UpdateFavoriteUseCase.Execute")
+	defer span.End()
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, errors.New("customer not found")
+	}
+
+	favorites, err := u.FavoritesRepository.FindAllByCustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(favorites, func(favorite *entity.Favorite) bool {
+		return favorite.ProductId == productId
+	})
+	if index < 0 {
+		return nil, ErrFavoriteNotFound
+	}
+
+	favorite := *favorites[index]
+
+	if changes.Note != nil {
+		favorite.Note = *changes.Note
+	}
+
+	if changes.Quantity != nil {
+		favorite.Quantity = *changes.Quantity
+	}
+
+	if changes.Priority != nil {
+		favorite.Priority, err = entity.ParseFavoritePriority(*changes.Priority)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := favorite.Validate(); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.FavoritesRepository.UpdateForCustomer(ctx, customer, &favorite)
+	if err != nil {
+		return nil, err
+	}
+
+	if !updated {
+		return nil, ErrFavoriteNotFound
+	}
+
+	return &favorite, nil
+}
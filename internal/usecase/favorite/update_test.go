@@ -0,0 +1,58 @@
+package favorite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUpdateUseCase() (*UpdateFavoriteUseCase, *stubFavoritesRepository) {
+	customer := &entity.Customer{Id: "customer-1"}
+	favorites := &stubFavoritesRepository{favorites: map[string][]*entity.Favorite{
+		customer.Id: {{ProductId: 1, Note: "sem glúten", Quantity: 1, Priority: entity.FavoritePriorityNormal}},
+	}}
+	customers := &stubCustomerRepository{customers: map[string]*entity.Customer{customer.Id: customer}}
+
+	return NewUpdateFavoriteUseCase(favorites, customers), favorites
+}
+
+func TestUpdateFavoriteUseCase_PartialUpdate(t *testing.T) {
+	useCase, favorites := newUpdateUseCase()
+	quantity := 3
+	priority := "high"
+
+	favorite, err := useCase.Execute(context.Background(), "customer-1", 1, FavoriteChanges{Quantity: &quantity, Priority: &priority})
+
+	require.NoError(t, err)
+	assert.Equal(t, "sem glúten", favorite.Note)
+	assert.Equal(t, 3, favorite.Quantity)
+	assert.Equal(t, entity.FavoritePriorityHigh, favorite.Priority)
+	assert.Equal(t, favorite, favorites.favorites["customer-1"][0])
+}
+
+func TestUpdateFavoriteUseCase_Invalid(t *testing.T) {
+	useCase, favorites := newUpdateUseCase()
+	quantity := 0
+	priority := "urgent"
+
+	_, err := useCase.Execute(context.Background(), "customer-1", 1, FavoriteChanges{Quantity: &quantity})
+	assert.ErrorIs(t, err, entity.ErrFavoriteQuantityInvalid)
+
+	_, err = useCase.Execute(context.Background(), "customer-1", 1, FavoriteChanges{Priority: &priority})
+	assert.ErrorIs(t, err, entity.ErrFavoritePriorityInvalid)
+
+	assert.Equal(t, 1, favorites.favorites["customer-1"][0].Quantity)
+}
+
+func TestUpdateFavoriteUseCase_NotFound(t *testing.T) {
+	useCase, _ := newUpdateUseCase()
+
+	_, err := useCase.Execute(context.Background(), "customer-1", 2, FavoriteChanges{})
+	assert.ErrorIs(t, err, ErrFavoriteNotFound)
+
+	_, err = useCase.Execute(context.Background(), "unknown", 1, FavoriteChanges{})
+	assert.EqualError(t, err, "customer not found")
+}
@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+)
+
+type CheckResult struct {
+	Name     string
+	Healthy  bool
+	Error    string
+	Duration time.Duration
+}
+
+type ReadinessUseCase struct {
+	Checkers []service.HealthChecker
+	Timeout  time.Duration
+}
+
+func NewReadinessUseCase(checkers []service.HealthChecker, timeout time.Duration) *ReadinessUseCase {
+	return &ReadinessUseCase{
+		Checkers: checkers,
+		Timeout:  timeout,
+	}
+}
+
+// Execute runs every checker concurrently and reports whether all of them passed.
+func (u *ReadinessUseCase) Execute(ctx context.Context) ([]CheckResult, bool) {
+	ctx, span := tracer.Start(ctx, "ReadinessUseCase.Execute")
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, u.Timeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(u.Checkers))
+	var wg sync.WaitGroup
+	for i, checker := range u.Checkers {
+		wg.Add(1)
+		go func(i int, checker service.HealthChecker) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := checker.Check(ctx)
+			results[i] = CheckResult{
+				Name:     checker.Name(),
+				Healthy:  err == nil,
+				Duration: time.Since(start),
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, checker)
+	}
+	wg.Wait()
+
+	ready := true
+	for _, result := range results {
+		ready = ready && result.Healthy
+	}
+
+	return results, ready
+}
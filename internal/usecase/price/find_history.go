@@ -0,0 +1,42 @@
+package price
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type FindPriceHistoryUseCase struct {
+	PriceHistoryRepository repository.PriceHistoryRepository
+	ProductRepository      repository.ProductRepository
+}
+
+func NewFindPriceHistoryUseCase(priceHistoryRepository repository.PriceHistoryRepository, productRepository repository.ProductRepository) *FindPriceHistoryUseCase {
+	return &FindPriceHistoryUseCase{
+		PriceHistoryRepository: priceHistoryRepository,
+		ProductRepository:      productRepository,
+	}
+}
+
+// Execute returns the recorded prices of a product, oldest first. The history
+// outlives the product, so the catalog is only asked when nothing was recorded.
+func (u *FindPriceHistoryUseCase) Execute(ctx context.Context, productId int64) ([]*entity.PricePoint, error) {
+	ctx, span := tracer.Start(ctx, "FindPriceHistoryUseCase.Execute")
+	defer span.End()
+
+	history, err := u.PriceHistoryRepository.FindByProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) > 0 {
+		return history, nil
+	}
+
+	if _, err := u.ProductRepository.FindById(ctx, productId); err != nil {
+		return nil, err
+	}
+
+	return []*entity.PricePoint{}, nil
+}
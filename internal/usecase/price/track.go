@@ -0,0 +1,129 @@
+package price
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+// TrackResult summarizes one pass over the catalog.
+type TrackResult struct {
+	Recorded int
+	Drops    int
+	Notified int
+}
+
+// TrackPricesUseCase polls the catalog, records every price change and
+// notifies the customers who favorited a product that got cheaper.
+type TrackPricesUseCase struct {
+	ProductRepository      repository.ProductRepository
+	PriceHistoryRepository repository.PriceHistoryRepository
+	FavoritesRepository    repository.FavoritesRepository
+	Notifier               service.PriceDropNotifier
+}
+
+func NewTrackPricesUseCase(
+	productRepository repository.ProductRepository,
+	priceHistoryRepository repository.PriceHistoryRepository,
+	favoritesRepository repository.FavoritesRepository,
+	notifier service.PriceDropNotifier,
+) *TrackPricesUseCase {
+	return &TrackPricesUseCase{
+		ProductRepository:      productRepository,
+		PriceHistoryRepository: priceHistoryRepository,
+		FavoritesRepository:    favoritesRepository,
+		Notifier:               notifier,
+	}
+}
+
+func (u *TrackPricesUseCase) Execute(ctx context.Context) (*TrackResult, error) {
+	ctx, span := tracer.Start(ctx, "TrackPricesUseCase.Execute")
+	defer span.End()
+
+	products, err := u.ProductRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := u.PriceHistoryRepository.FindLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []*entity.PricePoint
+	for _, product := range products {
+		if oldPrice, tracked := latest[product.Id]; tracked && entity.SamePrice(oldPrice, product.Price) {
+			continue
+		}
+
+		points = append(points, entity.NewPricePoint(product))
+	}
+
+	recorded, err := u.PriceHistoryRepository.Record(ctx, points)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[int64]string, len(products))
+	for _, product := range products {
+		titles[product.Id] = product.Title
+	}
+
+	// Only the points recorded by this pass notify, and against the price
+	// Record replaced under its lock, so a change another replica already
+	// recorded is neither announced twice nor with a stale old price.
+	var drops []*entity.PriceDrop
+	for _, change := range recorded {
+		if change.IsDrop() {
+			drops = append(drops, &entity.PriceDrop{
+				ProductId: change.Point.ProductId,
+				Title:     titles[change.Point.ProductId],
+				OldPrice:  *change.Previous,
+				NewPrice:  change.Point.Price,
+			})
+		}
+	}
+
+	result := &TrackResult{Recorded: len(recorded), Drops: len(drops)}
+	for _, drop := range drops {
+		result.Notified += u.notify(ctx, drop)
+	}
+
+	if result.Recorded > 0 {
+		logger.FromContext(ctx).Info("product prices tracked",
+			slog.Int("recorded", result.Recorded),
+			slog.Int("drops", result.Drops),
+			slog.Int("notified", result.Notified),
+		)
+	}
+
+	return result, nil
+}
+
+// notify sends drop to every customer who favorited the product. The prices
+// are already recorded, so failures are logged rather than returned.
+func (u *TrackPricesUseCase) notify(ctx context.Context, drop *entity.PriceDrop) int {
+	customerIds, err := u.FavoritesRepository.FindCustomerIdsByProduct(ctx, drop.ProductId)
+	if err != nil {
+		logger.FromContext(ctx).Warn("loading customers to notify of price drop", slog.Int64("product_id", drop.ProductId), slog.Any("error", err))
+		return 0
+	}
+
+	notified := 0
+	for _, customerId := range customerIds {
+		customerDrop := *drop
+		customerDrop.CustomerId = customerId
+
+		if err := u.Notifier.NotifyPriceDrop(ctx, &customerDrop); err != nil {
+			logger.FromContext(ctx).Warn("notifying price drop", slog.String("customer_id", customerId), slog.Int64("product_id", drop.ProductId), slog.Any("error", err))
+			continue
+		}
+		notified++
+	}
+
+	return notified
+}
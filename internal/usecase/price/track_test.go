@@ -0,0 +1,163 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProductRepository struct {
+	repository.ProductRepository
+	products []*entity.Product
+}
+
+func (s *stubProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	return s.products, nil
+}
+
+type stubPriceHistoryRepository struct {
+	points []*entity.PricePoint
+}
+
+func (s *stubPriceHistoryRepository) FindLatest(ctx context.Context) (map[int64]float64, error) {
+	latest := map[int64]float64{}
+	for _, point := range s.points {
+		latest[point.ProductId] = point.Price
+	}
+
+	return latest, nil
+}
+
+func (s *stubPriceHistoryRepository) Record(ctx context.Context, points []*entity.PricePoint) ([]*entity.PriceChange, error) {
+	latest, _ := s.FindLatest(ctx)
+
+	var changes []*entity.PriceChange
+	for _, point := range points {
+		change := &entity.PriceChange{Point: point}
+		if price, tracked := latest[point.ProductId]; tracked {
+			if entity.SamePrice(price, point.Price) {
+				continue
+			}
+			change.Previous = &price
+		}
+
+		s.points = append(s.points, point)
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func (s *stubPriceHistoryRepository) FindByProduct(ctx context.Context, productId int64) ([]*entity.PricePoint, error) {
+	return nil, nil
+}
+
+type stubFavoritesRepository struct {
+	repository.FavoritesRepository
+	customers map[int64][]string
+}
+
+func (s *stubFavoritesRepository) FindCustomerIdsByProduct(ctx context.Context, productId int64) ([]string, error) {
+	return s.customers[productId], nil
+}
+
+type stubNotifier struct {
+	drops   []*entity.PriceDrop
+	failFor string
+}
+
+func (s *stubNotifier) NotifyPriceDrop(ctx context.Context, drop *entity.PriceDrop) error {
+	if drop.CustomerId == s.failFor {
+		return errors.New("notifier down")
+	}
+
+	s.drops = append(s.drops, drop)
+	return nil
+}
+
+func TestTrackPricesUseCase_Execute(t *testing.T) {
+	products := &stubProductRepository{products: []*entity.Product{
+		{Id: 1, Title: "Backpack", Price: 109.95},
+		{Id: 2, Title: "T-Shirt", Price: 22.3},
+	}}
+	history := &stubPriceHistoryRepository{}
+	favorites := &stubFavoritesRepository{customers: map[int64][]string{1: {"customer-1", "customer-2"}}}
+	notifier := &stubNotifier{}
+	useCase := NewTrackPricesUseCase(products, history, favorites, notifier)
+
+	result, err := useCase.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &TrackResult{Recorded: 2}, result, "first pass only records a baseline")
+
+	result, err = useCase.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &TrackResult{}, result, "unchanged prices are not recorded again")
+
+	products.products[1].Price = 22.3000001
+	result, err = useCase.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &TrackResult{}, result, "prices equal in cents are not recorded again")
+
+	products.products[0].Price = 89.9
+	products.products[1].Price = 25
+	result, err = useCase.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &TrackResult{Recorded: 2, Drops: 1, Notified: 2}, result)
+	assert.Len(t, history.points, 4)
+
+	require.Len(t, notifier.drops, 2)
+	assert.Equal(t, &entity.PriceDrop{CustomerId: "customer-1", ProductId: 1, Title: "Backpack", OldPrice: 109.95, NewPrice: 89.9}, notifier.drops[0])
+	assert.Equal(t, "customer-2", notifier.drops[1].CustomerId)
+}
+
+func TestTrackPricesUseCase_NotifierFailure(t *testing.T) {
+	products := &stubProductRepository{products: []*entity.Product{{Id: 1, Title: "Backpack", Price: 89.9}}}
+	history := &stubPriceHistoryRepository{points: []*entity.PricePoint{{ProductId: 1, Price: 109.95}}}
+	favorites := &stubFavoritesRepository{customers: map[int64][]string{1: {"customer-1", "customer-2"}}}
+	notifier := &stubNotifier{failFor: "customer-1"}
+
+	result, err := NewTrackPricesUseCase(products, history, favorites, notifier).Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &TrackResult{Recorded: 1, Drops: 1, Notified: 1}, result)
+	assert.Len(t, history.points, 2)
+}
+
+// staleHistory reads the latest prices from before another replica recorded
+// its pass, while Record sees the current ones.
+type staleHistory struct {
+	*stubPriceHistoryRepository
+	stale map[int64]float64
+}
+
+func (s *staleHistory) FindLatest(ctx context.Context) (map[int64]float64, error) {
+	return s.stale, nil
+}
+
+func TestTrackPricesUseCase_ConcurrentTracker(t *testing.T) {
+	products := &stubProductRepository{products: []*entity.Product{
+		{Id: 1, Title: "Backpack", Price: 80},
+		{Id: 2, Title: "T-Shirt", Price: 20},
+	}}
+	history := &staleHistory{
+		stubPriceHistoryRepository: &stubPriceHistoryRepository{points: []*entity.PricePoint{
+			{ProductId: 1, Price: 100}, {ProductId: 1, Price: 90},
+			{ProductId: 2, Price: 25}, {ProductId: 2, Price: 20},
+		}},
+		stale: map[int64]float64{1: 100, 2: 25},
+	}
+	favorites := &stubFavoritesRepository{customers: map[int64][]string{1: {"customer-1"}, 2: {"customer-1"}}}
+	notifier := &stubNotifier{}
+
+	result, err := NewTrackPricesUseCase(products, history, favorites, notifier).Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &TrackResult{Recorded: 1, Drops: 1, Notified: 1}, result, "the drop already recorded by the other replica is not announced again")
+	require.Len(t, notifier.drops, 1)
+	assert.Equal(t, 90.0, notifier.drops[0].OldPrice, "the old price is the one Record replaced")
+}
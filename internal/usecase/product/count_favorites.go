@@ -0,0 +1,38 @@
+package product
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type CountFavoritesProductUseCase struct {
+	Repository          repository.ProductRepository
+	FavoritesRepository repository.FavoritesRepository
+}
+
+func NewCountFavoritesProductUseCase(repository repository.ProductRepository, favoritesRepository repository.FavoritesRepository) *CountFavoritesProductUseCase {
+	return &CountFavoritesProductUseCase{
+		Repository:          repository,
+		FavoritesRepository: favoritesRepository,
+	}
+}
+
+// Execute returns the product together with how many customers favorited it.
+func (u *CountFavoritesProductUseCase) Execute(ctx context.Context, productId int64) (*entity.Product, int64, error) {
+	ctx, span := tracer.Start(ctx, "CountFavoritesProductUseCase.Execute")
+	defer span.End()
+
+	product, err := u.Repository.FindById(ctx, productId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	counts, err := u.FavoritesRepository.CountByProductIds(ctx, []int64{productId})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return product, counts[productId], nil
+}
@@ -0,0 +1,30 @@
+package product
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type CreateProductUseCase struct {
+	Writer repository.ProductWriter
+}
+
+// NewCreateProductUseCase takes a nil writer when the configured catalog is read-only.
+func NewCreateProductUseCase(writer repository.ProductWriter) *CreateProductUseCase {
+	return &CreateProductUseCase{
+		Writer: writer,
+	}
+}
+
+func (u *CreateProductUseCase) Execute(ctx context.Context, product *entity.Product) (*entity.Product, error) {
+	ctx, span := tracer.Start(ctx, "CreateProductUseCase.Execute")
+	defer span.End()
+
+	if u.Writer == nil {
+		return nil, repository.ErrProductCatalogReadOnly
+	}
+
+	return u.Writer.Create(ctx, product)
+}
@@ -0,0 +1,29 @@
+package product
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type DeleteProductUseCase struct {
+	Writer repository.ProductWriter
+}
+
+// NewDeleteProductUseCase takes a nil writer when the configured catalog is read-only.
+func NewDeleteProductUseCase(writer repository.ProductWriter) *DeleteProductUseCase {
+	return &DeleteProductUseCase{
+		Writer: writer,
+	}
+}
+
+func (u *DeleteProductUseCase) Execute(ctx context.Context, productId int64) error {
+	ctx, span := tracer.Start(ctx, "DeleteProductUseCase.Execute")
+	defer span.End()
+
+	if u.Writer == nil {
+		return repository.ErrProductCatalogReadOnly
+	}
+
+	return u.Writer.Delete(ctx, productId)
+}
@@ -0,0 +1,30 @@
+package product
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type EditProductUseCase struct {
+	Writer repository.ProductWriter
+}
+
+// NewEditProductUseCase takes a nil writer when the configured catalog is read-only.
+func NewEditProductUseCase(writer repository.ProductWriter) *EditProductUseCase {
+	return &EditProductUseCase{
+		Writer: writer,
+	}
+}
+
+func (u *EditProductUseCase) Execute(ctx context.Context, product *entity.Product) error {
+	ctx, span := tracer.Start(ctx, "EditProductUseCase.Execute")
+	defer span.End()
+
+	if u.Writer == nil {
+		return repository.ErrProductCatalogReadOnly
+	}
+
+	return u.Writer.Update(ctx, product)
+}
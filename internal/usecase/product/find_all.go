@@ -1,25 +1,229 @@
 package product
 
 import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
 )
 
+const (
+	SortPrice     = "price"
+	SortRating    = "rating"
+	SortRateCount = "rate_count"
+
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidSort        = errors.New("sort must be one of price, rating, rate_count, optionally prefixed with -")
+	ErrInvalidPriceRange  = errors.New("min_price cannot be greater than max_price")
+	ErrInvalidRatingRange = errors.New("min_rating cannot be greater than max_rating")
+	ErrInvalidLimit       = errors.New("limit must be between 1 and 100")
+	ErrInvalidOffset      = errors.New("offset cannot be negative")
+)
+
+// ProductQuery narrows and orders the catalog. Zero values mean no filter,
+// ordering by id and the first DefaultLimit products.
+type ProductQuery struct {
+	Category  string
+	Search    string
+	MinPrice  *float64
+	MaxPrice  *float64
+	MinRating *float64
+	MaxRating *float64
+	// Sort is a field name, prefixed with - for descending order.
+	Sort   string
+	Limit  int
+	Offset int
+	// IncludeFavoriteCount fills ProductPage.FavoriteCounts for the page.
+	IncludeFavoriteCount bool
+}
+
+func (q ProductQuery) Validate() error {
+	if _, _, ok := q.sortField(); !ok {
+		return ErrInvalidSort
+	}
+
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return ErrInvalidPriceRange
+	}
+
+	if q.MinRating != nil && q.MaxRating != nil && *q.MinRating > *q.MaxRating {
+		return ErrInvalidRatingRange
+	}
+
+	if q.Limit < 0 || q.Limit > MaxLimit {
+		return ErrInvalidLimit
+	}
+
+	if q.Offset < 0 {
+		return ErrInvalidOffset
+	}
+
+	return nil
+}
+
+func (q ProductQuery) sortField() (field string, desc bool, ok bool) {
+	field, desc = strings.CutPrefix(q.Sort, "-")
+	switch field {
+	case SortPrice, SortRating, SortRateCount:
+		return field, desc, true
+	case "":
+		return field, false, !desc
+	default:
+		return field, desc, false
+	}
+}
+
+func (q ProductQuery) filter() *entity.ProductFilter {
+	field, desc, _ := q.sortField()
+	return &entity.ProductFilter{
+		Category:  q.Category,
+		Search:    q.Search,
+		MinPrice:  q.MinPrice,
+		MaxPrice:  q.MaxPrice,
+		MinRating: q.MinRating,
+		MaxRating: q.MaxRating,
+		SortField: field,
+		SortDesc:  desc,
+		Limit:     q.Limit,
+		Offset:    q.Offset,
+	}
+}
+
+func (q ProductQuery) matches(product *entity.Product) bool {
+	if q.Search != "" && !strings.Contains(strings.ToLower(product.Title), strings.ToLower(q.Search)) {
+		return false
+	}
+
+	if (q.MinPrice != nil && product.Price < *q.MinPrice) || (q.MaxPrice != nil && product.Price > *q.MaxPrice) {
+		return false
+	}
+
+	if (q.MinRating != nil && product.Rate < *q.MinRating) || (q.MaxRating != nil && product.Rate > *q.MaxRating) {
+		return false
+	}
+
+	return true
+}
+
+func (q ProductQuery) compare(a, b *entity.Product) int {
+	field, desc, _ := q.sortField()
+
+	var result int
+	switch field {
+	case SortPrice:
+		result = cmp.Compare(a.Price, b.Price)
+	case SortRating:
+		result = cmp.Compare(a.Rate, b.Rate)
+	case SortRateCount:
+		result = cmp.Compare(a.RateCount, b.RateCount)
+	}
+
+	if desc {
+		result = -result
+	}
+
+	return cmp.Or(result, cmp.Compare(a.Id, b.Id))
+}
+
+// ProductPage is one page of the filtered catalog. Total counts every product
+// that matched the filters, not only the ones in the page.
+type ProductPage struct {
+	Products []*entity.Product
+	Total    int
+	Limit    int
+	Offset   int
+	// FavoriteCounts is keyed by product id and nil unless requested;
+	// products nobody favorited are missing from it.
+	FavoriteCounts map[int64]int64
+}
+
 type FindAllProductUseCase struct {
 	Repository repository.ProductRepository
+	// Searcher is nil when the provider cannot filter the catalog itself.
+	Searcher            repository.ProductSearcher
+	FavoritesRepository repository.FavoritesRepository
 }
 
-func NewFindAllProductUseCase(repository repository.ProductRepository) *FindAllProductUseCase {
+func NewFindAllProductUseCase(repository repository.ProductRepository, searcher repository.ProductSearcher, favoritesRepository repository.FavoritesRepository) *FindAllProductUseCase {
 	return &FindAllProductUseCase{
-		Repository: repository,
+		Repository:          repository,
+		Searcher:            searcher,
+		FavoritesRepository: favoritesRepository,
 	}
 }
 
-func (g *FindAllProductUseCase) Execute() ([]*entity.Product, error) {
-	products, err := g.Repository.FindAll()
+// Execute hands the query to the provider when it can run it, and otherwise
+// applies it in memory, so search, ranges and sorting behave the same for
+// every provider, including fakestoreapi which supports none of them.
+func (g *FindAllProductUseCase) Execute(ctx context.Context, query ProductQuery) (*ProductPage, error) {
+	ctx, span := tracer.Start(ctx, "FindAllProductUseCase.Execute")
+	defer span.End()
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	if query.Limit == 0 {
+		query.Limit = DefaultLimit
+	}
+
+	page := &ProductPage{Limit: query.Limit, Offset: query.Offset}
+
+	var err error
+	if g.Searcher != nil {
+		page.Products, page.Total, err = g.Searcher.Search(ctx, query.filter())
+	} else {
+		page.Products, page.Total, err = g.search(ctx, query)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return products, nil
+	if query.IncludeFavoriteCount {
+		productIds := make([]int64, len(page.Products))
+		for i, product := range page.Products {
+			productIds[i] = product.Id
+		}
+
+		page.FavoriteCounts, err = g.FavoritesRepository.CountByProductIds(ctx, productIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+func (g *FindAllProductUseCase) search(ctx context.Context, query ProductQuery) ([]*entity.Product, int, error) {
+	var products []*entity.Product
+	var err error
+	if query.Category != "" {
+		products, err = g.Repository.FindByCategory(ctx, query.Category)
+	} else {
+		products, err = g.Repository.FindAll(ctx)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matched := make([]*entity.Product, 0, len(products))
+	for _, product := range products {
+		if query.matches(product) {
+			matched = append(matched, product)
+		}
+	}
+	slices.SortStableFunc(matched, query.compare)
+
+	start := min(query.Offset, len(matched))
+	end := min(start+query.Limit, len(matched))
+
+	return matched[start:end], len(matched), nil
 }
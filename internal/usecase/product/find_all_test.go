@@ -0,0 +1,178 @@
+package product
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProductRepository struct {
+	products []*entity.Product
+}
+
+func (s *stubProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	return s.products, nil
+}
+
+func (s *stubProductRepository) FindById(ctx context.Context, id int64) (*entity.Product, error) {
+	for _, product := range s.products {
+		if product.Id == id {
+			return product, nil
+		}
+	}
+
+	return nil, repository.ErrProductNotFound
+}
+
+func (s *stubProductRepository) FindByIds(ctx context.Context, ids []int64) (map[int64]*entity.Product, error) {
+	found := map[int64]*entity.Product{}
+	for _, product := range s.products {
+		if slices.Contains(ids, product.Id) {
+			found[product.Id] = product
+		}
+	}
+
+	return found, nil
+}
+
+func (s *stubProductRepository) FindByCategory(ctx context.Context, category string) ([]*entity.Product, error) {
+	var products []*entity.Product
+	for _, product := range s.products {
+		if product.Category == category {
+			products = append(products, product)
+		}
+	}
+
+	return products, nil
+}
+
+func (s *stubProductRepository) FindCategories(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func newCatalogUseCase() *FindAllProductUseCase {
+	return NewFindAllProductUseCase(&stubProductRepository{products: []*entity.Product{
+		{Id: 1, Title: "Backpack", Category: "men's clothing", Price: 109.95, Rate: 3.9, RateCount: 120},
+		{Id: 2, Title: "Slim Fit T-Shirt", Category: "men's clothing", Price: 22.3, Rate: 4.1, RateCount: 259},
+		{Id: 3, Title: "Cotton Jacket", Category: "men's clothing", Price: 55.99, Rate: 4.7, RateCount: 500},
+		{Id: 4, Title: "Gold Bracelet", Category: "jewelery", Price: 695, Rate: 4.6, RateCount: 400},
+		{Id: 5, Title: "Solid Gold Petite Micropave", Category: "jewelery", Price: 168, Rate: 3.9, RateCount: 70},
+	}}, nil, nil)
+}
+
+func ids(products []*entity.Product) []int64 {
+	result := make([]int64, len(products))
+	for i, product := range products {
+		result[i] = product.Id
+	}
+
+	return result
+}
+
+func ptr(value float64) *float64 {
+	return &value
+}
+
+func TestFindAllProductUseCase_Filters(t *testing.T) {
+	tests := map[string]struct {
+		query ProductQuery
+		want  []int64
+	}{
+		"no filter":       {query: ProductQuery{}, want: []int64{1, 2, 3, 4, 5}},
+		"category":        {query: ProductQuery{Category: "jewelery"}, want: []int64{4, 5}},
+		"search":          {query: ProductQuery{Search: "GOLD"}, want: []int64{4, 5}},
+		"price range":     {query: ProductQuery{MinPrice: ptr(50), MaxPrice: ptr(200)}, want: []int64{1, 3, 5}},
+		"min rating":      {query: ProductQuery{MinRating: ptr(4.5)}, want: []int64{3, 4}},
+		"combined":        {query: ProductQuery{Category: "men's clothing", MaxRating: ptr(4.5), Search: "ck"}, want: []int64{1}},
+		"sort price":      {query: ProductQuery{Sort: "price"}, want: []int64{2, 3, 1, 5, 4}},
+		"sort -rating":    {query: ProductQuery{Sort: "-rating"}, want: []int64{3, 4, 2, 1, 5}},
+		"sort rate_count": {query: ProductQuery{Sort: "rate_count"}, want: []int64{5, 1, 2, 4, 3}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			page, err := newCatalogUseCase().Execute(context.Background(), tt.query)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(page.Products))
+			assert.Equal(t, len(tt.want), page.Total)
+		})
+	}
+}
+
+func TestFindAllProductUseCase_Pagination(t *testing.T) {
+	useCase := newCatalogUseCase()
+
+	page, err := useCase.Execute(context.Background(), ProductQuery{Sort: "-price", Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 1}, ids(page.Products))
+	assert.Equal(t, 5, page.Total)
+	assert.Equal(t, 2, page.Limit)
+	assert.Equal(t, 1, page.Offset)
+
+	page, err = useCase.Execute(context.Background(), ProductQuery{Limit: 2, Offset: 10})
+	require.NoError(t, err)
+	assert.Empty(t, page.Products)
+	assert.Equal(t, 5, page.Total)
+
+	page, err = useCase.Execute(context.Background(), ProductQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLimit, page.Limit, "a missing limit falls back to a page size")
+}
+
+type stubProductSearcher struct {
+	filter *entity.ProductFilter
+}
+
+func (s *stubProductSearcher) Search(ctx context.Context, filter *entity.ProductFilter) ([]*entity.Product, int, error) {
+	s.filter = filter
+	return []*entity.Product{{Id: 4}}, 7, nil
+}
+
+func TestFindAllProductUseCase_Searcher(t *testing.T) {
+	useCase := newCatalogUseCase()
+	searcher := &stubProductSearcher{}
+	useCase.Searcher = searcher
+
+	page, err := useCase.Execute(context.Background(), ProductQuery{Category: "jewelery", MinPrice: ptr(10), Sort: "-rating", Offset: 3})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4}, ids(page.Products))
+	assert.Equal(t, 7, page.Total)
+	assert.Equal(t, &entity.ProductFilter{
+		Category:  "jewelery",
+		MinPrice:  ptr(10),
+		SortField: SortRating,
+		SortDesc:  true,
+		Limit:     DefaultLimit,
+		Offset:    3,
+	}, searcher.filter)
+}
+
+func TestFindAllProductUseCase_InvalidQuery(t *testing.T) {
+	tests := map[string]struct {
+		query ProductQuery
+		err   error
+	}{
+		"unknown sort":  {query: ProductQuery{Sort: "title"}, err: ErrInvalidSort},
+		"bare minus":    {query: ProductQuery{Sort: "-"}, err: ErrInvalidSort},
+		"price range":   {query: ProductQuery{MinPrice: ptr(10), MaxPrice: ptr(5)}, err: ErrInvalidPriceRange},
+		"rating range":  {query: ProductQuery{MinRating: ptr(4), MaxRating: ptr(3)}, err: ErrInvalidRatingRange},
+		"limit too big": {query: ProductQuery{Limit: MaxLimit + 1}, err: ErrInvalidLimit},
+		"negative":      {query: ProductQuery{Offset: -1}, err: ErrInvalidOffset},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			page, err := newCatalogUseCase().Execute(context.Background(), tt.query)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Nil(t, page)
+		})
+	}
+}
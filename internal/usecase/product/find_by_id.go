@@ -1,6 +1,8 @@
 package product
 
 import (
+	"context"
+
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
 )
@@ -15,7 +17,10 @@ func NewFindByIdProductUseCase(repository repository.ProductRepository) *FindByI
 	}
 }
 
-func (g *FindByIdProductUseCase) Execute(productId int64) (*entity.Product, error) {
-	product, err := g.Repository.FindById(productId)
+func (g *FindByIdProductUseCase) Execute(ctx context.Context, productId int64) (*entity.Product, error) {
+	ctx, span := tracer.Start(ctx, "FindByIdProductUseCase.Execute")
+	defer span.End()
+
+	product, err := g.Repository.FindById(ctx, productId)
 	return product, err
 }
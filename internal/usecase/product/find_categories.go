@@ -0,0 +1,33 @@
+package product
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type FindCategoriesProductUseCase struct {
+	Repository repository.ProductRepository
+}
+
+func NewFindCategoriesProductUseCase(repository repository.ProductRepository) *FindCategoriesProductUseCase {
+	return &FindCategoriesProductUseCase{
+		Repository: repository,
+	}
+}
+
+func (f *FindCategoriesProductUseCase) Execute(ctx context.Context) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "FindCategoriesProductUseCase.Execute")
+	defer span.End()
+
+	categories, err := f.Repository.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if categories == nil {
+		categories = []string{}
+	}
+
+	return categories, nil
+}
@@ -0,0 +1,95 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+const MaxMostFavoritedWindow = 365 * 24 * time.Hour
+
+var ErrInvalidWindow = errors.New("days must be between 1 and 365")
+
+// RankedProduct is a catalog product and how many customers favorited it in the window.
+type RankedProduct struct {
+	Product       *entity.Product
+	FavoriteCount int64
+}
+
+type MostFavorited struct {
+	Since    time.Time
+	Products []*RankedProduct
+}
+
+type MostFavoritedProductUseCase struct {
+	Repository          repository.ProductRepository
+	FavoritesRepository repository.FavoritesRepository
+}
+
+func NewMostFavoritedProductUseCase(repository repository.ProductRepository, favoritesRepository repository.FavoritesRepository) *MostFavoritedProductUseCase {
+	return &MostFavoritedProductUseCase{
+		Repository:          repository,
+		FavoritesRepository: favoritesRepository,
+	}
+}
+
+// Execute ranks the catalog by favorites created in the last window. Products
+// that left the catalog are skipped, so they do not take a place in the
+// ranking; the counts are read a page of limit at a time until it is full,
+// with one catalog lookup per page.
+func (u *MostFavoritedProductUseCase) Execute(ctx context.Context, window time.Duration, limit int) (*MostFavorited, error) {
+	ctx, span := tracer.Start(ctx, "MostFavoritedProductUseCase.Execute")
+	defer span.End()
+
+	if window <= 0 || window > MaxMostFavoritedWindow {
+		return nil, ErrInvalidWindow
+	}
+
+	if limit < 1 || limit > MaxLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	since := time.Now().Add(-window)
+	ranking := &MostFavorited{Since: since, Products: []*RankedProduct{}}
+	for offset := 0; len(ranking.Products) < limit; offset += limit {
+		counts, err := u.FavoritesRepository.CountBySince(ctx, since, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(counts) == 0 {
+			break
+		}
+
+		productIds := make([]int64, len(counts))
+		for i, count := range counts {
+			productIds[i] = count.ProductId
+		}
+
+		products, err := u.Repository.FindByIds(ctx, productIds)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, count := range counts {
+			product, ok := products[count.ProductId]
+			if !ok {
+				continue
+			}
+
+			ranking.Products = append(ranking.Products, &RankedProduct{Product: product, FavoriteCount: count.Favorites})
+			if len(ranking.Products) == limit {
+				break
+			}
+		}
+
+		if len(counts) < limit {
+			break
+		}
+	}
+
+	return ranking, nil
+}
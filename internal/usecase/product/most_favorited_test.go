@@ -0,0 +1,93 @@
+package product
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFavoritesRepository struct {
+	repository.FavoritesRepository
+	counts []*entity.FavoritedProduct
+	since  time.Time
+	pages  int
+}
+
+func (s *stubFavoritesRepository) CountBySince(ctx context.Context, since time.Time, limit, offset int) ([]*entity.FavoritedProduct, error) {
+	s.since = since
+	s.pages++
+	if offset >= len(s.counts) {
+		return nil, nil
+	}
+
+	return s.counts[offset:min(offset+limit, len(s.counts))], nil
+}
+
+func (s *stubFavoritesRepository) CountByProductIds(ctx context.Context, productIds []int64) (map[int64]int64, error) {
+	counts := map[int64]int64{}
+	for _, count := range s.counts {
+		if slices.Contains(productIds, count.ProductId) {
+			counts[count.ProductId] = count.Favorites
+		}
+	}
+
+	return counts, nil
+}
+
+func TestFindAllProductUseCase_IncludeFavoriteCount(t *testing.T) {
+	useCase := newCatalogUseCase()
+	useCase.FavoritesRepository = &stubFavoritesRepository{counts: []*entity.FavoritedProduct{
+		{ProductId: 1, Favorites: 4},
+		{ProductId: 5, Favorites: 2},
+	}}
+
+	page, err := useCase.Execute(context.Background(), ProductQuery{Limit: 2, IncludeFavoriteCount: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{1: 4}, page.FavoriteCounts)
+
+	page, err = useCase.Execute(context.Background(), ProductQuery{})
+
+	require.NoError(t, err)
+	assert.Nil(t, page.FavoriteCounts)
+}
+
+func TestMostFavoritedProductUseCase_SkipsProductsOutOfCatalog(t *testing.T) {
+	favorites := &stubFavoritesRepository{counts: []*entity.FavoritedProduct{
+		{ProductId: 3, Favorites: 9},
+		{ProductId: 99, Favorites: 7},
+		{ProductId: 1, Favorites: 4},
+		{ProductId: 5, Favorites: 2},
+	}}
+	useCase := NewMostFavoritedProductUseCase(newCatalogUseCase().Repository, favorites)
+
+	ranking, err := useCase.Execute(context.Background(), 7*24*time.Hour, 2)
+
+	require.NoError(t, err)
+	require.Len(t, ranking.Products, 2)
+	assert.Equal(t, int64(3), ranking.Products[0].Product.Id)
+	assert.Equal(t, int64(9), ranking.Products[0].FavoriteCount)
+	assert.Equal(t, int64(1), ranking.Products[1].Product.Id)
+	assert.Equal(t, 2, favorites.pages, "the product out of the catalog pulls a second page")
+	assert.Equal(t, ranking.Since, favorites.since)
+	assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), ranking.Since, time.Minute)
+}
+
+func TestMostFavoritedProductUseCase_Invalid(t *testing.T) {
+	useCase := NewMostFavoritedProductUseCase(newCatalogUseCase().Repository, &stubFavoritesRepository{})
+
+	_, err := useCase.Execute(context.Background(), 0, 10)
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+
+	_, err = useCase.Execute(context.Background(), 400*24*time.Hour, 10)
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+
+	_, err = useCase.Execute(context.Background(), time.Hour, 0)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
@@ -0,0 +1,131 @@
+package recommendation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+const MaxLimit = 50
+
+var ErrInvalidLimit = errors.New("limit must be between 1 and 50")
+
+type FindRecommendationsUseCase struct {
+	CustomerRepository       repository.CustomerRepository
+	ProductRepository        repository.ProductRepository
+	RecommendationRepository repository.RecommendationRepository
+}
+
+func NewFindRecommendationsUseCase(
+	customerRepository repository.CustomerRepository,
+	productRepository repository.ProductRepository,
+	recommendationRepository repository.RecommendationRepository,
+) *FindRecommendationsUseCase {
+	return &FindRecommendationsUseCase{
+		CustomerRepository:       customerRepository,
+		ProductRepository:        productRepository,
+		RecommendationRepository: recommendationRepository,
+	}
+}
+
+// Execute ranks products by how many customers favorited them together with
+// the customer's favorites, then by category affinity. Products of the
+// customer's favorite categories fill the list when there are not enough
+// co-favorites, e.g. before the first refresh. Favorited products and products
+// missing from the catalog are never recommended.
+func (u *FindRecommendationsUseCase) Execute(ctx context.Context, customerId string, limit int) ([]*entity.Recommendation, error) {
+	ctx, span := tracer.Start(ctx, "FindRecommendationsUseCase.Execute")
+	defer span.End()
+
+	if limit < 1 || limit > MaxLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	customer, err := u.CustomerRepository.FindById(ctx, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if customer == nil {
+		return nil, errors.New("customer not found")
+	}
+
+	favoritedIds, err := u.RecommendationRepository.FindFavoritedProductIds(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(favoritedIds) == 0 {
+		return []*entity.Recommendation{}, nil
+	}
+
+	coFavorited, err := u.RecommendationRepository.FindCoFavorited(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := u.ProductRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[int64]*entity.Product, len(products))
+	for _, product := range products {
+		catalog[product.Id] = product
+	}
+
+	favorited := make(map[int64]bool, len(favoritedIds))
+	affinity := map[string]int{}
+	for _, productId := range favoritedIds {
+		favorited[productId] = true
+		if product, ok := catalog[productId]; ok && product.Category != "" {
+			affinity[product.Category]++
+		}
+	}
+
+	recommended := map[int64]bool{}
+	recommendations := []*entity.Recommendation{}
+	for _, coFavorite := range coFavorited {
+		product, ok := catalog[coFavorite.ProductId]
+		if !ok || favorited[product.Id] {
+			continue
+		}
+
+		recommended[product.Id] = true
+		recommendations = append(recommendations, &entity.Recommendation{
+			Product:          product,
+			CoFavorites:      coFavorite.Favorites,
+			CategoryAffinity: affinity[product.Category],
+		})
+	}
+
+	if len(recommendations) < limit {
+		for _, product := range products {
+			if favorited[product.Id] || recommended[product.Id] || affinity[product.Category] == 0 {
+				continue
+			}
+
+			recommendations = append(recommendations, &entity.Recommendation{
+				Product:          product,
+				CategoryAffinity: affinity[product.Category],
+			})
+		}
+	}
+
+	slices.SortStableFunc(recommendations, compareRecommendations)
+
+	return recommendations[:min(limit, len(recommendations))], nil
+}
+
+func compareRecommendations(a, b *entity.Recommendation) int {
+	return cmp.Or(
+		cmp.Compare(b.CoFavorites, a.CoFavorites),
+		cmp.Compare(b.CategoryAffinity, a.CategoryAffinity),
+		cmp.Compare(b.Product.Rate, a.Product.Rate),
+		cmp.Compare(a.Product.Id, b.Product.Id),
+	)
+}
@@ -0,0 +1,80 @@
+package recommendation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecommendationsUseCase(repository *stubRecommendationRepository) *FindRecommendationsUseCase {
+	customers := &stubCustomerRepository{customers: map[string]*entity.Customer{
+		"customer-1": {Id: "customer-1", Name: "Julio", Email: "julio@example.com"},
+	}}
+	products := &stubProductRepository{products: []*entity.Product{
+		{Id: 1, Title: "Backpack", Category: "men's clothing", Price: 109.95, Rate: 3.9},
+		{Id: 2, Title: "Slim Fit T-Shirt", Category: "men's clothing", Price: 22.3, Rate: 4.1},
+		{Id: 3, Title: "Cotton Jacket", Category: "men's clothing", Price: 55.99, Rate: 4.7},
+		{Id: 4, Title: "Gold Bracelet", Category: "jewelery", Price: 695, Rate: 4.6},
+		{Id: 5, Title: "SSD 1TB", Category: "electronics", Price: 109, Rate: 4.8},
+	}}
+
+	return NewFindRecommendationsUseCase(customers, products, repository)
+}
+
+func recommendedIds(recommendations []*entity.Recommendation) []int64 {
+	ids := make([]int64, len(recommendations))
+	for i, recommendation := range recommendations {
+		ids[i] = recommendation.Product.Id
+	}
+
+	return ids
+}
+
+func TestFindRecommendationsUseCase_RanksByCoFavoritesThenAffinity(t *testing.T) {
+	repository := &stubRecommendationRepository{
+		favorited: map[string][]int64{"customer-1": {1}},
+		coFavorited: map[string][]*entity.FavoritedProduct{"customer-1": {
+			{ProductId: 4, Favorites: 3},
+			{ProductId: 99, Favorites: 3},
+			{ProductId: 5, Favorites: 1},
+			{ProductId: 2, Favorites: 1},
+		}},
+	}
+
+	recommendations, err := newRecommendationsUseCase(repository).Execute(context.Background(), "customer-1", 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{4, 2, 5, 3}, recommendedIds(recommendations))
+	assert.Equal(t, int64(3), recommendations[0].CoFavorites)
+	assert.Equal(t, 1, recommendations[1].CategoryAffinity)
+	assert.Equal(t, int64(0), recommendations[3].CoFavorites)
+}
+
+func TestFindRecommendationsUseCase_CategoryFallback(t *testing.T) {
+	repository := &stubRecommendationRepository{favorited: map[string][]int64{"customer-1": {1}}}
+
+	recommendations, err := newRecommendationsUseCase(repository).Execute(context.Background(), "customer-1", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3}, recommendedIds(recommendations))
+}
+
+func TestFindRecommendationsUseCase_NoFavorites(t *testing.T) {
+	recommendations, err := newRecommendationsUseCase(&stubRecommendationRepository{}).Execute(context.Background(), "customer-1", 10)
+
+	require.NoError(t, err)
+	assert.Empty(t, recommendations)
+}
+
+func TestFindRecommendationsUseCase_Errors(t *testing.T) {
+	useCase := newRecommendationsUseCase(&stubRecommendationRepository{})
+
+	_, err := useCase.Execute(context.Background(), "customer-1", MaxLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+
+	_, err = useCase.Execute(context.Background(), "missing", 10)
+	assert.EqualError(t, err, "customer not found")
+}
@@ -0,0 +1,36 @@
+package recommendation
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+)
+
+// RefreshRecommendationsUseCase rebuilds the co-favorite pairs that
+// FindRecommendationsUseCase reads, so requests never aggregate the whole
+// favorites table.
+type RefreshRecommendationsUseCase struct {
+	Repository repository.RecommendationRepository
+}
+
+func NewRefreshRecommendationsUseCase(repository repository.RecommendationRepository) *RefreshRecommendationsUseCase {
+	return &RefreshRecommendationsUseCase{
+		Repository: repository,
+	}
+}
+
+func (u *RefreshRecommendationsUseCase) Execute(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "RefreshRecommendationsUseCase.Execute")
+	defer span.End()
+
+	pairs, err := u.Repository.Refresh(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.FromContext(ctx).Info("recommendations refreshed", slog.Int64("pairs", pairs))
+
+	return pairs, nil
+}
@@ -0,0 +1,44 @@
+package recommendation
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type stubCustomerRepository struct {
+	repository.CustomerRepository
+	customers map[string]*entity.Customer
+}
+
+func (s *stubCustomerRepository) FindById(ctx context.Context, id string) (*entity.Customer, error) {
+	return s.customers[id], nil
+}
+
+type stubProductRepository struct {
+	repository.ProductRepository
+	products []*entity.Product
+}
+
+func (s *stubProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	return s.products, nil
+}
+
+// stubRecommendationRepository serves fixed co-favorites and favorites per customer id.
+type stubRecommendationRepository struct {
+	coFavorited map[string][]*entity.FavoritedProduct
+	favorited   map[string][]int64
+}
+
+func (s *stubRecommendationRepository) Refresh(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubRecommendationRepository) FindCoFavorited(ctx context.Context, customer *entity.Customer) ([]*entity.FavoritedProduct, error) {
+	return s.coFavorited[customer.Id], nil
+}
+
+func (s *stubRecommendationRepository) FindFavoritedProductIds(ctx context.Context, customer *entity.Customer) ([]int64, error) {
+	return s.favorited[customer.Id], nil
+}
@@ -0,0 +1,229 @@
+package seed
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/logger"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	firstNames = []string{"Ana", "Bruno", "Carla", "Diego", "Eduarda", "Felipe", "Gabriela", "Henrique", "Isabela", "João"}
+	lastNames  = []string{"Silva", "Santos", "Oliveira", "Souza", "Lima", "Pereira", "Costa", "Almeida"}
+)
+
+// UserCredentials is a user to create, with the password in plain text.
+type UserCredentials struct {
+	Email    string
+	Password string
+}
+
+// Result counts what a run created; everything else already existed.
+type Result struct {
+	Users     int
+	Customers int
+	Favorites int
+}
+
+// SeedUseCase fills a development database with users, fake customers and
+// favorites. Running it again creates only what is missing: customers are
+// found by email and the favorites picked for each customer depend only on
+// RandomSeed and the customer's position.
+type SeedUseCase struct {
+	UserRepository      repository.UserRepository
+	CustomerRepository  repository.CustomerRepository
+	FavoritesRepository repository.FavoritesRepository
+	ProductRepository   repository.ProductRepository
+
+	Users                []UserCredentials
+	Customers            int
+	FavoritesPerCustomer int
+	RandomSeed           int64
+}
+
+func NewSeedUseCase(
+	userRepository repository.UserRepository,
+	customerRepository repository.CustomerRepository,
+	favoritesRepository repository.FavoritesRepository,
+	productRepository repository.ProductRepository,
+	users []UserCredentials,
+	customers int,
+	favoritesPerCustomer int,
+	randomSeed int64,
+) *SeedUseCase {
+	return &SeedUseCase{
+		UserRepository:       userRepository,
+		CustomerRepository:   customerRepository,
+		FavoritesRepository:  favoritesRepository,
+		ProductRepository:    productRepository,
+		Users:                users,
+		Customers:            customers,
+		FavoritesPerCustomer: favoritesPerCustomer,
+		RandomSeed:           randomSeed,
+	}
+}
+
+func (u *SeedUseCase) Execute(ctx context.Context) (*Result, error) {
+	ctx, span := tracer.Start(ctx, "SeedUseCase.Execute")
+	defer span.End()
+
+	result := &Result{}
+
+	for _, credentials := range u.Users {
+		created, err := u.seedUser(ctx, credentials)
+		if err != nil {
+			return result, err
+		}
+
+		if created {
+			result.Users++
+		}
+	}
+
+	customers := make([]*entity.Customer, 0, u.Customers)
+	for i := range u.Customers {
+		customer, created, err := u.seedCustomer(ctx, i)
+		if err != nil {
+			return result, err
+		}
+
+		if created {
+			result.Customers++
+		}
+		customers = append(customers, customer)
+	}
+
+	if u.FavoritesPerCustomer > 0 && len(customers) > 0 {
+		products, err := u.ProductRepository.FindAll(ctx)
+		if err != nil {
+			return result, fmt.Errorf("error while getting products: %w", err)
+		}
+
+		// The picks are indexes into the catalog, so keep its order stable.
+		slices.SortFunc(products, func(a, b *entity.Product) int {
+			return cmp.Compare(a.Id, b.Id)
+		})
+
+		for i, customer := range customers {
+			added, err := u.seedFavorites(ctx, customer, products, i)
+			if err != nil {
+				return result, err
+			}
+
+			result.Favorites += added
+		}
+	}
+
+	logger.FromContext(ctx).Info("database seeded",
+		slog.Int("users", result.Users),
+		slog.Int("customers", result.Customers),
+		slog.Int("favorites", result.Favorites),
+	)
+
+	return result, nil
+}
+
+func (u *SeedUseCase) seedUser(ctx context.Context, credentials UserCredentials) (bool, error) {
+	existing, err := u.UserRepository.FindByEmail(ctx, credentials.Email)
+	if err != nil {
+		return false, fmt.Errorf("error while finding user %s: %w", credentials.Email, err)
+	}
+
+	if existing != nil {
+		return false, nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return false, fmt.Errorf("error while hashing password of %s: %w", credentials.Email, err)
+	}
+
+	user, err := entity.NewUser(userName(credentials.Email), credentials.Email, string(hash))
+	if err != nil {
+		return false, fmt.Errorf("invalid seed user %s: %w", credentials.Email, err)
+	}
+
+	if err := u.UserRepository.Create(ctx, user); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (u *SeedUseCase) seedCustomer(ctx context.Context, i int) (*entity.Customer, bool, error) {
+	email := fmt.Sprintf("cliente%03d@example.com", i+1)
+
+	existing, err := u.CustomerRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, false, fmt.Errorf("error while finding customer %s: %w", email, err)
+	}
+
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	name := firstNames[i%len(firstNames)] + " " + lastNames[i/len(firstNames)%len(lastNames)]
+	customer, err := entity.NewCustomer(name, email)
+	if err != nil {
+		return nil, false, err
+	}
+
+	customer, err = u.CustomerRepository.Create(ctx, customer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return customer, true, nil
+}
+
+// seedFavorites adds the products picked for the i-th customer that the
+// customer has not favorited yet.
+func (u *SeedUseCase) seedFavorites(ctx context.Context, customer *entity.Customer, products []*entity.Product, i int) (int, error) {
+	favorites, err := u.FavoritesRepository.FindAllByCustomer(ctx, customer)
+	if err != nil {
+		return 0, err
+	}
+
+	favorited := make(map[int64]bool, len(favorites))
+	for _, favorite := range favorites {
+		favorited[favorite.ProductId] = true
+	}
+
+	random := rand.New(rand.NewPCG(uint64(u.RandomSeed), uint64(i)))
+	picks := random.Perm(len(products))[:min(u.FavoritesPerCustomer, len(products))]
+
+	added := 0
+	for _, pick := range picks {
+		product := products[pick]
+		if favorited[product.Id] {
+			continue
+		}
+
+		// Seeding ignores the favorites limit; FAVORITES_MAX_PER_CUSTOMER still
+		// applies to anything added through the API afterwards.
+		if err := u.FavoritesRepository.AddToCustomer(ctx, customer, entity.NewFavorite(product), 0); err != nil {
+			return added, fmt.Errorf("error while adding product %d to %s: %w", product.Id, customer.Email, err)
+		}
+		added++
+	}
+
+	return added, nil
+}
+
+// userName turns the email's local part into a display name: admin@admin.com is Admin.
+func userName(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	if local == "" {
+		return email
+	}
+
+	return strings.ToUpper(local[:1]) + local[1:]
+}
@@ -0,0 +1,75 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newSeedUseCase() (*SeedUseCase, *stubUserRepository, *stubCustomerRepository, *stubFavoritesRepository) {
+	users := &stubUserRepository{users: map[string]*entity.User{}}
+	customers := &stubCustomerRepository{customers: map[string]*entity.Customer{}}
+	favorites := &stubFavoritesRepository{favorites: map[string][]int64{}}
+	products := &stubProductRepository{}
+	for id := int64(10); id > 0; id-- {
+		products.products = append(products.products, &entity.Product{Id: id, Title: "Product", Price: 10})
+	}
+
+	useCase := NewSeedUseCase(users, customers, favorites, products,
+		[]UserCredentials{{Email: "admin@admin.com", Password: "admin"}}, 3, 4, 1)
+
+	return useCase, users, customers, favorites
+}
+
+func TestSeedUseCase_CreatesUsersCustomersAndFavorites(t *testing.T) {
+	useCase, users, customers, favorites := newSeedUseCase()
+
+	result, err := useCase.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Users: 1, Customers: 3, Favorites: 12}, result)
+
+	admin := users.users["admin@admin.com"]
+	require.NotNil(t, admin)
+	assert.Equal(t, "Admin", admin.Name)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte("admin")))
+
+	require.Contains(t, customers.customers, "cliente001@example.com")
+	assert.Equal(t, "Ana Silva", customers.customers["cliente001@example.com"].Name)
+	for _, customer := range customers.customers {
+		assert.Len(t, favorites.favorites[customer.Id], 4)
+	}
+}
+
+func TestSeedUseCase_IsIdempotent(t *testing.T) {
+	useCase, _, customers, favorites := newSeedUseCase()
+
+	_, err := useCase.Execute(context.Background())
+	require.NoError(t, err)
+	picked := favorites.favorites[customers.customers["cliente002@example.com"].Id]
+
+	result, err := useCase.Execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &Result{}, result)
+	assert.Len(t, customers.customers, 3)
+	assert.Equal(t, picked, favorites.favorites[customers.customers["cliente002@example.com"].Id])
+}
+
+func TestSeedUseCase_SameSeedPicksSameFavorites(t *testing.T) {
+	first, _, firstCustomers, firstFavorites := newSeedUseCase()
+	second, _, secondCustomers, secondFavorites := newSeedUseCase()
+
+	_, err := first.Execute(context.Background())
+	require.NoError(t, err)
+	_, err = second.Execute(context.Background())
+	require.NoError(t, err)
+
+	for email, customer := range firstCustomers.customers {
+		assert.Equal(t, firstFavorites.favorites[customer.Id], secondFavorites.favorites[secondCustomers.customers[email].Id])
+	}
+}
@@ -0,0 +1,65 @@
+package seed
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type stubUserRepository struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func (s *stubUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return s.users[email], nil
+}
+
+func (s *stubUserRepository) Create(ctx context.Context, user *entity.User) error {
+	s.users[user.Email] = user
+	return nil
+}
+
+type stubCustomerRepository struct {
+	repository.CustomerRepository
+	customers map[string]*entity.Customer
+}
+
+func (s *stubCustomerRepository) FindByEmail(ctx context.Context, email string) (*entity.Customer, error) {
+	return s.customers[email], nil
+}
+
+func (s *stubCustomerRepository) Create(ctx context.Context, customer *entity.Customer) (*entity.Customer, error) {
+	s.customers[customer.Email] = customer
+	return customer, nil
+}
+
+// stubFavoritesRepository keeps the favorited product ids per customer id.
+type stubFavoritesRepository struct {
+	repository.FavoritesRepository
+	favorites map[string][]int64
+}
+
+func (s *stubFavoritesRepository) FindAllByCustomer(ctx context.Context, customer *entity.Customer) ([]*entity.Favorite, error) {
+	var favorites []*entity.Favorite
+	for _, productId := range s.favorites[customer.Id] {
+		favorites = append(favorites, &entity.Favorite{ProductId: productId})
+	}
+
+	return favorites, nil
+}
+
+func (s *stubFavoritesRepository) AddToCustomer(ctx context.Context, customer *entity.Customer, favorite *entity.Favorite, limit int) error {
+	s.favorites[customer.Id] = append(s.favorites[customer.Id], favorite.ProductId)
+	return nil
+}
+
+type stubProductRepository struct {
+	repository.ProductRepository
+	products []*entity.Product
+}
+
+func (s *stubProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	return s.products, nil
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type CreateWebhookUseCase struct {
+	Repository repository.WebhookRepository
+}
+
+func NewCreateWebhookUseCase(repository repository.WebhookRepository) *CreateWebhookUseCase {
+	return &CreateWebhookUseCase{
+		Repository: repository,
+	}
+}
+
+func (c *CreateWebhookUseCase) Execute(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "CreateWebhookUseCase.Execute")
+	defer span.End()
+
+	return c.Repository.Create(ctx, webhook)
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type DeleteWebhookUseCase struct {
+	Repository repository.WebhookRepository
+}
+
+func NewDeleteWebhookUseCase(repository repository.WebhookRepository) *DeleteWebhookUseCase {
+	return &DeleteWebhookUseCase{
+		Repository: repository,
+	}
+}
+
+func (u *DeleteWebhookUseCase) Execute(ctx context.Context, webhookId string) error {
+	ctx, span := tracer.Start(ctx, "DeleteWebhookUseCase.Execute")
+	defer span.End()
+
+	webhook, err := u.Repository.FindById(ctx, webhookId)
+	if err != nil {
+		return err
+	}
+
+	if webhook == nil {
+		return errors.New("webhook not found")
+	}
+
+	return u.Repository.Delete(ctx, webhook)
+}
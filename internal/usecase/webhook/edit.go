@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type EditWebhookUseCase struct {
+	Repository repository.WebhookRepository
+}
+
+func NewEditWebhookUseCase(repository repository.WebhookRepository) *EditWebhookUseCase {
+	return &EditWebhookUseCase{
+		Repository: repository,
+	}
+}
+
+func (u *EditWebhookUseCase) Execute(ctx context.Context, webhookId, url string, events []string, active bool) (*entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "EditWebhookUseCase.Execute")
+	defer span.End()
+
+	webhook, err := u.Repository.FindById(ctx, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil {
+		return nil, errors.New("webhook not found")
+	}
+
+	webhook, err = entity.NewWebhookWithId(webhook.Id, url, events, webhook.Secret, active)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.Repository.Update(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type FindAllWebhookUseCase struct {
+	Repository repository.WebhookRepository
+}
+
+func NewFindAllWebhookUseCase(repository repository.WebhookRepository) *FindAllWebhookUseCase {
+	return &FindAllWebhookUseCase{
+		Repository: repository,
+	}
+}
+
+func (f *FindAllWebhookUseCase) Execute(ctx context.Context) ([]*entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "FindAllWebhookUseCase.Execute")
+	defer span.End()
+
+	return f.Repository.FindAll(ctx)
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type FindByIdWebhookUseCase struct {
+	Repository repository.WebhookRepository
+}
+
+func NewFindByIdWebhookUseCase(repository repository.WebhookRepository) *FindByIdWebhookUseCase {
+	return &FindByIdWebhookUseCase{
+		Repository: repository,
+	}
+}
+
+func (f *FindByIdWebhookUseCase) Execute(ctx context.Context, webhookId string) (*entity.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "FindByIdWebhookUseCase.Execute")
+	defer span.End()
+
+	return f.Repository.FindById(ctx, webhookId)
+}
@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+)
+
+type FindDeliveriesWebhookUseCase struct {
+	WebhookRepository  repository.WebhookRepository
+	DeliveryRepository repository.WebhookDeliveryRepository
+}
+
+func NewFindDeliveriesWebhookUseCase(
+	webhookRepository repository.WebhookRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+) *FindDeliveriesWebhookUseCase {
+	return &FindDeliveriesWebhookUseCase{
+		WebhookRepository:  webhookRepository,
+		DeliveryRepository: deliveryRepository,
+	}
+}
+
+func (f *FindDeliveriesWebhookUseCase) Execute(ctx context.Context, webhookId string) ([]*entity.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "FindDeliveriesWebhookUseCase.Execute")
+	defer span.End()
+
+	webhook, err := f.WebhookRepository.FindById(ctx, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil {
+		return nil, errors.New("webhook not found")
+	}
+
+	return f.DeliveryRepository.FindAllByWebhook(ctx, webhook)
+}
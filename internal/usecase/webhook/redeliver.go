@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/entity"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
+)
+
+type RedeliverWebhookUseCase struct {
+	WebhookRepository  repository.WebhookRepository
+	DeliveryRepository repository.WebhookDeliveryRepository
+	Sender             service.WebhookSender
+}
+
+func NewRedeliverWebhookUseCase(
+	webhookRepository repository.WebhookRepository,
+	deliveryRepository repository.WebhookDeliveryRepository,
+	sender service.WebhookSender,
+) *RedeliverWebhookUseCase {
+	return &RedeliverWebhookUseCase{
+		WebhookRepository:  webhookRepository,
+		DeliveryRepository: deliveryRepository,
+		Sender:             sender,
+	}
+}
+
+func (u *RedeliverWebhookUseCase) Execute(ctx context.Context, webhookId, deliveryId string) (*entity.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "RedeliverWebhookUseCase.Execute")
+	defer span.End()
+
+	webhook, err := u.WebhookRepository.FindById(ctx, webhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil {
+		return nil, errors.New("webhook not found")
+	}
+
+	delivery, err := u.DeliveryRepository.FindById(ctx, deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery == nil || delivery.WebhookId != webhook.Id {
+		return nil, errors.New("delivery not found")
+	}
+
+	if err := u.Sender.Redeliver(ctx, webhook, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,17 @@
+package wire
+
+import (
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/router"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/scheduler"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/sse"
+	webhookDispatcher "github.com/juliocsrf/aiqfome-challenge/internal/adapter/webhook"
+)
+
+// App holds the router and the background components main needs to stop on shutdown.
+type App struct {
+	Router            *router.Router
+	WebhookDispatcher *webhookDispatcher.Dispatcher
+	EventBroker       *sse.Broker
+	// Schedulers are the background jobs enabled in the config.
+	Schedulers []*scheduler.Scheduler
+}
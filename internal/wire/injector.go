@@ -3,14 +3,47 @@
 package wire
 
 import (
-	"database/sql"
-
 	"github.com/google/wire"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/juliocsrf/aiqfome-challenge/config"
-	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/router"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/catalog"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/customer"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/seed"
 )
 
-func InitializeApp(db *sql.DB, conf *config.Conf) (*router.Router, error) {
+func InitializeApp(db *pgxpool.Pool, conf *config.Conf) (*App, error) {
 	wire.Build(AllProviders)
-	return &router.Router{}, nil
+	return &App{}, nil
+}
+
+// InitializeSync builds only what the sync command needs to import the catalog.
+func InitializeSync(db *pgxpool.Pool, conf *config.Conf) (*catalog.SyncCatalogUseCase, error) {
+	wire.Build(ProvideQueries, ProvideFakestoreapiClient, ProvideCatalogRepository, ProvideSyncCatalogUseCase)
+	return nil, nil
+}
+
+// InitializeSeed builds only what the seed command needs to fill a development database.
+func InitializeSeed(db *pgxpool.Pool, conf *config.Conf) (*seed.SeedUseCase, error) {
+	wire.Build(
+		ProvideQueries,
+		ProvideFakestoreapiClient,
+		ProvideProductRepository,
+		ProvideUserRepository,
+		ProvideCustomerRepository,
+		ProvideFavoritesRepository,
+		ProvideSeedUseCase,
+	)
+	return nil, nil
+}
+
+// InitializeCustomerImport builds only what the customers command needs to import customers.
+func InitializeCustomerImport(db *pgxpool.Pool, conf *config.Conf) (*customer.ImportCustomersUseCase, error) {
+	wire.Build(ProvideQueries, ProvideCustomerRepository, ProvideBusinessMetrics, ProvideImportCustomersUseCase)
+	return nil, nil
+}
+
+// InitializeCustomerExport builds only what the customers command needs to export customers.
+func InitializeCustomerExport(db *pgxpool.Pool, conf *config.Conf) (*customer.ExportCustomersUseCase, error) {
+	wire.Build(ProvideQueries, ProvideCustomerRepository, ProvideExportCustomersUseCase)
+	return nil, nil
 }
@@ -1,28 +1,51 @@
 package wire
 
 import (
-	"database/sql"
+	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/wire"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/juliocsrf/aiqfome-challenge/config"
 	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/database"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/health"
 	authHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/auth"
+	collectionHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/collection"
 	customerHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/customer"
 	favoriteHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/favorite"
+	healthHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/health"
 	productHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/product"
+	webhookHandler "github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/handler/webhook"
 	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/router"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/metrics"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/notifier"
 	productRepo "github.com/juliocsrf/aiqfome-challenge/internal/adapter/repository/fakestoreapi"
+	fileRepo "github.com/juliocsrf/aiqfome-challenge/internal/adapter/repository/file"
 	customerRepo "github.com/juliocsrf/aiqfome-challenge/internal/adapter/repository/postgres"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/scheduler"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/sse"
+	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/tracing"
+	webhookDispatcher "github.com/juliocsrf/aiqfome-challenge/internal/adapter/webhook"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/event"
 	"github.com/juliocsrf/aiqfome-challenge/internal/domain/repository"
+	"github.com/juliocsrf/aiqfome-challenge/internal/domain/service"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/auth"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/catalog"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/collection"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/customer"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/favorite"
+	healthUseCase "github.com/juliocsrf/aiqfome-challenge/internal/usecase/health"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/price"
 	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/product"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/recommendation"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/seed"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/webhook"
 )
 
 // Database providers
-func ProvideQueries(db *sql.DB) *database.Queries {
-	return database.New(db)
+func ProvideQueries(db *pgxpool.Pool) *database.Queries {
+	return database.New(tracing.NewDB(db, "postgresql"))
 }
 
 // Repository providers
@@ -30,21 +53,131 @@ func ProvideCustomerRepository(queries *database.Queries) repository.CustomerRep
 	return customerRepo.NewCustomerRepository(queries)
 }
 
-func ProvideFavoritesRepository(queries *database.Queries) repository.FavoritesRepository {
-	return customerRepo.NewFavoritesRepository(queries)
+func ProvideFavoritesRepository(db *pgxpool.Pool, queries *database.Queries) repository.FavoritesRepository {
+	return customerRepo.NewFavoritesRepository(db, queries)
+}
+
+func ProvideCollectionRepository(db *pgxpool.Pool, queries *database.Queries) repository.CollectionRepository {
+	return customerRepo.NewCollectionRepository(db, queries)
 }
 
 func ProvideUserRepository(queries *database.Queries) repository.UserRepository {
 	return customerRepo.NewUserRepository(queries)
 }
 
-func ProvideProductRepository() repository.ProductRepository {
-	return productRepo.NewProductRepository()
+func ProvideFakestoreapiClient(conf *config.Conf) *productRepo.Client {
+	return productRepo.NewClient(productRepo.ClientOptions{
+		BaseURL:          conf.Fakestoreapi.BaseURL,
+		Timeout:          conf.Fakestoreapi.Timeout,
+		MaxRetries:       conf.Fakestoreapi.MaxRetries,
+		RetryBackoff:     conf.Fakestoreapi.RetryBackoff,
+		BreakerThreshold: conf.Fakestoreapi.BreakerThreshold,
+		BreakerCooldown:  conf.Fakestoreapi.BreakerCooldown,
+	})
+}
+
+// ProvideProductRepository selects the product catalog from PRODUCT_PROVIDER.
+func ProvideProductRepository(conf *config.Conf, client *productRepo.Client, queries *database.Queries) (repository.ProductRepository, error) {
+	switch conf.Catalog.Provider {
+	case config.ProductProviderFakestoreapi:
+		return productRepo.NewProductRepository(client), nil
+	case config.ProductProviderPostgres:
+		return customerRepo.NewProductRepository(queries), nil
+	case config.ProductProviderFile:
+		return fileRepo.NewProductRepository(conf.Catalog.File)
+	default:
+		return nil, fmt.Errorf("unknown product provider %q", conf.Catalog.Provider)
+	}
+}
+
+func ProvidePriceHistoryRepository(db *pgxpool.Pool, queries *database.Queries) repository.PriceHistoryRepository {
+	return customerRepo.NewPriceHistoryRepository(db, queries)
+}
+
+func ProvideRecommendationRepository(db *pgxpool.Pool, queries *database.Queries) repository.RecommendationRepository {
+	return customerRepo.NewRecommendationRepository(db, queries)
+}
+
+func ProvideCatalogRepository(db *pgxpool.Pool, queries *database.Queries) repository.CatalogRepository {
+	return customerRepo.NewCatalogRepository(db, queries)
+}
+
+// ProvideProductSearcher returns nil when the selected catalog is filtered in memory.
+func ProvideProductSearcher(repo repository.ProductRepository) repository.ProductSearcher {
+	if searcher, ok := repo.(repository.ProductSearcher); ok {
+		return searcher
+	}
+
+	return nil
+}
+
+// ProvideProductWriter returns nil when the selected catalog is read-only.
+func ProvideProductWriter(repo repository.ProductRepository) repository.ProductWriter {
+	if writer, ok := repo.(repository.ProductWriter); ok {
+		return writer
+	}
+
+	return nil
+}
+
+func ProvideWebhookRepository(queries *database.Queries) repository.WebhookRepository {
+	return customerRepo.NewWebhookRepository(queries)
+}
+
+func ProvideWebhookDeliveryRepository(queries *database.Queries) repository.WebhookDeliveryRepository {
+	return customerRepo.NewWebhookDeliveryRepository(queries)
+}
+
+// Event providers
+func ProvideWebhookDispatcher(
+	webhookRepo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	conf *config.Conf,
+) *webhookDispatcher.Dispatcher {
+	return webhookDispatcher.NewDispatcher(webhookRepo, deliveryRepo, conf.Webhook.MaxAttempts, conf.Webhook.InitialBackoff, conf.Webhook.Timeout, conf.Webhook.AllowPrivateNetworks)
+}
+
+func ProvideEventBroker() *sse.Broker {
+	return sse.NewBroker(sse.DefaultHistorySize)
+}
+
+func ProvideEventPublisher(dispatcher *webhookDispatcher.Dispatcher, broker *sse.Broker) event.Publisher {
+	return event.MultiPublisher{dispatcher, broker}
+}
+
+func ProvidePriceDropNotifier(conf *config.Conf, publisher event.Publisher) (service.PriceDropNotifier, error) {
+	return notifier.New(conf.Pricing.Notifier, publisher)
+}
+
+func ProvideEventSubscriber(broker *sse.Broker) event.Subscriber {
+	return broker
+}
+
+func ProvideWebhookSender(dispatcher *webhookDispatcher.Dispatcher) service.WebhookSender {
+	return dispatcher
+}
+
+// Health check providers
+func ProvideHealthCheckers(db *pgxpool.Pool, conf *config.Conf) []service.HealthChecker {
+	checkers := []service.HealthChecker{
+		health.NewDatabaseChecker(db),
+	}
+
+	if conf.Catalog.Provider == config.ProductProviderFakestoreapi {
+		checkers = append(checkers, health.NewHTTPChecker("fakestoreapi", conf.Fakestoreapi.BaseURL+"/products/1"))
+	}
+
+	return checkers
+}
+
+// Metrics providers
+func ProvideBusinessMetrics() service.BusinessMetrics {
+	return metrics.NewBusinessMetrics()
 }
 
 // Use case providers
-func ProvideCreateCustomerUseCase(repo repository.CustomerRepository) *customer.CreateCustomerUseCase {
-	return customer.NewCreateCustomerUseCase(repo)
+func ProvideCreateCustomerUseCase(repo repository.CustomerRepository, businessMetrics service.BusinessMetrics) *customer.CreateCustomerUseCase {
+	return customer.NewCreateCustomerUseCase(repo, businessMetrics)
 }
 
 func ProvideFindByIdCustomerUseCase(
@@ -63,36 +196,248 @@ func ProvideDeleteCustomerUseCase(repo repository.CustomerRepository) *customer.
 	return customer.NewDeleteCustomerUseCase(repo)
 }
 
-func ProvideFindAllProductUseCase(repo repository.ProductRepository) *product.FindAllProductUseCase {
-	return product.NewFindAllProductUseCase(repo)
+func ProvideImportCustomersUseCase(repo repository.CustomerRepository, businessMetrics service.BusinessMetrics) *customer.ImportCustomersUseCase {
+	return customer.NewImportCustomersUseCase(repo, businessMetrics)
+}
+
+func ProvideExportCustomersUseCase(repo repository.CustomerRepository) *customer.ExportCustomersUseCase {
+	return customer.NewExportCustomersUseCase(repo)
+}
+
+func ProvideFindAllProductUseCase(repo repository.ProductRepository, searcher repository.ProductSearcher, favoritesRepo repository.FavoritesRepository) *product.FindAllProductUseCase {
+	return product.NewFindAllProductUseCase(repo, searcher, favoritesRepo)
 }
 
 func ProvideFindByIdProductUseCase(repo repository.ProductRepository) *product.FindByIdProductUseCase {
 	return product.NewFindByIdProductUseCase(repo)
 }
 
+func ProvideCountFavoritesProductUseCase(repo repository.ProductRepository, favoritesRepo repository.FavoritesRepository) *product.CountFavoritesProductUseCase {
+	return product.NewCountFavoritesProductUseCase(repo, favoritesRepo)
+}
+
+func ProvideMostFavoritedProductUseCase(repo repository.ProductRepository, favoritesRepo repository.FavoritesRepository) *product.MostFavoritedProductUseCase {
+	return product.NewMostFavoritedProductUseCase(repo, favoritesRepo)
+}
+
+func ProvideFindCategoriesProductUseCase(repo repository.ProductRepository) *product.FindCategoriesProductUseCase {
+	return product.NewFindCategoriesProductUseCase(repo)
+}
+
+func ProvideTrackPricesUseCase(
+	productRepo repository.ProductRepository,
+	priceHistoryRepo repository.PriceHistoryRepository,
+	favoritesRepo repository.FavoritesRepository,
+	notifier service.PriceDropNotifier,
+) *price.TrackPricesUseCase {
+	return price.NewTrackPricesUseCase(productRepo, priceHistoryRepo, favoritesRepo, notifier)
+}
+
+func ProvideFindPriceHistoryUseCase(priceHistoryRepo repository.PriceHistoryRepository, productRepo repository.ProductRepository) *price.FindPriceHistoryUseCase {
+	return price.NewFindPriceHistoryUseCase(priceHistoryRepo, productRepo)
+}
+
+// ProvideSyncCatalogUseCase always reads from fakestoreapi, whatever PRODUCT_PROVIDER serves.
+func ProvideSyncCatalogUseCase(client *productRepo.Client, catalogRepository repository.CatalogRepository) *catalog.SyncCatalogUseCase {
+	return catalog.NewSyncCatalogUseCase(productRepo.NewProductRepository(client), catalogRepository)
+}
+
+func ProvideFindRecommendationsUseCase(customerRepo repository.CustomerRepository, productRepo repository.ProductRepository, recommendationRepo repository.RecommendationRepository) *recommendation.FindRecommendationsUseCase {
+	return recommendation.NewFindRecommendationsUseCase(customerRepo, productRepo, recommendationRepo)
+}
+
+func ProvideRefreshRecommendationsUseCase(recommendationRepo repository.RecommendationRepository) *recommendation.RefreshRecommendationsUseCase {
+	return recommendation.NewRefreshRecommendationsUseCase(recommendationRepo)
+}
+
+func ProvideSeedUseCase(
+	conf *config.Conf,
+	userRepo repository.UserRepository,
+	customerRepo repository.CustomerRepository,
+	favoritesRepo repository.FavoritesRepository,
+	productRepo repository.ProductRepository,
+) *seed.SeedUseCase {
+	users := make([]seed.UserCredentials, 0, len(conf.Seed.Users))
+	for _, entry := range conf.Seed.Users {
+		email, password, _ := config.ParseSeedUser(entry)
+		users = append(users, seed.UserCredentials{Email: email, Password: password})
+	}
+
+	// Outside development the seed only bootstraps the users: fake customers
+	// and favorites never reach a real database.
+	customers, favoritesPerCustomer := conf.Seed.Customers, conf.Seed.FavoritesPerCustomer
+	if !conf.IsDevelopment() {
+		customers, favoritesPerCustomer = 0, 0
+	}
+
+	return seed.NewSeedUseCase(userRepo, customerRepo, favoritesRepo, productRepo,
+		users, customers, favoritesPerCustomer, int64(conf.Seed.RandomSeed))
+}
+
+func ProvideCreateProductUseCase(writer repository.ProductWriter) *product.CreateProductUseCase {
+	return product.NewCreateProductUseCase(writer)
+}
+
+func ProvideEditProductUseCase(writer repository.ProductWriter) *product.EditProductUseCase {
+	return product.NewEditProductUseCase(writer)
+}
+
+func ProvideDeleteProductUseCase(writer repository.ProductWriter) *product.DeleteProductUseCase {
+	return product.NewDeleteProductUseCase(writer)
+}
+
 func ProvideCreateFavoriteUseCase(
 	favoritesRepo repository.FavoritesRepository,
 	customerRepo repository.CustomerRepository,
 	productRepo repository.ProductRepository,
+	eventPublisher event.Publisher,
+	businessMetrics service.BusinessMetrics,
+	conf *config.Conf,
 ) *favorite.CreateFavoriteUseCase {
-	return favorite.NewCreateFavoriteUseCase(favoritesRepo, customerRepo, productRepo)
+	return favorite.NewCreateFavoriteUseCase(favoritesRepo, customerRepo, productRepo, eventPublisher, businessMetrics, conf.Favorites.MaxPerCustomer)
 }
 
 func ProvideDeleteFavoriteUseCase(
 	favoritesRepo repository.FavoritesRepository,
 	customerRepo repository.CustomerRepository,
 	productRepo repository.ProductRepository,
+	eventPublisher event.Publisher,
+	businessMetrics service.BusinessMetrics,
 ) *favorite.DeleteFavoriteUseCase {
-	return favorite.NewDeleteFavoriteUseCase(favoritesRepo, customerRepo, productRepo)
+	return favorite.NewDeleteFavoriteUseCase(favoritesRepo, customerRepo, productRepo, eventPublisher, businessMetrics)
+}
+
+func ProvideUpdateFavoriteUseCase(favoritesRepo repository.FavoritesRepository, customerRepo repository.CustomerRepository) *favorite.UpdateFavoriteUseCase {
+	return favorite.NewUpdateFavoriteUseCase(favoritesRepo, customerRepo)
+}
+
+func ProvideFindQuotaFavoriteUseCase(favoritesRepo repository.FavoritesRepository, customerRepo repository.CustomerRepository, conf *config.Conf) *favorite.FindQuotaFavoriteUseCase {
+	return favorite.NewFindQuotaFavoriteUseCase(favoritesRepo, customerRepo, conf.Favorites.MaxPerCustomer)
+}
+
+func ProvideSetQuotaFavoriteUseCase(favoritesRepo repository.FavoritesRepository, customerRepo repository.CustomerRepository, conf *config.Conf) *favorite.SetQuotaFavoriteUseCase {
+	return favorite.NewSetQuotaFavoriteUseCase(favoritesRepo, customerRepo, conf.Favorites.MaxPerCustomer)
+}
+
+func ProvideStreamFavoriteUseCase(
+	customerRepo repository.CustomerRepository,
+	eventSubscriber event.Subscriber,
+) *favorite.StreamFavoriteUseCase {
+	return favorite.NewStreamFavoriteUseCase(customerRepo, eventSubscriber)
+}
+
+func ProvideFindOrphanFavoritesUseCase(
+	favoritesRepo repository.FavoritesRepository,
+	productRepo repository.ProductRepository,
+) *favorite.FindOrphanFavoritesUseCase {
+	return favorite.NewFindOrphanFavoritesUseCase(favoritesRepo, productRepo)
+}
+
+func ProvidePruneOrphanFavoritesUseCase(
+	findOrphansUseCase *favorite.FindOrphanFavoritesUseCase,
+	favoritesRepo repository.FavoritesRepository,
+) *favorite.PruneOrphanFavoritesUseCase {
+	return favorite.NewPruneOrphanFavoritesUseCase(findOrphansUseCase, favoritesRepo)
+}
+
+func ProvideFindAllCollectionUseCase(customerRepo repository.CustomerRepository, collectionRepo repository.CollectionRepository) *collection.FindAllCollectionUseCase {
+	return collection.NewFindAllCollectionUseCase(customerRepo, collectionRepo)
+}
+
+func ProvideFindByIdCollectionUseCase(
+	customerRepo repository.CustomerRepository,
+	collectionRepo repository.CollectionRepository,
+	productRepo repository.ProductRepository,
+) *collection.FindByIdCollectionUseCase {
+	return collection.NewFindByIdCollectionUseCase(customerRepo, collectionRepo, productRepo)
+}
+
+func ProvideCreateCollectionUseCase(customerRepo repository.CustomerRepository, collectionRepo repository.CollectionRepository) *collection.CreateCollectionUseCase {
+	return collection.NewCreateCollectionUseCase(customerRepo, collectionRepo)
+}
+
+func ProvideEditCollectionUseCase(customerRepo repository.CustomerRepository, collectionRepo repository.CollectionRepository) *collection.EditCollectionUseCase {
+	return collection.NewEditCollectionUseCase(customerRepo, collectionRepo)
 }
 
-func ProvideLoginUseCase(userRepo repository.UserRepository, jwtSecret string) *auth.LoginUseCase {
-	return auth.NewLoginUseCase(userRepo, jwtSecret)
+func ProvideDeleteCollectionUseCase(customerRepo repository.CustomerRepository, collectionRepo repository.CollectionRepository) *collection.DeleteCollectionUseCase {
+	return collection.NewDeleteCollectionUseCase(customerRepo, collectionRepo)
 }
 
-func ProvideRefreshTokenUseCase(userRepo repository.UserRepository, jwtSecret string) *auth.RefreshTokenUseCase {
-	return auth.NewRefreshTokenUseCase(userRepo, jwtSecret)
+func ProvideAddFavoriteCollectionUseCase(
+	customerRepo repository.CustomerRepository,
+	collectionRepo repository.CollectionRepository,
+	productRepo repository.ProductRepository,
+	eventPublisher event.Publisher,
+	businessMetrics service.BusinessMetrics,
+	conf *config.Conf,
+) *collection.AddFavoriteCollectionUseCase {
+	return collection.NewAddFavoriteCollectionUseCase(customerRepo, collectionRepo, productRepo, eventPublisher, businessMetrics, conf.Favorites.MaxPerCustomer)
+}
+
+func ProvideRemoveFavoriteCollectionUseCase(
+	customerRepo repository.CustomerRepository,
+	collectionRepo repository.CollectionRepository,
+	eventPublisher event.Publisher,
+	businessMetrics service.BusinessMetrics,
+) *collection.RemoveFavoriteCollectionUseCase {
+	return collection.NewRemoveFavoriteCollectionUseCase(customerRepo, collectionRepo, eventPublisher, businessMetrics)
+}
+
+func ProvideTransferFavoriteCollectionUseCase(
+	customerRepo repository.CustomerRepository,
+	collectionRepo repository.CollectionRepository,
+	eventPublisher event.Publisher,
+	businessMetrics service.BusinessMetrics,
+) *collection.TransferFavoriteCollectionUseCase {
+	return collection.NewTransferFavoriteCollectionUseCase(customerRepo, collectionRepo, eventPublisher, businessMetrics)
+}
+
+func ProvideLoginUseCase(conf *config.Conf, userRepo repository.UserRepository, jwtSecret string, businessMetrics service.BusinessMetrics) *auth.LoginUseCase {
+	return auth.NewLoginUseCase(userRepo, jwtSecret, conf.Auth.AccessTokenTTL, conf.Auth.RefreshTokenTTL, businessMetrics)
+}
+
+func ProvideRefreshTokenUseCase(conf *config.Conf, userRepo repository.UserRepository, jwtSecret string) *auth.RefreshTokenUseCase {
+	return auth.NewRefreshTokenUseCase(userRepo, jwtSecret, conf.Auth.AccessTokenTTL)
+}
+
+func ProvideCreateWebhookUseCase(repo repository.WebhookRepository) *webhook.CreateWebhookUseCase {
+	return webhook.NewCreateWebhookUseCase(repo)
+}
+
+func ProvideFindAllWebhookUseCase(repo repository.WebhookRepository) *webhook.FindAllWebhookUseCase {
+	return webhook.NewFindAllWebhookUseCase(repo)
+}
+
+func ProvideFindByIdWebhookUseCase(repo repository.WebhookRepository) *webhook.FindByIdWebhookUseCase {
+	return webhook.NewFindByIdWebhookUseCase(repo)
+}
+
+func ProvideEditWebhookUseCase(repo repository.WebhookRepository) *webhook.EditWebhookUseCase {
+	return webhook.NewEditWebhookUseCase(repo)
+}
+
+func ProvideDeleteWebhookUseCase(repo repository.WebhookRepository) *webhook.DeleteWebhookUseCase {
+	return webhook.NewDeleteWebhookUseCase(repo)
+}
+
+func ProvideFindDeliveriesWebhookUseCase(
+	webhookRepo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+) *webhook.FindDeliveriesWebhookUseCase {
+	return webhook.NewFindDeliveriesWebhookUseCase(webhookRepo, deliveryRepo)
+}
+
+func ProvideRedeliverWebhookUseCase(
+	webhookRepo repository.WebhookRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	sender service.WebhookSender,
+) *webhook.RedeliverWebhookUseCase {
+	return webhook.NewRedeliverWebhookUseCase(webhookRepo, deliveryRepo, sender)
+}
+
+func ProvideReadinessUseCase(checkers []service.HealthChecker) *healthUseCase.ReadinessUseCase {
+	return healthUseCase.NewReadinessUseCase(checkers, 3*time.Second)
 }
 
 // JWT Secret provider
@@ -106,22 +451,52 @@ func ProvideCustomerHandler(
 	findByIdUseCase *customer.FindByIdCustomerUseCase,
 	editUseCase *customer.EditCustomerUseCase,
 	deleteUseCase *customer.DeleteCustomerUseCase,
+	recommendationsUseCase *recommendation.FindRecommendationsUseCase,
+	importUseCase *customer.ImportCustomersUseCase,
+	exportUseCase *customer.ExportCustomersUseCase,
+	conf *config.Conf,
 ) *customerHandler.CustomerHandler {
-	return customerHandler.NewCustomerHandler(createUseCase, findByIdUseCase, editUseCase, deleteUseCase)
+	return customerHandler.NewCustomerHandler(createUseCase, findByIdUseCase, editUseCase, deleteUseCase, recommendationsUseCase, importUseCase, exportUseCase, conf.CustomerImport.MaxBytes, conf.CustomerImport.MaxRows)
 }
 
 func ProvideProductHandler(
 	findAllUseCase *product.FindAllProductUseCase,
 	findByIdUseCase *product.FindByIdProductUseCase,
+	findCategoriesUseCase *product.FindCategoriesProductUseCase,
+	createUseCase *product.CreateProductUseCase,
+	editUseCase *product.EditProductUseCase,
+	deleteUseCase *product.DeleteProductUseCase,
+	priceHistoryUseCase *price.FindPriceHistoryUseCase,
+	countFavoritesUseCase *product.CountFavoritesProductUseCase,
+	mostFavoritedUseCase *product.MostFavoritedProductUseCase,
 ) *productHandler.ProductHandler {
-	return productHandler.NewProductHandler(findAllUseCase, findByIdUseCase)
+	return productHandler.NewProductHandler(findAllUseCase, findByIdUseCase, findCategoriesUseCase, createUseCase, editUseCase, deleteUseCase, priceHistoryUseCase, countFavoritesUseCase, mostFavoritedUseCase)
 }
 
 func ProvideFavoriteHandler(
 	createUseCase *favorite.CreateFavoriteUseCase,
 	deleteUseCase *favorite.DeleteFavoriteUseCase,
+	streamUseCase *favorite.StreamFavoriteUseCase,
+	findOrphansUseCase *favorite.FindOrphanFavoritesUseCase,
+	pruneOrphansUseCase *favorite.PruneOrphanFavoritesUseCase,
+	updateUseCase *favorite.UpdateFavoriteUseCase,
+	findQuotaUseCase *favorite.FindQuotaFavoriteUseCase,
+	setQuotaUseCase *favorite.SetQuotaFavoriteUseCase,
 ) *favoriteHandler.FavoriteHandler {
-	return favoriteHandler.NewFavoriteHandler(createUseCase, deleteUseCase)
+	return favoriteHandler.NewFavoriteHandler(createUseCase, deleteUseCase, streamUseCase, findOrphansUseCase, pruneOrphansUseCase, updateUseCase, findQuotaUseCase, setQuotaUseCase)
+}
+
+func ProvideCollectionHandler(
+	findAllUseCase *collection.FindAllCollectionUseCase,
+	findByIdUseCase *collection.FindByIdCollectionUseCase,
+	createUseCase *collection.CreateCollectionUseCase,
+	editUseCase *collection.EditCollectionUseCase,
+	deleteUseCase *collection.DeleteCollectionUseCase,
+	addFavoriteUseCase *collection.AddFavoriteCollectionUseCase,
+	removeFavoriteUseCase *collection.RemoveFavoriteCollectionUseCase,
+	transferUseCase *collection.TransferFavoriteCollectionUseCase,
+) *collectionHandler.CollectionHandler {
+	return collectionHandler.NewCollectionHandler(findAllUseCase, findByIdUseCase, createUseCase, editUseCase, deleteUseCase, addFavoriteUseCase, removeFavoriteUseCase, transferUseCase)
 }
 
 func ProvideAuthHandler(
@@ -131,23 +506,113 @@ func ProvideAuthHandler(
 	return authHandler.NewAuthHandler(loginUseCase, refreshTokenUseCase)
 }
 
+func ProvideWebhookHandler(
+	createUseCase *webhook.CreateWebhookUseCase,
+	findAllUseCase *webhook.FindAllWebhookUseCase,
+	findByIdUseCase *webhook.FindByIdWebhookUseCase,
+	editUseCase *webhook.EditWebhookUseCase,
+	deleteUseCase *webhook.DeleteWebhookUseCase,
+	findDeliveriesUseCase *webhook.FindDeliveriesWebhookUseCase,
+	redeliverUseCase *webhook.RedeliverWebhookUseCase,
+) *webhookHandler.WebhookHandler {
+	return webhookHandler.NewWebhookHandler(createUseCase, findAllUseCase, findByIdUseCase, editUseCase, deleteUseCase, findDeliveriesUseCase, redeliverUseCase)
+}
+
+func ProvideHealthHandler(readinessUseCase *healthUseCase.ReadinessUseCase) *healthHandler.HealthHandler {
+	return healthHandler.NewHealthHandler(readinessUseCase)
+}
+
 // Router provider
 func ProvideRouter(
 	customerHandler *customerHandler.CustomerHandler,
 	productHandler *productHandler.ProductHandler,
 	favoriteHandler *favoriteHandler.FavoriteHandler,
+	collectionHandler *collectionHandler.CollectionHandler,
 	authHandler *authHandler.AuthHandler,
+	webhookHandler *webhookHandler.WebhookHandler,
+	healthHandler *healthHandler.HealthHandler,
 	jwtSecret string,
+	conf *config.Conf,
 ) *router.Router {
-	return router.NewRouter(customerHandler, productHandler, favoriteHandler, authHandler, jwtSecret)
+	return router.NewRouter(customerHandler, productHandler, favoriteHandler, collectionHandler, authHandler, webhookHandler, healthHandler, jwtSecret, conf.CORS.AllowedOrigins)
+}
+
+// ProvideSchedulers returns the webhook retry poller and the background jobs
+// whose interval is set.
+func ProvideSchedulers(
+	conf *config.Conf,
+	dispatcher *webhookDispatcher.Dispatcher,
+	syncUseCase *catalog.SyncCatalogUseCase,
+	trackUseCase *price.TrackPricesUseCase,
+	refreshRecommendationsUseCase *recommendation.RefreshRecommendationsUseCase,
+) []*scheduler.Scheduler {
+	schedulers := []*scheduler.Scheduler{
+		scheduler.New("webhook-deliveries", conf.Webhook.PollInterval, dispatcher.DeliverDue),
+	}
+
+	if conf.Catalog.SyncInterval > 0 {
+		schedulers = append(schedulers, scheduler.New("catalog-sync", conf.Catalog.SyncInterval, func(ctx context.Context) error {
+			_, err := syncUseCase.Execute(ctx)
+			return err
+		}))
+	}
+
+	if conf.Pricing.TrackInterval > 0 {
+		schedulers = append(schedulers, scheduler.New("price-tracker", conf.Pricing.TrackInterval, func(ctx context.Context) error {
+			_, err := trackUseCase.Execute(ctx)
+			return err
+		}))
+	}
+
+	if conf.Recommendation.RefreshInterval > 0 {
+		schedulers = append(schedulers, scheduler.New("recommendations-refresh", conf.Recommendation.RefreshInterval, func(ctx context.Context) error {
+			_, err := refreshRecommendationsUseCase.Execute(ctx)
+			return err
+		}))
+	}
+
+	return schedulers
+}
+
+// App provider
+func ProvideApp(
+	router *router.Router,
+	dispatcher *webhookDispatcher.Dispatcher,
+	broker *sse.Broker,
+	schedulers []*scheduler.Scheduler,
+) *App {
+	return &App{
+		Router:            router,
+		WebhookDispatcher: dispatcher,
+		EventBroker:       broker,
+		Schedulers:        schedulers,
+	}
 }
 
 // Wire sets
 var RepositorySet = wire.NewSet(
 	ProvideCustomerRepository,
 	ProvideFavoritesRepository,
+	ProvideCollectionRepository,
 	ProvideUserRepository,
+	ProvideFakestoreapiClient,
 	ProvideProductRepository,
+	ProvideProductSearcher,
+	ProvideProductWriter,
+	ProvideCatalogRepository,
+	ProvidePriceHistoryRepository,
+	ProvideRecommendationRepository,
+	ProvideWebhookRepository,
+	ProvideWebhookDeliveryRepository,
+)
+
+var EventSet = wire.NewSet(
+	ProvideWebhookDispatcher,
+	ProvideEventBroker,
+	ProvideEventPublisher,
+	ProvideEventSubscriber,
+	ProvidePriceDropNotifier,
+	ProvideWebhookSender,
 )
 
 var UseCaseSet = wire.NewSet(
@@ -155,26 +620,69 @@ var UseCaseSet = wire.NewSet(
 	ProvideFindByIdCustomerUseCase,
 	ProvideEditCustomerUseCase,
 	ProvideDeleteCustomerUseCase,
+	ProvideImportCustomersUseCase,
+	ProvideExportCustomersUseCase,
 	ProvideFindAllProductUseCase,
 	ProvideFindByIdProductUseCase,
+	ProvideFindCategoriesProductUseCase,
+	ProvideCountFavoritesProductUseCase,
+	ProvideMostFavoritedProductUseCase,
+	ProvideSyncCatalogUseCase,
+	ProvideTrackPricesUseCase,
+	ProvideFindRecommendationsUseCase,
+	ProvideRefreshRecommendationsUseCase,
+	ProvideFindPriceHistoryUseCase,
+	ProvideCreateProductUseCase,
+	ProvideEditProductUseCase,
+	ProvideDeleteProductUseCase,
 	ProvideCreateFavoriteUseCase,
 	ProvideDeleteFavoriteUseCase,
+	ProvideUpdateFavoriteUseCase,
+	ProvideFindQuotaFavoriteUseCase,
+	ProvideSetQuotaFavoriteUseCase,
+	ProvideStreamFavoriteUseCase,
+	ProvideFindOrphanFavoritesUseCase,
+	ProvidePruneOrphanFavoritesUseCase,
+	ProvideFindAllCollectionUseCase,
+	ProvideFindByIdCollectionUseCase,
+	ProvideCreateCollectionUseCase,
+	ProvideEditCollectionUseCase,
+	ProvideDeleteCollectionUseCase,
+	ProvideAddFavoriteCollectionUseCase,
+	ProvideRemoveFavoriteCollectionUseCase,
+	ProvideTransferFavoriteCollectionUseCase,
 	ProvideLoginUseCase,
 	ProvideRefreshTokenUseCase,
+	ProvideCreateWebhookUseCase,
+	ProvideFindAllWebhookUseCase,
+	ProvideFindByIdWebhookUseCase,
+	ProvideEditWebhookUseCase,
+	ProvideDeleteWebhookUseCase,
+	ProvideFindDeliveriesWebhookUseCase,
+	ProvideRedeliverWebhookUseCase,
+	ProvideReadinessUseCase,
 )
 
 var HandlerSet = wire.NewSet(
 	ProvideCustomerHandler,
 	ProvideProductHandler,
 	ProvideFavoriteHandler,
+	ProvideCollectionHandler,
 	ProvideAuthHandler,
+	ProvideWebhookHandler,
+	ProvideHealthHandler,
 )
 
 var AllProviders = wire.NewSet(
 	ProvideQueries,
 	ProvideJWTSecret,
+	ProvideHealthCheckers,
+	ProvideBusinessMetrics,
 	RepositorySet,
+	EventSet,
 	UseCaseSet,
 	HandlerSet,
 	ProvideRouter,
+	ProvideSchedulers,
+	ProvideApp,
 )
@@ -7,34 +7,140 @@
 package wire
 
 import (
-	"database/sql"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/juliocsrf/aiqfome-challenge/config"
-	"github.com/juliocsrf/aiqfome-challenge/internal/adapter/http/chi/router"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/catalog"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/customer"
+	"github.com/juliocsrf/aiqfome-challenge/internal/usecase/seed"
 )
 
 // Injectors from injector.go:
 
-func InitializeApp(db *sql.DB, conf *config.Conf) (*router.Router, error) {
+func InitializeApp(db *pgxpool.Pool, conf *config.Conf) (*App, error) {
 	queries := ProvideQueries(db)
 	customerRepository := ProvideCustomerRepository(queries)
-	createCustomerUseCase := ProvideCreateCustomerUseCase(customerRepository)
-	favoritesRepository := ProvideFavoritesRepository(queries)
-	productRepository := ProvideProductRepository()
+	businessMetrics := ProvideBusinessMetrics()
+	createCustomerUseCase := ProvideCreateCustomerUseCase(customerRepository, businessMetrics)
+	favoritesRepository := ProvideFavoritesRepository(db, queries)
+	client := ProvideFakestoreapiClient(conf)
+	productRepository, err := ProvideProductRepository(conf, client, queries)
+	if err != nil {
+		return nil, err
+	}
 	findByIdCustomerUseCase := ProvideFindByIdCustomerUseCase(customerRepository, favoritesRepository, productRepository)
 	editCustomerUseCase := ProvideEditCustomerUseCase(customerRepository)
 	deleteCustomerUseCase := ProvideDeleteCustomerUseCase(customerRepository)
-	customerHandler := ProvideCustomerHandler(createCustomerUseCase, findByIdCustomerUseCase, editCustomerUseCase, deleteCustomerUseCase)
-	findAllProductUseCase := ProvideFindAllProductUseCase(productRepository)
+	recommendationRepository := ProvideRecommendationRepository(db, queries)
+	findRecommendationsUseCase := ProvideFindRecommendationsUseCase(customerRepository, productRepository, recommendationRepository)
+	importCustomersUseCase := ProvideImportCustomersUseCase(customerRepository, businessMetrics)
+	exportCustomersUseCase := ProvideExportCustomersUseCase(customerRepository)
+	customerHandler := ProvideCustomerHandler(createCustomerUseCase, findByIdCustomerUseCase, editCustomerUseCase, deleteCustomerUseCase, findRecommendationsUseCase, importCustomersUseCase, exportCustomersUseCase, conf)
+	productSearcher := ProvideProductSearcher(productRepository)
+	findAllProductUseCase := ProvideFindAllProductUseCase(productRepository, productSearcher, favoritesRepository)
 	findByIdProductUseCase := ProvideFindByIdProductUseCase(productRepository)
-	productHandler := ProvideProductHandler(findAllProductUseCase, findByIdProductUseCase)
-	createFavoriteUseCase := ProvideCreateFavoriteUseCase(favoritesRepository, customerRepository, productRepository)
-	deleteFavoriteUseCase := ProvideDeleteFavoriteUseCase(favoritesRepository, customerRepository, productRepository)
-	favoriteHandler := ProvideFavoriteHandler(createFavoriteUseCase, deleteFavoriteUseCase)
+	findCategoriesProductUseCase := ProvideFindCategoriesProductUseCase(productRepository)
+	productWriter := ProvideProductWriter(productRepository)
+	createProductUseCase := ProvideCreateProductUseCase(productWriter)
+	editProductUseCase := ProvideEditProductUseCase(productWriter)
+	deleteProductUseCase := ProvideDeleteProductUseCase(productWriter)
+	priceHistoryRepository := ProvidePriceHistoryRepository(db, queries)
+	findPriceHistoryUseCase := ProvideFindPriceHistoryUseCase(priceHistoryRepository, productRepository)
+	countFavoritesProductUseCase := ProvideCountFavoritesProductUseCase(productRepository, favoritesRepository)
+	mostFavoritedProductUseCase := ProvideMostFavoritedProductUseCase(productRepository, favoritesRepository)
+	productHandler := ProvideProductHandler(findAllProductUseCase, findByIdProductUseCase, findCategoriesProductUseCase, createProductUseCase, editProductUseCase, deleteProductUseCase, findPriceHistoryUseCase, countFavoritesProductUseCase, mostFavoritedProductUseCase)
+	webhookRepository := ProvideWebhookRepository(queries)
+	webhookDeliveryRepository := ProvideWebhookDeliveryRepository(queries)
+	dispatcher := ProvideWebhookDispatcher(webhookRepository, webhookDeliveryRepository, conf)
+	broker := ProvideEventBroker()
+	publisher := ProvideEventPublisher(dispatcher, broker)
+	createFavoriteUseCase := ProvideCreateFavoriteUseCase(favoritesRepository, customerRepository, productRepository, publisher, businessMetrics, conf)
+	deleteFavoriteUseCase := ProvideDeleteFavoriteUseCase(favoritesRepository, customerRepository, productRepository, publisher, businessMetrics)
+	subscriber := ProvideEventSubscriber(broker)
+	streamFavoriteUseCase := ProvideStreamFavoriteUseCase(customerRepository, subscriber)
+	findOrphanFavoritesUseCase := ProvideFindOrphanFavoritesUseCase(favoritesRepository, productRepository)
+	pruneOrphanFavoritesUseCase := ProvidePruneOrphanFavoritesUseCase(findOrphanFavoritesUseCase, favoritesRepository)
+	updateFavoriteUseCase := ProvideUpdateFavoriteUseCase(favoritesRepository, customerRepository)
+	findQuotaFavoriteUseCase := ProvideFindQuotaFavoriteUseCase(favoritesRepository, customerRepository, conf)
+	setQuotaFavoriteUseCase := ProvideSetQuotaFavoriteUseCase(favoritesRepository, customerRepository, conf)
+	favoriteHandler := ProvideFavoriteHandler(createFavoriteUseCase, deleteFavoriteUseCase, streamFavoriteUseCase, findOrphanFavoritesUseCase, pruneOrphanFavoritesUseCase, updateFavoriteUseCase, findQuotaFavoriteUseCase, setQuotaFavoriteUseCase)
+	collectionRepository := ProvideCollectionRepository(db, queries)
+	findAllCollectionUseCase := ProvideFindAllCollectionUseCase(customerRepository, collectionRepository)
+	findByIdCollectionUseCase := ProvideFindByIdCollectionUseCase(customerRepository, collectionRepository, productRepository)
+	createCollectionUseCase := ProvideCreateCollectionUseCase(customerRepository, collectionRepository)
+	editCollectionUseCase := ProvideEditCollectionUseCase(customerRepository, collectionRepository)
+	deleteCollectionUseCase := ProvideDeleteCollectionUseCase(customerRepository, collectionRepository)
+	addFavoriteCollectionUseCase := ProvideAddFavoriteCollectionUseCase(customerRepository, collectionRepository, productRepository, publisher, businessMetrics, conf)
+	removeFavoriteCollectionUseCase := ProvideRemoveFavoriteCollectionUseCase(customerRepository, collectionRepository, publisher, businessMetrics)
+	transferFavoriteCollectionUseCase := ProvideTransferFavoriteCollectionUseCase(customerRepository, collectionRepository, publisher, businessMetrics)
+	collectionHandler := ProvideCollectionHandler(findAllCollectionUseCase, findByIdCollectionUseCase, createCollectionUseCase, editCollectionUseCase, deleteCollectionUseCase, addFavoriteCollectionUseCase, removeFavoriteCollectionUseCase, transferFavoriteCollectionUseCase)
 	userRepository := ProvideUserRepository(queries)
 	string2 := ProvideJWTSecret(conf)
-	loginUseCase := ProvideLoginUseCase(userRepository, string2)
-	refreshTokenUseCase := ProvideRefreshTokenUseCase(userRepository, string2)
+	loginUseCase := ProvideLoginUseCase(conf, userRepository, string2, businessMetrics)
+	refreshTokenUseCase := ProvideRefreshTokenUseCase(conf, userRepository, string2)
 	authHandler := ProvideAuthHandler(loginUseCase, refreshTokenUseCase)
-	routerRouter := ProvideRouter(customerHandler, productHandler, favoriteHandler, authHandler, string2)
-	return routerRouter, nil
+	createWebhookUseCase := ProvideCreateWebhookUseCase(webhookRepository)
+	findAllWebhookUseCase := ProvideFindAllWebhookUseCase(webhookRepository)
+	findByIdWebhookUseCase := ProvideFindByIdWebhookUseCase(webhookRepository)
+	editWebhookUseCase := ProvideEditWebhookUseCase(webhookRepository)
+	deleteWebhookUseCase := ProvideDeleteWebhookUseCase(webhookRepository)
+	findDeliveriesWebhookUseCase := ProvideFindDeliveriesWebhookUseCase(webhookRepository, webhookDeliveryRepository)
+	webhookSender := ProvideWebhookSender(dispatcher)
+	redeliverWebhookUseCase := ProvideRedeliverWebhookUseCase(webhookRepository, webhookDeliveryRepository, webhookSender)
+	webhookHandler := ProvideWebhookHandler(createWebhookUseCase, findAllWebhookUseCase, findByIdWebhookUseCase, editWebhookUseCase, deleteWebhookUseCase, findDeliveriesWebhookUseCase, redeliverWebhookUseCase)
+	v := ProvideHealthCheckers(db, conf)
+	readinessUseCase := ProvideReadinessUseCase(v)
+	healthHandler := ProvideHealthHandler(readinessUseCase)
+	router := ProvideRouter(customerHandler, productHandler, favoriteHandler, collectionHandler, authHandler, webhookHandler, healthHandler, string2, conf)
+	catalogRepository := ProvideCatalogRepository(db, queries)
+	syncCatalogUseCase := ProvideSyncCatalogUseCase(client, catalogRepository)
+	priceDropNotifier, err := ProvidePriceDropNotifier(conf, publisher)
+	if err != nil {
+		return nil, err
+	}
+	trackPricesUseCase := ProvideTrackPricesUseCase(productRepository, priceHistoryRepository, favoritesRepository, priceDropNotifier)
+	refreshRecommendationsUseCase := ProvideRefreshRecommendationsUseCase(recommendationRepository)
+	v2 := ProvideSchedulers(conf, dispatcher, syncCatalogUseCase, trackPricesUseCase, refreshRecommendationsUseCase)
+	app := ProvideApp(router, dispatcher, broker, v2)
+	return app, nil
+}
+
+// InitializeSync builds only what the sync command needs to import the catalog.
+func InitializeSync(db *pgxpool.Pool, conf *config.Conf) (*catalog.SyncCatalogUseCase, error) {
+	client := ProvideFakestoreapiClient(conf)
+	queries := ProvideQueries(db)
+	catalogRepository := ProvideCatalogRepository(db, queries)
+	syncCatalogUseCase := ProvideSyncCatalogUseCase(client, catalogRepository)
+	return syncCatalogUseCase, nil
+}
+
+// InitializeSeed builds only what the seed command needs to fill a development database.
+func InitializeSeed(db *pgxpool.Pool, conf *config.Conf) (*seed.SeedUseCase, error) {
+	queries := ProvideQueries(db)
+	userRepository := ProvideUserRepository(queries)
+	customerRepository := ProvideCustomerRepository(queries)
+	favoritesRepository := ProvideFavoritesRepository(db, queries)
+	client := ProvideFakestoreapiClient(conf)
+	productRepository, err := ProvideProductRepository(conf, client, queries)
+	if err != nil {
+		return nil, err
+	}
+	seedUseCase := ProvideSeedUseCase(conf, userRepository, customerRepository, favoritesRepository, productRepository)
+	return seedUseCase, nil
+}
+
+// InitializeCustomerImport builds only what the customers command needs to import customers.
+func InitializeCustomerImport(db *pgxpool.Pool, conf *config.Conf) (*customer.ImportCustomersUseCase, error) {
+	queries := ProvideQueries(db)
+	customerRepository := ProvideCustomerRepository(queries)
+	businessMetrics := ProvideBusinessMetrics()
+	importCustomersUseCase := ProvideImportCustomersUseCase(customerRepository, businessMetrics)
+	return importCustomersUseCase, nil
+}
+
+// InitializeCustomerExport builds only what the customers command needs to export customers.
+func InitializeCustomerExport(db *pgxpool.Pool, conf *config.Conf) (*customer.ExportCustomersUseCase, error) {
+	queries := ProvideQueries(db)
+	customerRepository := ProvideCustomerRepository(queries)
+	exportCustomersUseCase := ProvideExportCustomersUseCase(customerRepository)
+	return exportCustomersUseCase, nil
 }